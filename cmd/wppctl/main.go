@@ -6,11 +6,15 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	wppv1 "github.com/matheus3301/wpp/gen/wpp/v1"
 	"github.com/matheus3301/wpp/internal/session"
 	"github.com/matheus3301/wpp/internal/tui/client"
+	"google.golang.org/protobuf/proto"
 )
 
 func main() {
@@ -52,6 +56,58 @@ func main() {
 			os.Exit(1)
 		}
 		cmdSync(ctx, c, args[1], *jsonFlag)
+	case "messages":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "usage: wppctl messages <chat-jid>")
+			os.Exit(1)
+		}
+		cmdMessages(ctx, c, args[1], *jsonFlag)
+	case "search":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "usage: wppctl search <query>")
+			os.Exit(1)
+		}
+		cmdSearch(ctx, c, strings.Join(args[1:], " "), *jsonFlag)
+	case "download":
+		if len(args) < 3 {
+			fmt.Fprintln(os.Stderr, "usage: wppctl download <chat-jid> <msg-id>")
+			os.Exit(1)
+		}
+		// Large attachments can take longer than the default RPC timeout.
+		dlCtx, dlCancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer dlCancel()
+		cmdDownload(dlCtx, c, args[1], args[2], *jsonFlag)
+	case "send":
+		cmdSend(ctx, c, args[1:], *jsonFlag)
+	case "scheduled":
+		chatJID := ""
+		if len(args) >= 2 {
+			chatJID = args[1]
+		}
+		cmdScheduled(ctx, c, chatJID, *jsonFlag)
+	case "retry", "cancel":
+		if len(args) < 2 {
+			fmt.Fprintf(os.Stderr, "usage: wppctl %s <msg-id>\n", args[0])
+			os.Exit(1)
+		}
+		cmdOutbox(ctx, c, args[0], args[1], *jsonFlag)
+	case "message":
+		switch {
+		case len(args) >= 5 && args[1] == "edit":
+			cmdMessageEdit(ctx, c, args[2], args[3], strings.Join(args[4:], " "), *jsonFlag)
+		case len(args) == 4 && args[1] == "delete":
+			cmdMessageDelete(ctx, c, args[2], args[3], *jsonFlag)
+		default:
+			fmt.Fprintln(os.Stderr, "usage: wppctl message edit <chat-jid> <msg-id> <text>")
+			fmt.Fprintln(os.Stderr, "       wppctl message delete <chat-jid> <msg-id>")
+			os.Exit(1)
+		}
+	case "backfill":
+		cmdBackfill(c, args[1:], *jsonFlag)
+	case "chat":
+		cmdChat(ctx, c, args[1:], *jsonFlag)
+	case "stats":
+		cmdStats(ctx, c, *jsonFlag)
 	case "sessions":
 		if len(args) >= 2 && args[1] == "list" {
 			cmdSessionsList(ctx, c, *jsonFlag)
@@ -75,6 +131,20 @@ func printUsage() {
 	fmt.Fprintln(os.Stderr, "  sync start       Start sync")
 	fmt.Fprintln(os.Stderr, "  sync stop        Stop sync")
 	fmt.Fprintln(os.Stderr, "  sync status      Show sync status")
+	fmt.Fprintln(os.Stderr, "  messages <jid>   List recent messages with delivery status")
+	fmt.Fprintln(os.Stderr, "  search <query>   Search messages (from: in: after: before: has: type:)")
+	fmt.Fprintln(os.Stderr, "  download <jid> <msg-id>  Download a message attachment and print its path")
+	fmt.Fprintln(os.Stderr, "  send [--at <time>] <jid> <text>  Send a message, or schedule it (15:04, 2006-01-02 15:04, RFC 3339 or 2h30m from now)")
+	fmt.Fprintln(os.Stderr, "  send --file <path> [--type <type>] <jid> [caption]  Send a file (image, video, audio, voice, document, sticker)")
+	fmt.Fprintln(os.Stderr, "  scheduled [jid]  List messages scheduled for later")
+	fmt.Fprintln(os.Stderr, "  retry <msg-id>   Resend a failed or scheduled message now")
+	fmt.Fprintln(os.Stderr, "  cancel <msg-id>  Drop a queued, scheduled or failed message")
+	fmt.Fprintln(os.Stderr, "  message edit <jid> <msg-id> <text>  Edit a message we sent (within 20 minutes)")
+	fmt.Fprintln(os.Stderr, "  message delete <jid> <msg-id>       Delete a message we sent for everyone")
+	fmt.Fprintln(os.Stderr, "  backfill [--count <n>] [--wait] <jid>  Ask the phone for messages older than the oldest stored")
+	fmt.Fprintln(os.Stderr, "  chat <archive|unarchive|pin|unpin|unmute> <jid>  Organize a chat on WhatsApp")
+	fmt.Fprintln(os.Stderr, "  chat mute [--for <duration>] <jid>  Mute a chat, indefinitely without --for (e.g. 8h)")
+	fmt.Fprintln(os.Stderr, "  stats            Show event bus delivery counters per subscriber")
 	fmt.Fprintln(os.Stderr, "  sessions list    List known sessions")
 }
 
@@ -143,12 +213,425 @@ func cmdSync(ctx context.Context, c *client.Client, subcmd string, jsonOut bool)
 		fmt.Printf("Syncing: %v\n", resp.Syncing)
 		fmt.Printf("Messages synced: %d\n", resp.MessagesSynced)
 		fmt.Printf("Chats synced: %d\n", resp.ChatsSynced)
+		if resp.HistorySyncType != "" {
+			fmt.Printf("History sync: %s, %d%%\n", resp.HistorySyncType, resp.HistoryProgress)
+		}
+		if resp.LastSyncAtUnixMs > 0 {
+			fmt.Printf("Last sync: %s\n", time.UnixMilli(resp.LastSyncAtUnixMs).Format("2006-01-02 15:04"))
+		}
 	default:
 		fmt.Fprintf(os.Stderr, "unknown sync subcommand: %s\n", subcmd)
 		os.Exit(1)
 	}
 }
 
+func cmdMessages(ctx context.Context, c *client.Client, chatJID string, jsonOut bool) {
+	resp, err := c.Message.ListMessages(ctx, &wppv1.ListMessagesRequest{
+		ChatJid:    chatJID,
+		Pagination: &wppv1.Pagination{Limit: 20},
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if jsonOut {
+		outputJSON(resp)
+		return
+	}
+	// Messages come newest first; print oldest first.
+	for i := len(resp.Messages) - 1; i >= 0; i-- {
+		m := resp.Messages[i]
+		sender := m.SenderName
+		if m.FromMe {
+			sender = "You"
+		}
+		ts := time.UnixMilli(m.TimestampUnixMs).Format("2006-01-02 15:04")
+		body := m.Body
+		if m.Media != nil {
+			label := strings.TrimSpace(m.Media.MediaType + " " + m.Media.FileName)
+			body = strings.TrimSpace("[" + label + "] " + body)
+		}
+		if m.Status == "failed" {
+			// The id to pass to retry or cancel.
+			body += "  [" + m.Id + "]"
+		}
+		fmt.Printf("%s  %-10s %-20s %s\n", ts, m.Status, sender, body)
+	}
+}
+
+func cmdSearch(ctx context.Context, c *client.Client, query string, jsonOut bool) {
+	resp, err := c.Message.SearchMessages(ctx, &wppv1.SearchMessagesRequest{
+		Query:      query,
+		Pagination: &wppv1.Pagination{Limit: 20},
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if jsonOut {
+		outputJSON(resp)
+		return
+	}
+	if len(resp.Results) == 0 {
+		fmt.Println("No messages found.")
+		return
+	}
+	for _, r := range resp.Results {
+		m := r.Message
+		sender := m.SenderName
+		if m.FromMe {
+			sender = "You"
+		}
+		ts := time.UnixMilli(m.TimestampUnixMs).Format("2006-01-02 15:04")
+		fmt.Printf("%s  %-28s %-20s %s\n", ts, m.ChatJid, sender, r.Snippet)
+	}
+}
+
+func cmdDownload(ctx context.Context, c *client.Client, chatJID, msgID string, jsonOut bool) {
+	resp, err := c.Message.DownloadMedia(ctx, &wppv1.DownloadMediaRequest{ChatJid: chatJID, MsgId: msgID})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if jsonOut {
+		outputJSON(resp)
+		return
+	}
+	fmt.Println(resp.Path)
+}
+
+func cmdSend(ctx context.Context, c *client.Client, args []string, jsonOut bool) {
+	fs := flag.NewFlagSet("send", flag.ExitOnError)
+	at := fs.String("at", "", "send at this time instead of now")
+	file := fs.String("file", "", "send this file, with the text as caption")
+	mediaType := fs.String("type", "", "send the file as image, video, audio, voice, document or sticker")
+	_ = fs.Parse(args)
+	if *file != "" {
+		if fs.NArg() < 1 || *at != "" {
+			fmt.Fprintln(os.Stderr, "usage: wppctl send --file <path> [--type <type>] <chat-jid> [caption]")
+			os.Exit(1)
+		}
+		cmdSendFile(ctx, c, fs.Arg(0), *file, *mediaType, strings.Join(fs.Args()[1:], " "), jsonOut)
+		return
+	}
+	if fs.NArg() < 2 {
+		fmt.Fprintln(os.Stderr, "usage: wppctl send [--at <time>] <chat-jid> <text>")
+		os.Exit(1)
+	}
+
+	req := &wppv1.SendTextRequest{
+		ClientMsgId: uuid.New().String(),
+		ChatJid:     fs.Arg(0),
+		Text:        strings.Join(fs.Args()[1:], " "),
+	}
+	if *at != "" {
+		sendAt, err := parseSendAt(*at, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: --at: %v\n", err)
+			os.Exit(1)
+		}
+		req.SendAtUnixMs = sendAt.UnixMilli()
+	}
+	resp, err := c.Message.SendText(ctx, req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if jsonOut {
+		outputJSON(resp)
+		return
+	}
+	if req.SendAtUnixMs != 0 {
+		fmt.Printf("Message %s scheduled for %s.\n", req.ClientMsgId, time.UnixMilli(req.SendAtUnixMs).Format("2006-01-02 15:04"))
+	} else {
+		fmt.Printf("Message %s %s.\n", req.ClientMsgId, resp.Message)
+	}
+}
+
+// cmdBackfill requests older history of a chat. With --wait it follows the
+// sync events until the phone answers, which can take a while.
+func cmdBackfill(c *client.Client, args []string, jsonOut bool) {
+	fs := flag.NewFlagSet("backfill", flag.ExitOnError)
+	count := fs.Int("count", 0, "messages to ask for (default 50)")
+	wait := fs.Bool("wait", false, "wait for the phone to send them")
+	_ = fs.Parse(args)
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: wppctl backfill [--count <n>] [--wait] <chat-jid>")
+		os.Exit(1)
+	}
+
+	timeout := 10 * time.Second
+	if *wait {
+		timeout = 2 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Watch before requesting so a quick answer is not missed.
+	var stream wppv1.SyncService_WatchSyncEventsClient
+	if *wait {
+		var err error
+		if stream, err = c.Sync.WatchSyncEvents(ctx, &wppv1.WatchSyncEventsRequest{}); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	resp, err := c.Sync.BackfillChat(ctx, &wppv1.BackfillChatRequest{ChatJid: fs.Arg(0), Count: int32(*count)})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if stream == nil {
+		if jsonOut {
+			outputJSON(resp)
+			return
+		}
+		fmt.Printf("Requested history older than %s (request %s).\n", resp.OldestMsgId, resp.RequestId)
+		return
+	}
+
+	for {
+		evt, err := stream.Recv()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: waiting for request %s: %v\n", resp.RequestId, err)
+			os.Exit(1)
+		}
+		if evt.Kind != "sync.history_batch" || evt.CorrelationId != resp.RequestId {
+			continue
+		}
+		var batch wppv1.SyncHistoryBatch
+		if err := proto.Unmarshal(evt.Payload, &batch); err != nil {
+			fmt.Fprintf(os.Stderr, "error: decode event: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOut {
+			outputJSON(&batch)
+			return
+		}
+		fmt.Printf("Received %d older messages.\n", batch.MessagesCount)
+		return
+	}
+}
+
+// cmdSendFile sends a file as an attachment. The daemon reads the file, so
+// its path is made absolute first.
+func cmdSendFile(ctx context.Context, c *client.Client, chatJID, path, mediaType, caption string, jsonOut bool) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	clientMsgID := uuid.New().String()
+	resp, err := c.Message.SendMedia(ctx, &wppv1.SendMediaRequest{
+		ClientMsgId: clientMsgID,
+		ChatJid:     chatJID,
+		Path:        abs,
+		Caption:     caption,
+		MediaType:   mediaType,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if jsonOut {
+		outputJSON(resp)
+		return
+	}
+	fmt.Printf("Message %s %s.\n", clientMsgID, resp.Message)
+}
+
+// parseSendAt parses the --at time of send: a clock time (the next one to
+// come), a local date and time, an RFC 3339 timestamp, or a duration from
+// now.
+func parseSendAt(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.ParseInLocation("15:04", s, time.Local); err == nil {
+		at := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, time.Local)
+		if !at.After(now) {
+			at = at.AddDate(0, 0, 1)
+		}
+		return at, nil
+	}
+	return time.Time{}, fmt.Errorf("want 15:04, 2006-01-02 15:04, RFC 3339 or a duration, got %q", s)
+}
+
+func cmdScheduled(ctx context.Context, c *client.Client, chatJID string, jsonOut bool) {
+	resp, err := c.Message.ListScheduled(ctx, &wppv1.ListScheduledRequest{ChatJid: chatJID})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if jsonOut {
+		outputJSON(resp)
+		return
+	}
+	if len(resp.Messages) == 0 {
+		fmt.Println("No scheduled messages.")
+		return
+	}
+	for _, m := range resp.Messages {
+		ts := time.UnixMilli(m.SendAtUnixMs).Format("2006-01-02 15:04")
+		fmt.Printf("%s  %-30s %s  [%s]\n", ts, m.ChatJid, m.Text, m.ClientMsgId)
+	}
+}
+
+func cmdOutbox(ctx context.Context, c *client.Client, action, msgID string, jsonOut bool) {
+	var resp any
+	var err error
+	if action == "retry" {
+		resp, err = c.Message.RetryMessage(ctx, &wppv1.RetryMessageRequest{ClientMsgId: msgID})
+	} else {
+		resp, err = c.Message.CancelMessage(ctx, &wppv1.CancelMessageRequest{ClientMsgId: msgID})
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if jsonOut {
+		outputJSON(resp)
+		return
+	}
+	if action == "retry" {
+		fmt.Printf("Message %s queued for sending.\n", msgID)
+	} else {
+		fmt.Printf("Message %s cancelled.\n", msgID)
+	}
+}
+
+func cmdMessageEdit(ctx context.Context, c *client.Client, chatJID, msgID, text string, jsonOut bool) {
+	resp, err := c.Message.EditMessage(ctx, &wppv1.EditMessageRequest{
+		ClientMsgId: uuid.New().String(),
+		ChatJid:     chatJID,
+		MsgId:       msgID,
+		Text:        text,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if jsonOut {
+		outputJSON(resp)
+		return
+	}
+	fmt.Printf("Edit of message %s %s.\n", msgID, resp.Message)
+}
+
+func cmdMessageDelete(ctx context.Context, c *client.Client, chatJID, msgID string, jsonOut bool) {
+	resp, err := c.Message.RevokeMessage(ctx, &wppv1.RevokeMessageRequest{
+		ClientMsgId: uuid.New().String(),
+		ChatJid:     chatJID,
+		MsgId:       msgID,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if jsonOut {
+		outputJSON(resp)
+		return
+	}
+	if resp.Message == "cancelled" {
+		fmt.Printf("Message %s was not sent yet and is cancelled.\n", msgID)
+		return
+	}
+	fmt.Printf("Deletion of message %s %s.\n", msgID, resp.Message)
+}
+
+// cmdChat archives, pins or mutes a chat on WhatsApp, or undoes it.
+func cmdChat(ctx context.Context, c *client.Client, args []string, jsonOut bool) {
+	usage := func() {
+		fmt.Fprintln(os.Stderr, "usage: wppctl chat <archive|unarchive|pin|unpin|unmute> <chat-jid>")
+		fmt.Fprintln(os.Stderr, "       wppctl chat mute [--for <duration>] <chat-jid>")
+		os.Exit(1)
+	}
+	if len(args) < 2 {
+		usage()
+	}
+	action := args[0]
+	fs := flag.NewFlagSet("chat "+action, flag.ExitOnError)
+	var muteFor *time.Duration
+	if action == "mute" {
+		muteFor = fs.Duration("for", 0, "how long to mute (default indefinitely)")
+	}
+	_ = fs.Parse(args[1:])
+	if fs.NArg() != 1 {
+		usage()
+	}
+	chatJID := fs.Arg(0)
+
+	var chat *wppv1.Chat
+	var err error
+	switch action {
+	case "archive", "unarchive":
+		var resp *wppv1.ArchiveChatResponse
+		resp, err = c.Chat.ArchiveChat(ctx, &wppv1.ArchiveChatRequest{ChatJid: chatJID, Archived: action == "archive"})
+		chat = resp.GetChat()
+	case "pin", "unpin":
+		var resp *wppv1.PinChatResponse
+		resp, err = c.Chat.PinChat(ctx, &wppv1.PinChatRequest{ChatJid: chatJID, Pinned: action == "pin"})
+		chat = resp.GetChat()
+	case "mute", "unmute":
+		req := &wppv1.MuteChatRequest{ChatJid: chatJID, Muted: action == "mute"}
+		if muteFor != nil {
+			req.DurationSeconds = int64(muteFor.Seconds())
+		}
+		var resp *wppv1.MuteChatResponse
+		resp, err = c.Chat.MuteChat(ctx, req)
+		chat = resp.GetChat()
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if jsonOut {
+		outputJSON(chat)
+		return
+	}
+	if action == "mute" && chat.MutedUntilUnixMs > 0 {
+		fmt.Printf("Chat %s muted until %s.\n", chatJID, time.UnixMilli(chat.MutedUntilUnixMs).Format("2006-01-02 15:04"))
+		return
+	}
+	done := map[string]string{
+		"archive": "archived", "unarchive": "unarchived",
+		"pin": "pinned", "unpin": "unpinned",
+		"mute": "muted", "unmute": "unmuted",
+	}
+	fmt.Printf("Chat %s %s.\n", chatJID, done[action])
+}
+
+func cmdStats(ctx context.Context, c *client.Client, jsonOut bool) {
+	resp, err := c.Session.GetBusStats(ctx, &wppv1.GetBusStatsRequest{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if jsonOut {
+		outputJSON(resp)
+		return
+	}
+	fmt.Printf("%-24s %-10s %-11s %10s %8s %7s %7s\n", "SUBSCRIBER", "NAMESPACE", "DELIVERY", "DELIVERED", "DROPPED", "QUEUED", "PEAK")
+	for _, s := range resp.Subscribers {
+		delivery := "best-effort"
+		if s.Lossless {
+			delivery = "lossless"
+		}
+		fmt.Printf("%-24s %-10s %-11s %10d %8d %7d %7d\n", s.Name, s.Namespace, delivery, s.Delivered, s.Dropped, s.Queued, s.MaxQueued)
+	}
+	fmt.Printf("\nTotal dropped: %d\n", resp.TotalDropped)
+}
+
 func cmdSessionsList(ctx context.Context, c *client.Client, jsonOut bool) {
 	resp, err := c.Session.ListSessions(ctx, &wppv1.ListSessionsRequest{})
 	if err != nil {
@@ -81,6 +81,9 @@ type Chat struct {
 	LastMessageAtUnixMs int64                  `protobuf:"varint,4,opt,name=last_message_at_unix_ms,json=lastMessageAtUnixMs,proto3" json:"last_message_at_unix_ms,omitempty"`
 	UnreadCount         int32                  `protobuf:"varint,5,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
 	IsGroup             bool                   `protobuf:"varint,6,opt,name=is_group,json=isGroup,proto3" json:"is_group,omitempty"`
+	Archived            bool                   `protobuf:"varint,7,opt,name=archived,proto3" json:"archived,omitempty"`
+	PinnedAtUnixMs      int64                  `protobuf:"varint,8,opt,name=pinned_at_unix_ms,json=pinnedAtUnixMs,proto3" json:"pinned_at_unix_ms,omitempty"`       // 0 when not pinned
+	MutedUntilUnixMs    int64                  `protobuf:"varint,9,opt,name=muted_until_unix_ms,json=mutedUntilUnixMs,proto3" json:"muted_until_unix_ms,omitempty"` // 0 when not muted, -1 when muted indefinitely
 	unknownFields       protoimpl.UnknownFields
 	sizeCache           protoimpl.SizeCache
 }
@@ -157,6 +160,27 @@ func (x *Chat) GetIsGroup() bool {
 	return false
 }
 
+func (x *Chat) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Chat) GetPinnedAtUnixMs() int64 {
+	if x != nil {
+		return x.PinnedAtUnixMs
+	}
+	return 0
+}
+
+func (x *Chat) GetMutedUntilUnixMs() int64 {
+	if x != nil {
+		return x.MutedUntilUnixMs
+	}
+	return 0
+}
+
 type ListChatsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Chats         []*Chat                `protobuf:"bytes,1,rep,name=chats,proto3" json:"chats,omitempty"`
@@ -341,36 +365,849 @@ func (x *WatchChatUpdatesRequest) GetCursor() string {
 	return ""
 }
 
-var File_wpp_v1_chat_proto protoreflect.FileDescriptor
+// WatchPresenceRequest streams presence.* events. Presence is not
+// journaled: the stream starts at the live tail and cannot be resumed.
+// We show as online to contacts while any presence stream is open.
+type WatchPresenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatJid       string                 `protobuf:"bytes,1,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"` // optional: only this chat; for a direct chat, also the contact's online state
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_wpp_v1_chat_proto_rawDesc = "" +
-	"\n" +
-	"\x11wpp/v1/chat.proto\x12\x06wpp.v1\x1a\x13wpp/v1/common.proto\"^\n" +
-	"\x10ListChatsRequest\x122\n" +
-	"\n" +
-	"pagination\x18\x01 \x01(\v2\x12.wpp.v1.PaginationR\n" +
-	"pagination\x12\x16\n" +
-	"\x06filter\x18\x02 \x01(\tR\x06filter\"\xd2\x01\n" +
-	"\x04Chat\x12\x10\n" +
-	"\x03jid\x18\x01 \x01(\tR\x03jid\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x120\n" +
-	"\x14last_message_preview\x18\x03 \x01(\tR\x12lastMessagePreview\x124\n" +
-	"\x17last_message_at_unix_ms\x18\x04 \x01(\x03R\x13lastMessageAtUnixMs\x12!\n" +
-	"\funread_count\x18\x05 \x01(\x05R\vunreadCount\x12\x19\n" +
-	"\bis_group\x18\x06 \x01(\bR\aisGroup\"f\n" +
-	"\x11ListChatsResponse\x12\"\n" +
-	"\x05chats\x18\x01 \x03(\v2\f.wpp.v1.ChatR\x05chats\x12-\n" +
-	"\tpage_info\x18\x02 \x01(\v2\x10.wpp.v1.PageInfoR\bpageInfo\"\"\n" +
-	"\x0eGetChatRequest\x12\x10\n" +
-	"\x03jid\x18\x01 \x01(\tR\x03jid\"3\n" +
-	"\x0fGetChatResponse\x12 \n" +
-	"\x04chat\x18\x01 \x01(\v2\f.wpp.v1.ChatR\x04chat\"1\n" +
-	"\x17WatchChatUpdatesRequest\x12\x16\n" +
-	"\x06cursor\x18\x01 \x01(\tR\x06cursor2\xd9\x01\n" +
+func (x *WatchPresenceRequest) Reset() {
+	*x = WatchPresenceRequest{}
+	mi := &file_wpp_v1_chat_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPresenceRequest) ProtoMessage() {}
+
+func (x *WatchPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_chat_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPresenceRequest.ProtoReflect.Descriptor instead.
+func (*WatchPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_chat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WatchPresenceRequest) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+type SendChatPresenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatJid       string                 `protobuf:"bytes,1,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"` // composing, recording, paused
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendChatPresenceRequest) Reset() {
+	*x = SendChatPresenceRequest{}
+	mi := &file_wpp_v1_chat_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendChatPresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendChatPresenceRequest) ProtoMessage() {}
+
+func (x *SendChatPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_chat_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendChatPresenceRequest.ProtoReflect.Descriptor instead.
+func (*SendChatPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_chat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SendChatPresenceRequest) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+func (x *SendChatPresenceRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type SendChatPresenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendChatPresenceResponse) Reset() {
+	*x = SendChatPresenceResponse{}
+	mi := &file_wpp_v1_chat_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendChatPresenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendChatPresenceResponse) ProtoMessage() {}
+
+func (x *SendChatPresenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_chat_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendChatPresenceResponse.ProtoReflect.Descriptor instead.
+func (*SendChatPresenceResponse) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_chat_proto_rawDescGZIP(), []int{8}
+}
+
+type MarkReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatJid       string                 `protobuf:"bytes,1,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadRequest) Reset() {
+	*x = MarkReadRequest{}
+	mi := &file_wpp_v1_chat_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadRequest) ProtoMessage() {}
+
+func (x *MarkReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_chat_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkReadRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_chat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MarkReadRequest) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+type MarkReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MarkedCount   int32                  `protobuf:"varint,1,opt,name=marked_count,json=markedCount,proto3" json:"marked_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadResponse) Reset() {
+	*x = MarkReadResponse{}
+	mi := &file_wpp_v1_chat_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadResponse) ProtoMessage() {}
+
+func (x *MarkReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_chat_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkReadResponse) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_chat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MarkReadResponse) GetMarkedCount() int32 {
+	if x != nil {
+		return x.MarkedCount
+	}
+	return 0
+}
+
+// ArchiveChatRequest archives or unarchives a chat on WhatsApp. Archiving
+// also unpins it.
+type ArchiveChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatJid       string                 `protobuf:"bytes,1,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	Archived      bool                   `protobuf:"varint,2,opt,name=archived,proto3" json:"archived,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveChatRequest) Reset() {
+	*x = ArchiveChatRequest{}
+	mi := &file_wpp_v1_chat_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveChatRequest) ProtoMessage() {}
+
+func (x *ArchiveChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_chat_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveChatRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveChatRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_chat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ArchiveChatRequest) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+func (x *ArchiveChatRequest) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+type ArchiveChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chat          *Chat                  `protobuf:"bytes,1,opt,name=chat,proto3" json:"chat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveChatResponse) Reset() {
+	*x = ArchiveChatResponse{}
+	mi := &file_wpp_v1_chat_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveChatResponse) ProtoMessage() {}
+
+func (x *ArchiveChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_chat_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveChatResponse.ProtoReflect.Descriptor instead.
+func (*ArchiveChatResponse) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_chat_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ArchiveChatResponse) GetChat() *Chat {
+	if x != nil {
+		return x.Chat
+	}
+	return nil
+}
+
+type PinChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatJid       string                 `protobuf:"bytes,1,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	Pinned        bool                   `protobuf:"varint,2,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinChatRequest) Reset() {
+	*x = PinChatRequest{}
+	mi := &file_wpp_v1_chat_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinChatRequest) ProtoMessage() {}
+
+func (x *PinChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_chat_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinChatRequest.ProtoReflect.Descriptor instead.
+func (*PinChatRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_chat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PinChatRequest) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+func (x *PinChatRequest) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+type PinChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chat          *Chat                  `protobuf:"bytes,1,opt,name=chat,proto3" json:"chat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinChatResponse) Reset() {
+	*x = PinChatResponse{}
+	mi := &file_wpp_v1_chat_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinChatResponse) ProtoMessage() {}
+
+func (x *PinChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_chat_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinChatResponse.ProtoReflect.Descriptor instead.
+func (*PinChatResponse) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_chat_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PinChatResponse) GetChat() *Chat {
+	if x != nil {
+		return x.Chat
+	}
+	return nil
+}
+
+type MuteChatRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ChatJid         string                 `protobuf:"bytes,1,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	Muted           bool                   `protobuf:"varint,2,opt,name=muted,proto3" json:"muted,omitempty"`
+	DurationSeconds int64                  `protobuf:"varint,3,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // optional: 0 mutes indefinitely
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MuteChatRequest) Reset() {
+	*x = MuteChatRequest{}
+	mi := &file_wpp_v1_chat_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuteChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuteChatRequest) ProtoMessage() {}
+
+func (x *MuteChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_chat_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuteChatRequest.ProtoReflect.Descriptor instead.
+func (*MuteChatRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_chat_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MuteChatRequest) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+func (x *MuteChatRequest) GetMuted() bool {
+	if x != nil {
+		return x.Muted
+	}
+	return false
+}
+
+func (x *MuteChatRequest) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+type MuteChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chat          *Chat                  `protobuf:"bytes,1,opt,name=chat,proto3" json:"chat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MuteChatResponse) Reset() {
+	*x = MuteChatResponse{}
+	mi := &file_wpp_v1_chat_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuteChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuteChatResponse) ProtoMessage() {}
+
+func (x *MuteChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_chat_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuteChatResponse.ProtoReflect.Descriptor instead.
+func (*MuteChatResponse) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_chat_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MuteChatResponse) GetChat() *Chat {
+	if x != nil {
+		return x.Chat
+	}
+	return nil
+}
+
+type GroupParticipant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jid           string                 `protobuf:"bytes,1,opt,name=jid,proto3" json:"jid,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IsAdmin       bool                   `protobuf:"varint,3,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
+	IsSuperAdmin  bool                   `protobuf:"varint,4,opt,name=is_super_admin,json=isSuperAdmin,proto3" json:"is_super_admin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupParticipant) Reset() {
+	*x = GroupParticipant{}
+	mi := &file_wpp_v1_chat_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupParticipant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupParticipant) ProtoMessage() {}
+
+func (x *GroupParticipant) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_chat_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupParticipant.ProtoReflect.Descriptor instead.
+func (*GroupParticipant) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_chat_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GroupParticipant) GetJid() string {
+	if x != nil {
+		return x.Jid
+	}
+	return ""
+}
+
+func (x *GroupParticipant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GroupParticipant) GetIsAdmin() bool {
+	if x != nil {
+		return x.IsAdmin
+	}
+	return false
+}
+
+func (x *GroupParticipant) GetIsSuperAdmin() bool {
+	if x != nil {
+		return x.IsSuperAdmin
+	}
+	return false
+}
+
+type GroupInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Jid             string                 `protobuf:"bytes,1,opt,name=jid,proto3" json:"jid,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Topic           string                 `protobuf:"bytes,3,opt,name=topic,proto3" json:"topic,omitempty"`
+	OwnerJid        string                 `protobuf:"bytes,4,opt,name=owner_jid,json=ownerJid,proto3" json:"owner_jid,omitempty"`
+	CreatedAtUnixMs int64                  `protobuf:"varint,5,opt,name=created_at_unix_ms,json=createdAtUnixMs,proto3" json:"created_at_unix_ms,omitempty"`
+	Participants    []*GroupParticipant    `protobuf:"bytes,6,rep,name=participants,proto3" json:"participants,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GroupInfo) Reset() {
+	*x = GroupInfo{}
+	mi := &file_wpp_v1_chat_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupInfo) ProtoMessage() {}
+
+func (x *GroupInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_chat_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupInfo.ProtoReflect.Descriptor instead.
+func (*GroupInfo) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_chat_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GroupInfo) GetJid() string {
+	if x != nil {
+		return x.Jid
+	}
+	return ""
+}
+
+func (x *GroupInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GroupInfo) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *GroupInfo) GetOwnerJid() string {
+	if x != nil {
+		return x.OwnerJid
+	}
+	return ""
+}
+
+func (x *GroupInfo) GetCreatedAtUnixMs() int64 {
+	if x != nil {
+		return x.CreatedAtUnixMs
+	}
+	return 0
+}
+
+func (x *GroupInfo) GetParticipants() []*GroupParticipant {
+	if x != nil {
+		return x.Participants
+	}
+	return nil
+}
+
+type GetGroupInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jid           string                 `protobuf:"bytes,1,opt,name=jid,proto3" json:"jid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGroupInfoRequest) Reset() {
+	*x = GetGroupInfoRequest{}
+	mi := &file_wpp_v1_chat_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGroupInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGroupInfoRequest) ProtoMessage() {}
+
+func (x *GetGroupInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_chat_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGroupInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetGroupInfoRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_chat_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetGroupInfoRequest) GetJid() string {
+	if x != nil {
+		return x.Jid
+	}
+	return ""
+}
+
+type GetGroupInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *GroupInfo             `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGroupInfoResponse) Reset() {
+	*x = GetGroupInfoResponse{}
+	mi := &file_wpp_v1_chat_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGroupInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGroupInfoResponse) ProtoMessage() {}
+
+func (x *GetGroupInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_chat_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGroupInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetGroupInfoResponse) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_chat_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetGroupInfoResponse) GetGroup() *GroupInfo {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+var File_wpp_v1_chat_proto protoreflect.FileDescriptor
+
+const file_wpp_v1_chat_proto_rawDesc = "" +
+	"\n" +
+	"\x11wpp/v1/chat.proto\x12\x06wpp.v1\x1a\x13wpp/v1/common.proto\"^\n" +
+	"\x10ListChatsRequest\x122\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2\x12.wpp.v1.PaginationR\n" +
+	"pagination\x12\x16\n" +
+	"\x06filter\x18\x02 \x01(\tR\x06filter\"\xc8\x02\n" +
+	"\x04Chat\x12\x10\n" +
+	"\x03jid\x18\x01 \x01(\tR\x03jid\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x120\n" +
+	"\x14last_message_preview\x18\x03 \x01(\tR\x12lastMessagePreview\x124\n" +
+	"\x17last_message_at_unix_ms\x18\x04 \x01(\x03R\x13lastMessageAtUnixMs\x12!\n" +
+	"\funread_count\x18\x05 \x01(\x05R\vunreadCount\x12\x19\n" +
+	"\bis_group\x18\x06 \x01(\bR\aisGroup\x12\x1a\n" +
+	"\barchived\x18\a \x01(\bR\barchived\x12)\n" +
+	"\x11pinned_at_unix_ms\x18\b \x01(\x03R\x0epinnedAtUnixMs\x12-\n" +
+	"\x13muted_until_unix_ms\x18\t \x01(\x03R\x10mutedUntilUnixMs\"f\n" +
+	"\x11ListChatsResponse\x12\"\n" +
+	"\x05chats\x18\x01 \x03(\v2\f.wpp.v1.ChatR\x05chats\x12-\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x10.wpp.v1.PageInfoR\bpageInfo\"\"\n" +
+	"\x0eGetChatRequest\x12\x10\n" +
+	"\x03jid\x18\x01 \x01(\tR\x03jid\"3\n" +
+	"\x0fGetChatResponse\x12 \n" +
+	"\x04chat\x18\x01 \x01(\v2\f.wpp.v1.ChatR\x04chat\"1\n" +
+	"\x17WatchChatUpdatesRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\"1\n" +
+	"\x14WatchPresenceRequest\x12\x19\n" +
+	"\bchat_jid\x18\x01 \x01(\tR\achatJid\"J\n" +
+	"\x17SendChatPresenceRequest\x12\x19\n" +
+	"\bchat_jid\x18\x01 \x01(\tR\achatJid\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\"\x1a\n" +
+	"\x18SendChatPresenceResponse\",\n" +
+	"\x0fMarkReadRequest\x12\x19\n" +
+	"\bchat_jid\x18\x01 \x01(\tR\achatJid\"5\n" +
+	"\x10MarkReadResponse\x12!\n" +
+	"\fmarked_count\x18\x01 \x01(\x05R\vmarkedCount\"K\n" +
+	"\x12ArchiveChatRequest\x12\x19\n" +
+	"\bchat_jid\x18\x01 \x01(\tR\achatJid\x12\x1a\n" +
+	"\barchived\x18\x02 \x01(\bR\barchived\"7\n" +
+	"\x13ArchiveChatResponse\x12 \n" +
+	"\x04chat\x18\x01 \x01(\v2\f.wpp.v1.ChatR\x04chat\"C\n" +
+	"\x0ePinChatRequest\x12\x19\n" +
+	"\bchat_jid\x18\x01 \x01(\tR\achatJid\x12\x16\n" +
+	"\x06pinned\x18\x02 \x01(\bR\x06pinned\"3\n" +
+	"\x0fPinChatResponse\x12 \n" +
+	"\x04chat\x18\x01 \x01(\v2\f.wpp.v1.ChatR\x04chat\"m\n" +
+	"\x0fMuteChatRequest\x12\x19\n" +
+	"\bchat_jid\x18\x01 \x01(\tR\achatJid\x12\x14\n" +
+	"\x05muted\x18\x02 \x01(\bR\x05muted\x12)\n" +
+	"\x10duration_seconds\x18\x03 \x01(\x03R\x0fdurationSeconds\"4\n" +
+	"\x10MuteChatResponse\x12 \n" +
+	"\x04chat\x18\x01 \x01(\v2\f.wpp.v1.ChatR\x04chat\"y\n" +
+	"\x10GroupParticipant\x12\x10\n" +
+	"\x03jid\x18\x01 \x01(\tR\x03jid\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
+	"\bis_admin\x18\x03 \x01(\bR\aisAdmin\x12$\n" +
+	"\x0eis_super_admin\x18\x04 \x01(\bR\fisSuperAdmin\"\xcf\x01\n" +
+	"\tGroupInfo\x12\x10\n" +
+	"\x03jid\x18\x01 \x01(\tR\x03jid\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05topic\x18\x03 \x01(\tR\x05topic\x12\x1b\n" +
+	"\towner_jid\x18\x04 \x01(\tR\bownerJid\x12+\n" +
+	"\x12created_at_unix_ms\x18\x05 \x01(\x03R\x0fcreatedAtUnixMs\x12<\n" +
+	"\fparticipants\x18\x06 \x03(\v2\x18.wpp.v1.GroupParticipantR\fparticipants\"'\n" +
+	"\x13GetGroupInfoRequest\x12\x10\n" +
+	"\x03jid\x18\x01 \x01(\tR\x03jid\"?\n" +
+	"\x14GetGroupInfoResponse\x12'\n" +
+	"\x05group\x18\x01 \x01(\v2\x11.wpp.v1.GroupInfoR\x05group2\xc5\x05\n" +
 	"\vChatService\x12@\n" +
 	"\tListChats\x12\x18.wpp.v1.ListChatsRequest\x1a\x19.wpp.v1.ListChatsResponse\x12:\n" +
 	"\aGetChat\x12\x16.wpp.v1.GetChatRequest\x1a\x17.wpp.v1.GetChatResponse\x12L\n" +
-	"\x10WatchChatUpdates\x12\x1f.wpp.v1.WatchChatUpdatesRequest\x1a\x15.wpp.v1.EventEnvelope0\x01B-Z+github.com/matheus3301/wpp/gen/wpp/v1;wppv1b\x06proto3"
+	"\x10WatchChatUpdates\x12\x1f.wpp.v1.WatchChatUpdatesRequest\x1a\x15.wpp.v1.EventEnvelope0\x01\x12=\n" +
+	"\bMarkRead\x12\x17.wpp.v1.MarkReadRequest\x1a\x18.wpp.v1.MarkReadResponse\x12I\n" +
+	"\fGetGroupInfo\x12\x1b.wpp.v1.GetGroupInfoRequest\x1a\x1c.wpp.v1.GetGroupInfoResponse\x12F\n" +
+	"\rWatchPresence\x12\x1c.wpp.v1.WatchPresenceRequest\x1a\x15.wpp.v1.EventEnvelope0\x01\x12U\n" +
+	"\x10SendChatPresence\x12\x1f.wpp.v1.SendChatPresenceRequest\x1a .wpp.v1.SendChatPresenceResponse\x12F\n" +
+	"\vArchiveChat\x12\x1a.wpp.v1.ArchiveChatRequest\x1a\x1b.wpp.v1.ArchiveChatResponse\x12:\n" +
+	"\aPinChat\x12\x16.wpp.v1.PinChatRequest\x1a\x17.wpp.v1.PinChatResponse\x12=\n" +
+	"\bMuteChat\x12\x17.wpp.v1.MuteChatRequest\x1a\x18.wpp.v1.MuteChatResponseB-Z+github.com/matheus3301/wpp/gen/wpp/v1;wppv1b\x06proto3"
 
 var (
 	file_wpp_v1_chat_proto_rawDescOnce sync.Once
@@ -384,34 +1221,68 @@ func file_wpp_v1_chat_proto_rawDescGZIP() []byte {
 	return file_wpp_v1_chat_proto_rawDescData
 }
 
-var file_wpp_v1_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_wpp_v1_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_wpp_v1_chat_proto_goTypes = []any{
-	(*ListChatsRequest)(nil),        // 0: wpp.v1.ListChatsRequest
-	(*Chat)(nil),                    // 1: wpp.v1.Chat
-	(*ListChatsResponse)(nil),       // 2: wpp.v1.ListChatsResponse
-	(*GetChatRequest)(nil),          // 3: wpp.v1.GetChatRequest
-	(*GetChatResponse)(nil),         // 4: wpp.v1.GetChatResponse
-	(*WatchChatUpdatesRequest)(nil), // 5: wpp.v1.WatchChatUpdatesRequest
-	(*Pagination)(nil),              // 6: wpp.v1.Pagination
-	(*PageInfo)(nil),                // 7: wpp.v1.PageInfo
-	(*EventEnvelope)(nil),           // 8: wpp.v1.EventEnvelope
+	(*ListChatsRequest)(nil),         // 0: wpp.v1.ListChatsRequest
+	(*Chat)(nil),                     // 1: wpp.v1.Chat
+	(*ListChatsResponse)(nil),        // 2: wpp.v1.ListChatsResponse
+	(*GetChatRequest)(nil),           // 3: wpp.v1.GetChatRequest
+	(*GetChatResponse)(nil),          // 4: wpp.v1.GetChatResponse
+	(*WatchChatUpdatesRequest)(nil),  // 5: wpp.v1.WatchChatUpdatesRequest
+	(*WatchPresenceRequest)(nil),     // 6: wpp.v1.WatchPresenceRequest
+	(*SendChatPresenceRequest)(nil),  // 7: wpp.v1.SendChatPresenceRequest
+	(*SendChatPresenceResponse)(nil), // 8: wpp.v1.SendChatPresenceResponse
+	(*MarkReadRequest)(nil),          // 9: wpp.v1.MarkReadRequest
+	(*MarkReadResponse)(nil),         // 10: wpp.v1.MarkReadResponse
+	(*ArchiveChatRequest)(nil),       // 11: wpp.v1.ArchiveChatRequest
+	(*ArchiveChatResponse)(nil),      // 12: wpp.v1.ArchiveChatResponse
+	(*PinChatRequest)(nil),           // 13: wpp.v1.PinChatRequest
+	(*PinChatResponse)(nil),          // 14: wpp.v1.PinChatResponse
+	(*MuteChatRequest)(nil),          // 15: wpp.v1.MuteChatRequest
+	(*MuteChatResponse)(nil),         // 16: wpp.v1.MuteChatResponse
+	(*GroupParticipant)(nil),         // 17: wpp.v1.GroupParticipant
+	(*GroupInfo)(nil),                // 18: wpp.v1.GroupInfo
+	(*GetGroupInfoRequest)(nil),      // 19: wpp.v1.GetGroupInfoRequest
+	(*GetGroupInfoResponse)(nil),     // 20: wpp.v1.GetGroupInfoResponse
+	(*Pagination)(nil),               // 21: wpp.v1.Pagination
+	(*PageInfo)(nil),                 // 22: wpp.v1.PageInfo
+	(*EventEnvelope)(nil),            // 23: wpp.v1.EventEnvelope
 }
 var file_wpp_v1_chat_proto_depIdxs = []int32{
-	6, // 0: wpp.v1.ListChatsRequest.pagination:type_name -> wpp.v1.Pagination
-	1, // 1: wpp.v1.ListChatsResponse.chats:type_name -> wpp.v1.Chat
-	7, // 2: wpp.v1.ListChatsResponse.page_info:type_name -> wpp.v1.PageInfo
-	1, // 3: wpp.v1.GetChatResponse.chat:type_name -> wpp.v1.Chat
-	0, // 4: wpp.v1.ChatService.ListChats:input_type -> wpp.v1.ListChatsRequest
-	3, // 5: wpp.v1.ChatService.GetChat:input_type -> wpp.v1.GetChatRequest
-	5, // 6: wpp.v1.ChatService.WatchChatUpdates:input_type -> wpp.v1.WatchChatUpdatesRequest
-	2, // 7: wpp.v1.ChatService.ListChats:output_type -> wpp.v1.ListChatsResponse
-	4, // 8: wpp.v1.ChatService.GetChat:output_type -> wpp.v1.GetChatResponse
-	8, // 9: wpp.v1.ChatService.WatchChatUpdates:output_type -> wpp.v1.EventEnvelope
-	7, // [7:10] is the sub-list for method output_type
-	4, // [4:7] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	21, // 0: wpp.v1.ListChatsRequest.pagination:type_name -> wpp.v1.Pagination
+	1,  // 1: wpp.v1.ListChatsResponse.chats:type_name -> wpp.v1.Chat
+	22, // 2: wpp.v1.ListChatsResponse.page_info:type_name -> wpp.v1.PageInfo
+	1,  // 3: wpp.v1.GetChatResponse.chat:type_name -> wpp.v1.Chat
+	1,  // 4: wpp.v1.ArchiveChatResponse.chat:type_name -> wpp.v1.Chat
+	1,  // 5: wpp.v1.PinChatResponse.chat:type_name -> wpp.v1.Chat
+	1,  // 6: wpp.v1.MuteChatResponse.chat:type_name -> wpp.v1.Chat
+	17, // 7: wpp.v1.GroupInfo.participants:type_name -> wpp.v1.GroupParticipant
+	18, // 8: wpp.v1.GetGroupInfoResponse.group:type_name -> wpp.v1.GroupInfo
+	0,  // 9: wpp.v1.ChatService.ListChats:input_type -> wpp.v1.ListChatsRequest
+	3,  // 10: wpp.v1.ChatService.GetChat:input_type -> wpp.v1.GetChatRequest
+	5,  // 11: wpp.v1.ChatService.WatchChatUpdates:input_type -> wpp.v1.WatchChatUpdatesRequest
+	9,  // 12: wpp.v1.ChatService.MarkRead:input_type -> wpp.v1.MarkReadRequest
+	19, // 13: wpp.v1.ChatService.GetGroupInfo:input_type -> wpp.v1.GetGroupInfoRequest
+	6,  // 14: wpp.v1.ChatService.WatchPresence:input_type -> wpp.v1.WatchPresenceRequest
+	7,  // 15: wpp.v1.ChatService.SendChatPresence:input_type -> wpp.v1.SendChatPresenceRequest
+	11, // 16: wpp.v1.ChatService.ArchiveChat:input_type -> wpp.v1.ArchiveChatRequest
+	13, // 17: wpp.v1.ChatService.PinChat:input_type -> wpp.v1.PinChatRequest
+	15, // 18: wpp.v1.ChatService.MuteChat:input_type -> wpp.v1.MuteChatRequest
+	2,  // 19: wpp.v1.ChatService.ListChats:output_type -> wpp.v1.ListChatsResponse
+	4,  // 20: wpp.v1.ChatService.GetChat:output_type -> wpp.v1.GetChatResponse
+	23, // 21: wpp.v1.ChatService.WatchChatUpdates:output_type -> wpp.v1.EventEnvelope
+	10, // 22: wpp.v1.ChatService.MarkRead:output_type -> wpp.v1.MarkReadResponse
+	20, // 23: wpp.v1.ChatService.GetGroupInfo:output_type -> wpp.v1.GetGroupInfoResponse
+	23, // 24: wpp.v1.ChatService.WatchPresence:output_type -> wpp.v1.EventEnvelope
+	8,  // 25: wpp.v1.ChatService.SendChatPresence:output_type -> wpp.v1.SendChatPresenceResponse
+	12, // 26: wpp.v1.ChatService.ArchiveChat:output_type -> wpp.v1.ArchiveChatResponse
+	14, // 27: wpp.v1.ChatService.PinChat:output_type -> wpp.v1.PinChatResponse
+	16, // 28: wpp.v1.ChatService.MuteChat:output_type -> wpp.v1.MuteChatResponse
+	19, // [19:29] is the sub-list for method output_type
+	9,  // [9:19] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_wpp_v1_chat_proto_init() }
@@ -426,7 +1297,7 @@ func file_wpp_v1_chat_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_wpp_v1_chat_proto_rawDesc), len(file_wpp_v1_chat_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
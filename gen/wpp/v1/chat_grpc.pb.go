@@ -22,6 +22,13 @@ const (
 	ChatService_ListChats_FullMethodName        = "/wpp.v1.ChatService/ListChats"
 	ChatService_GetChat_FullMethodName          = "/wpp.v1.ChatService/GetChat"
 	ChatService_WatchChatUpdates_FullMethodName = "/wpp.v1.ChatService/WatchChatUpdates"
+	ChatService_MarkRead_FullMethodName         = "/wpp.v1.ChatService/MarkRead"
+	ChatService_GetGroupInfo_FullMethodName     = "/wpp.v1.ChatService/GetGroupInfo"
+	ChatService_WatchPresence_FullMethodName    = "/wpp.v1.ChatService/WatchPresence"
+	ChatService_SendChatPresence_FullMethodName = "/wpp.v1.ChatService/SendChatPresence"
+	ChatService_ArchiveChat_FullMethodName      = "/wpp.v1.ChatService/ArchiveChat"
+	ChatService_PinChat_FullMethodName          = "/wpp.v1.ChatService/PinChat"
+	ChatService_MuteChat_FullMethodName         = "/wpp.v1.ChatService/MuteChat"
 )
 
 // ChatServiceClient is the client API for ChatService service.
@@ -31,6 +38,13 @@ type ChatServiceClient interface {
 	ListChats(ctx context.Context, in *ListChatsRequest, opts ...grpc.CallOption) (*ListChatsResponse, error)
 	GetChat(ctx context.Context, in *GetChatRequest, opts ...grpc.CallOption) (*GetChatResponse, error)
 	WatchChatUpdates(ctx context.Context, in *WatchChatUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EventEnvelope], error)
+	MarkRead(ctx context.Context, in *MarkReadRequest, opts ...grpc.CallOption) (*MarkReadResponse, error)
+	GetGroupInfo(ctx context.Context, in *GetGroupInfoRequest, opts ...grpc.CallOption) (*GetGroupInfoResponse, error)
+	WatchPresence(ctx context.Context, in *WatchPresenceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EventEnvelope], error)
+	SendChatPresence(ctx context.Context, in *SendChatPresenceRequest, opts ...grpc.CallOption) (*SendChatPresenceResponse, error)
+	ArchiveChat(ctx context.Context, in *ArchiveChatRequest, opts ...grpc.CallOption) (*ArchiveChatResponse, error)
+	PinChat(ctx context.Context, in *PinChatRequest, opts ...grpc.CallOption) (*PinChatResponse, error)
+	MuteChat(ctx context.Context, in *MuteChatRequest, opts ...grpc.CallOption) (*MuteChatResponse, error)
 }
 
 type chatServiceClient struct {
@@ -80,6 +94,85 @@ func (c *chatServiceClient) WatchChatUpdates(ctx context.Context, in *WatchChatU
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ChatService_WatchChatUpdatesClient = grpc.ServerStreamingClient[EventEnvelope]
 
+func (c *chatServiceClient) MarkRead(ctx context.Context, in *MarkReadRequest, opts ...grpc.CallOption) (*MarkReadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkReadResponse)
+	err := c.cc.Invoke(ctx, ChatService_MarkRead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetGroupInfo(ctx context.Context, in *GetGroupInfoRequest, opts ...grpc.CallOption) (*GetGroupInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetGroupInfoResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetGroupInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) WatchPresence(ctx context.Context, in *WatchPresenceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EventEnvelope], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChatService_ServiceDesc.Streams[1], ChatService_WatchPresence_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchPresenceRequest, EventEnvelope]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChatService_WatchPresenceClient = grpc.ServerStreamingClient[EventEnvelope]
+
+func (c *chatServiceClient) SendChatPresence(ctx context.Context, in *SendChatPresenceRequest, opts ...grpc.CallOption) (*SendChatPresenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendChatPresenceResponse)
+	err := c.cc.Invoke(ctx, ChatService_SendChatPresence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ArchiveChat(ctx context.Context, in *ArchiveChatRequest, opts ...grpc.CallOption) (*ArchiveChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ArchiveChatResponse)
+	err := c.cc.Invoke(ctx, ChatService_ArchiveChat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) PinChat(ctx context.Context, in *PinChatRequest, opts ...grpc.CallOption) (*PinChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PinChatResponse)
+	err := c.cc.Invoke(ctx, ChatService_PinChat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) MuteChat(ctx context.Context, in *MuteChatRequest, opts ...grpc.CallOption) (*MuteChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MuteChatResponse)
+	err := c.cc.Invoke(ctx, ChatService_MuteChat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChatServiceServer is the server API for ChatService service.
 // All implementations must embed UnimplementedChatServiceServer
 // for forward compatibility.
@@ -87,6 +180,13 @@ type ChatServiceServer interface {
 	ListChats(context.Context, *ListChatsRequest) (*ListChatsResponse, error)
 	GetChat(context.Context, *GetChatRequest) (*GetChatResponse, error)
 	WatchChatUpdates(*WatchChatUpdatesRequest, grpc.ServerStreamingServer[EventEnvelope]) error
+	MarkRead(context.Context, *MarkReadRequest) (*MarkReadResponse, error)
+	GetGroupInfo(context.Context, *GetGroupInfoRequest) (*GetGroupInfoResponse, error)
+	WatchPresence(*WatchPresenceRequest, grpc.ServerStreamingServer[EventEnvelope]) error
+	SendChatPresence(context.Context, *SendChatPresenceRequest) (*SendChatPresenceResponse, error)
+	ArchiveChat(context.Context, *ArchiveChatRequest) (*ArchiveChatResponse, error)
+	PinChat(context.Context, *PinChatRequest) (*PinChatResponse, error)
+	MuteChat(context.Context, *MuteChatRequest) (*MuteChatResponse, error)
 	mustEmbedUnimplementedChatServiceServer()
 }
 
@@ -106,6 +206,27 @@ func (UnimplementedChatServiceServer) GetChat(context.Context, *GetChatRequest)
 func (UnimplementedChatServiceServer) WatchChatUpdates(*WatchChatUpdatesRequest, grpc.ServerStreamingServer[EventEnvelope]) error {
 	return status.Error(codes.Unimplemented, "method WatchChatUpdates not implemented")
 }
+func (UnimplementedChatServiceServer) MarkRead(context.Context, *MarkReadRequest) (*MarkReadResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MarkRead not implemented")
+}
+func (UnimplementedChatServiceServer) GetGroupInfo(context.Context, *GetGroupInfoRequest) (*GetGroupInfoResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetGroupInfo not implemented")
+}
+func (UnimplementedChatServiceServer) WatchPresence(*WatchPresenceRequest, grpc.ServerStreamingServer[EventEnvelope]) error {
+	return status.Error(codes.Unimplemented, "method WatchPresence not implemented")
+}
+func (UnimplementedChatServiceServer) SendChatPresence(context.Context, *SendChatPresenceRequest) (*SendChatPresenceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SendChatPresence not implemented")
+}
+func (UnimplementedChatServiceServer) ArchiveChat(context.Context, *ArchiveChatRequest) (*ArchiveChatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ArchiveChat not implemented")
+}
+func (UnimplementedChatServiceServer) PinChat(context.Context, *PinChatRequest) (*PinChatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PinChat not implemented")
+}
+func (UnimplementedChatServiceServer) MuteChat(context.Context, *MuteChatRequest) (*MuteChatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MuteChat not implemented")
+}
 func (UnimplementedChatServiceServer) mustEmbedUnimplementedChatServiceServer() {}
 func (UnimplementedChatServiceServer) testEmbeddedByValue()                     {}
 
@@ -174,6 +295,125 @@ func _ChatService_WatchChatUpdates_Handler(srv interface{}, stream grpc.ServerSt
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ChatService_WatchChatUpdatesServer = grpc.ServerStreamingServer[EventEnvelope]
 
+func _ChatService_MarkRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).MarkRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_MarkRead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).MarkRead(ctx, req.(*MarkReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetGroupInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetGroupInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetGroupInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetGroupInfo(ctx, req.(*GetGroupInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_WatchPresence_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPresenceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChatServiceServer).WatchPresence(m, &grpc.GenericServerStream[WatchPresenceRequest, EventEnvelope]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChatService_WatchPresenceServer = grpc.ServerStreamingServer[EventEnvelope]
+
+func _ChatService_SendChatPresence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendChatPresenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).SendChatPresence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_SendChatPresence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).SendChatPresence(ctx, req.(*SendChatPresenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ArchiveChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ArchiveChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ArchiveChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ArchiveChat(ctx, req.(*ArchiveChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_PinChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).PinChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_PinChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).PinChat(ctx, req.(*PinChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_MuteChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuteChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).MuteChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_MuteChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).MuteChat(ctx, req.(*MuteChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ChatService_ServiceDesc is the grpc.ServiceDesc for ChatService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -189,6 +429,30 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetChat",
 			Handler:    _ChatService_GetChat_Handler,
 		},
+		{
+			MethodName: "MarkRead",
+			Handler:    _ChatService_MarkRead_Handler,
+		},
+		{
+			MethodName: "GetGroupInfo",
+			Handler:    _ChatService_GetGroupInfo_Handler,
+		},
+		{
+			MethodName: "SendChatPresence",
+			Handler:    _ChatService_SendChatPresence_Handler,
+		},
+		{
+			MethodName: "ArchiveChat",
+			Handler:    _ChatService_ArchiveChat_Handler,
+		},
+		{
+			MethodName: "PinChat",
+			Handler:    _ChatService_PinChat_Handler,
+		},
+		{
+			MethodName: "MuteChat",
+			Handler:    _ChatService_MuteChat_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -196,6 +460,11 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _ChatService_WatchChatUpdates_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchPresence",
+			Handler:       _ChatService_WatchPresence_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "wpp/v1/chat.proto",
 }
@@ -273,6 +273,18 @@ type SyncHistoryBatch struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	MessagesCount int32                  `protobuf:"varint,1,opt,name=messages_count,json=messagesCount,proto3" json:"messages_count,omitempty"`
 	ChatsCount    int32                  `protobuf:"varint,2,opt,name=chats_count,json=chatsCount,proto3" json:"chats_count,omitempty"`
+	// initial_bootstrap, recent, full, on_demand, ...
+	SyncType   string `protobuf:"bytes,3,opt,name=sync_type,json=syncType,proto3" json:"sync_type,omitempty"`
+	ChunkOrder int32  `protobuf:"varint,4,opt,name=chunk_order,json=chunkOrder,proto3" json:"chunk_order,omitempty"`
+	// Percent of the sync done, 0 when the chunk does not say.
+	Progress int32 `protobuf:"varint,5,opt,name=progress,proto3" json:"progress,omitempty"`
+	// Messages and chats first stored by history so far.
+	TotalMessages int64 `protobuf:"varint,6,opt,name=total_messages,json=totalMessages,proto3" json:"total_messages,omitempty"`
+	TotalChats    int64 `protobuf:"varint,7,opt,name=total_chats,json=totalChats,proto3" json:"total_chats,omitempty"`
+	// For an on_demand chunk: its chat, and the BackfillChat request it
+	// answers ("" if none is pending).
+	ChatJid       string `protobuf:"bytes,8,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	RequestId     string `protobuf:"bytes,9,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -321,6 +333,55 @@ func (x *SyncHistoryBatch) GetChatsCount() int32 {
 	return 0
 }
 
+func (x *SyncHistoryBatch) GetSyncType() string {
+	if x != nil {
+		return x.SyncType
+	}
+	return ""
+}
+
+func (x *SyncHistoryBatch) GetChunkOrder() int32 {
+	if x != nil {
+		return x.ChunkOrder
+	}
+	return 0
+}
+
+func (x *SyncHistoryBatch) GetProgress() int32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *SyncHistoryBatch) GetTotalMessages() int64 {
+	if x != nil {
+		return x.TotalMessages
+	}
+	return 0
+}
+
+func (x *SyncHistoryBatch) GetTotalChats() int64 {
+	if x != nil {
+		return x.TotalChats
+	}
+	return 0
+}
+
+func (x *SyncHistoryBatch) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+func (x *SyncHistoryBatch) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
 type SyncReconnecting struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Attempt       int32                  `protobuf:"varint,1,opt,name=attempt,proto3" json:"attempt,omitempty"`
@@ -457,7 +518,7 @@ type MessageUpserted struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChatJid       string                 `protobuf:"bytes,1,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
 	MsgId         string                 `protobuf:"bytes,2,opt,name=msg_id,json=msgId,proto3" json:"msg_id,omitempty"`
-	IsNew         bool                   `protobuf:"varint,3,opt,name=is_new,json=isNew,proto3" json:"is_new,omitempty"`
+	IsNew         bool                   `protobuf:"varint,3,opt,name=is_new,json=isNew,proto3" json:"is_new,omitempty"` // first time the message is stored
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -517,6 +578,7 @@ type MessageSendAck struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ClientMsgId   string                 `protobuf:"bytes,1,opt,name=client_msg_id,json=clientMsgId,proto3" json:"client_msg_id,omitempty"`
 	ServerMsgId   string                 `protobuf:"bytes,2,opt,name=server_msg_id,json=serverMsgId,proto3" json:"server_msg_id,omitempty"`
+	ChatJid       string                 `protobuf:"bytes,3,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -565,12 +627,22 @@ func (x *MessageSendAck) GetServerMsgId() string {
 	return ""
 }
 
+func (x *MessageSendAck) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
 type MessageSendFailed struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ClientMsgId   string                 `protobuf:"bytes,1,opt,name=client_msg_id,json=clientMsgId,proto3" json:"client_msg_id,omitempty"`
-	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ClientMsgId       string                 `protobuf:"bytes,1,opt,name=client_msg_id,json=clientMsgId,proto3" json:"client_msg_id,omitempty"`
+	Reason            string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	ChatJid           string                 `protobuf:"bytes,3,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	Attempts          int32                  `protobuf:"varint,4,opt,name=attempts,proto3" json:"attempts,omitempty"`                                                // attempts made so far
+	NextAttemptUnixMs int64                  `protobuf:"varint,5,opt,name=next_attempt_unix_ms,json=nextAttemptUnixMs,proto3" json:"next_attempt_unix_ms,omitempty"` // when it is retried; 0 once the send is dead
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *MessageSendFailed) Reset() {
@@ -617,6 +689,305 @@ func (x *MessageSendFailed) GetReason() string {
 	return ""
 }
 
+func (x *MessageSendFailed) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+func (x *MessageSendFailed) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *MessageSendFailed) GetNextAttemptUnixMs() int64 {
+	if x != nil {
+		return x.NextAttemptUnixMs
+	}
+	return 0
+}
+
+type MessageStatusChanged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatJid       string                 `protobuf:"bytes,1,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	MsgId         string                 `protobuf:"bytes,2,opt,name=msg_id,json=msgId,proto3" json:"msg_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // delivered, read, played
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageStatusChanged) Reset() {
+	*x = MessageStatusChanged{}
+	mi := &file_wpp_v1_events_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageStatusChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageStatusChanged) ProtoMessage() {}
+
+func (x *MessageStatusChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_events_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageStatusChanged.ProtoReflect.Descriptor instead.
+func (*MessageStatusChanged) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_events_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MessageStatusChanged) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+func (x *MessageStatusChanged) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+func (x *MessageStatusChanged) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ChatUpdated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatJid       string                 `protobuf:"bytes,1,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatUpdated) Reset() {
+	*x = ChatUpdated{}
+	mi := &file_wpp_v1_events_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatUpdated) ProtoMessage() {}
+
+func (x *ChatUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_events_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatUpdated.ProtoReflect.Descriptor instead.
+func (*ChatUpdated) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_events_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ChatUpdated) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+// ChatCleared is a chat whose messages were cleared on another device.
+// deleted is set when the chat itself was deleted and is gone.
+type ChatCleared struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatJid       string                 `protobuf:"bytes,1,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	Deleted       bool                   `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatCleared) Reset() {
+	*x = ChatCleared{}
+	mi := &file_wpp_v1_events_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatCleared) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatCleared) ProtoMessage() {}
+
+func (x *ChatCleared) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_events_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatCleared.ProtoReflect.Descriptor instead.
+func (*ChatCleared) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_events_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ChatCleared) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+func (x *ChatCleared) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+type PresenceChatState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatJid       string                 `protobuf:"bytes,1,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	SenderJid     string                 `protobuf:"bytes,2,opt,name=sender_jid,json=senderJid,proto3" json:"sender_jid,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"` // composing, recording, paused
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PresenceChatState) Reset() {
+	*x = PresenceChatState{}
+	mi := &file_wpp_v1_events_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PresenceChatState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresenceChatState) ProtoMessage() {}
+
+func (x *PresenceChatState) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_events_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresenceChatState.ProtoReflect.Descriptor instead.
+func (*PresenceChatState) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_events_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PresenceChatState) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+func (x *PresenceChatState) GetSenderJid() string {
+	if x != nil {
+		return x.SenderJid
+	}
+	return ""
+}
+
+func (x *PresenceChatState) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type PresenceUpdated struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Jid            string                 `protobuf:"bytes,1,opt,name=jid,proto3" json:"jid,omitempty"`
+	Online         bool                   `protobuf:"varint,2,opt,name=online,proto3" json:"online,omitempty"`
+	LastSeenUnixMs int64                  `protobuf:"varint,3,opt,name=last_seen_unix_ms,json=lastSeenUnixMs,proto3" json:"last_seen_unix_ms,omitempty"` // 0 when hidden or unknown
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PresenceUpdated) Reset() {
+	*x = PresenceUpdated{}
+	mi := &file_wpp_v1_events_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PresenceUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresenceUpdated) ProtoMessage() {}
+
+func (x *PresenceUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_events_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresenceUpdated.ProtoReflect.Descriptor instead.
+func (*PresenceUpdated) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_events_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PresenceUpdated) GetJid() string {
+	if x != nil {
+		return x.Jid
+	}
+	return ""
+}
+
+func (x *PresenceUpdated) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
+}
+
+func (x *PresenceUpdated) GetLastSeenUnixMs() int64 {
+	if x != nil {
+		return x.LastSeenUnixMs
+	}
+	return 0
+}
+
 var File_wpp_v1_events_proto protoreflect.FileDescriptor
 
 const file_wpp_v1_events_proto_rawDesc = "" +
@@ -631,11 +1002,21 @@ const file_wpp_v1_events_proto_rawDesc = "" +
 	"\x10SessionLoggedOut\x12\x18\n" +
 	"\asession\x18\x01 \x01(\tR\asession\"\x10\n" +
 	"\x0eSyncConnecting\"\x0f\n" +
-	"\rSyncConnected\"Z\n" +
+	"\rSyncConnected\"\xb6\x02\n" +
 	"\x10SyncHistoryBatch\x12%\n" +
 	"\x0emessages_count\x18\x01 \x01(\x05R\rmessagesCount\x12\x1f\n" +
 	"\vchats_count\x18\x02 \x01(\x05R\n" +
-	"chatsCount\",\n" +
+	"chatsCount\x12\x1b\n" +
+	"\tsync_type\x18\x03 \x01(\tR\bsyncType\x12\x1f\n" +
+	"\vchunk_order\x18\x04 \x01(\x05R\n" +
+	"chunkOrder\x12\x1a\n" +
+	"\bprogress\x18\x05 \x01(\x05R\bprogress\x12%\n" +
+	"\x0etotal_messages\x18\x06 \x01(\x03R\rtotalMessages\x12\x1f\n" +
+	"\vtotal_chats\x18\a \x01(\x03R\n" +
+	"totalChats\x12\x19\n" +
+	"\bchat_jid\x18\b \x01(\tR\achatJid\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\t \x01(\tR\trequestId\",\n" +
 	"\x10SyncReconnecting\x12\x18\n" +
 	"\aattempt\x18\x01 \x01(\x05R\aattempt\"*\n" +
 	"\x10SyncDisconnected\x12\x16\n" +
@@ -645,13 +1026,35 @@ const file_wpp_v1_events_proto_rawDesc = "" +
 	"\x0fMessageUpserted\x12\x19\n" +
 	"\bchat_jid\x18\x01 \x01(\tR\achatJid\x12\x15\n" +
 	"\x06msg_id\x18\x02 \x01(\tR\x05msgId\x12\x15\n" +
-	"\x06is_new\x18\x03 \x01(\bR\x05isNew\"X\n" +
+	"\x06is_new\x18\x03 \x01(\bR\x05isNew\"s\n" +
 	"\x0eMessageSendAck\x12\"\n" +
 	"\rclient_msg_id\x18\x01 \x01(\tR\vclientMsgId\x12\"\n" +
-	"\rserver_msg_id\x18\x02 \x01(\tR\vserverMsgId\"O\n" +
+	"\rserver_msg_id\x18\x02 \x01(\tR\vserverMsgId\x12\x19\n" +
+	"\bchat_jid\x18\x03 \x01(\tR\achatJid\"\xb7\x01\n" +
 	"\x11MessageSendFailed\x12\"\n" +
 	"\rclient_msg_id\x18\x01 \x01(\tR\vclientMsgId\x12\x16\n" +
-	"\x06reason\x18\x02 \x01(\tR\x06reasonB-Z+github.com/matheus3301/wpp/gen/wpp/v1;wppv1b\x06proto3"
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x19\n" +
+	"\bchat_jid\x18\x03 \x01(\tR\achatJid\x12\x1a\n" +
+	"\battempts\x18\x04 \x01(\x05R\battempts\x12/\n" +
+	"\x14next_attempt_unix_ms\x18\x05 \x01(\x03R\x11nextAttemptUnixMs\"`\n" +
+	"\x14MessageStatusChanged\x12\x19\n" +
+	"\bchat_jid\x18\x01 \x01(\tR\achatJid\x12\x15\n" +
+	"\x06msg_id\x18\x02 \x01(\tR\x05msgId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"(\n" +
+	"\vChatUpdated\x12\x19\n" +
+	"\bchat_jid\x18\x01 \x01(\tR\achatJid\"B\n" +
+	"\vChatCleared\x12\x19\n" +
+	"\bchat_jid\x18\x01 \x01(\tR\achatJid\x12\x18\n" +
+	"\adeleted\x18\x02 \x01(\bR\adeleted\"c\n" +
+	"\x11PresenceChatState\x12\x19\n" +
+	"\bchat_jid\x18\x01 \x01(\tR\achatJid\x12\x1d\n" +
+	"\n" +
+	"sender_jid\x18\x02 \x01(\tR\tsenderJid\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\"f\n" +
+	"\x0fPresenceUpdated\x12\x10\n" +
+	"\x03jid\x18\x01 \x01(\tR\x03jid\x12\x16\n" +
+	"\x06online\x18\x02 \x01(\bR\x06online\x12)\n" +
+	"\x11last_seen_unix_ms\x18\x03 \x01(\x03R\x0elastSeenUnixMsB-Z+github.com/matheus3301/wpp/gen/wpp/v1;wppv1b\x06proto3"
 
 var (
 	file_wpp_v1_events_proto_rawDescOnce sync.Once
@@ -665,7 +1068,7 @@ func file_wpp_v1_events_proto_rawDescGZIP() []byte {
 	return file_wpp_v1_events_proto_rawDescData
 }
 
-var file_wpp_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_wpp_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_wpp_v1_events_proto_goTypes = []any{
 	(*SessionQRGenerated)(nil),   // 0: wpp.v1.SessionQRGenerated
 	(*SessionAuthenticated)(nil), // 1: wpp.v1.SessionAuthenticated
@@ -680,6 +1083,11 @@ var file_wpp_v1_events_proto_goTypes = []any{
 	(*MessageUpserted)(nil),      // 10: wpp.v1.MessageUpserted
 	(*MessageSendAck)(nil),       // 11: wpp.v1.MessageSendAck
 	(*MessageSendFailed)(nil),    // 12: wpp.v1.MessageSendFailed
+	(*MessageStatusChanged)(nil), // 13: wpp.v1.MessageStatusChanged
+	(*ChatUpdated)(nil),          // 14: wpp.v1.ChatUpdated
+	(*ChatCleared)(nil),          // 15: wpp.v1.ChatCleared
+	(*PresenceChatState)(nil),    // 16: wpp.v1.PresenceChatState
+	(*PresenceUpdated)(nil),      // 17: wpp.v1.PresenceUpdated
 }
 var file_wpp_v1_events_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -700,7 +1108,7 @@ func file_wpp_v1_events_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_wpp_v1_events_proto_rawDesc), len(file_wpp_v1_events_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
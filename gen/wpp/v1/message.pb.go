@@ -73,6 +73,102 @@ func (x *ListMessagesRequest) GetPagination() *Pagination {
 	return nil
 }
 
+type GetMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatJid       string                 `protobuf:"bytes,1,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	MsgId         string                 `protobuf:"bytes,2,opt,name=msg_id,json=msgId,proto3" json:"msg_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessageRequest) Reset() {
+	*x = GetMessageRequest{}
+	mi := &file_wpp_v1_message_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessageRequest) ProtoMessage() {}
+
+func (x *GetMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessageRequest.ProtoReflect.Descriptor instead.
+func (*GetMessageRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetMessageRequest) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+func (x *GetMessageRequest) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+type GetMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessageResponse) Reset() {
+	*x = GetMessageResponse{}
+	mi := &file_wpp_v1_message_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessageResponse) ProtoMessage() {}
+
+func (x *GetMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessageResponse.ProtoReflect.Descriptor instead.
+func (*GetMessageResponse) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetMessageResponse) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
 type Message struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -82,15 +178,22 @@ type Message struct {
 	Body            string                 `protobuf:"bytes,5,opt,name=body,proto3" json:"body,omitempty"`
 	TimestampUnixMs int64                  `protobuf:"varint,6,opt,name=timestamp_unix_ms,json=timestampUnixMs,proto3" json:"timestamp_unix_ms,omitempty"`
 	FromMe          bool                   `protobuf:"varint,7,opt,name=from_me,json=fromMe,proto3" json:"from_me,omitempty"`
-	MessageType     string                 `protobuf:"bytes,8,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"` // text, image, etc.
-	Status          string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`                              // sent, delivered, read, failed
+	MessageType     string                 `protobuf:"bytes,8,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`                // text, image, etc.
+	Status          string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`                                             // queued, sending, sent, delivered, read, played, failed
+	EditedAtUnixMs  int64                  `protobuf:"varint,10,opt,name=edited_at_unix_ms,json=editedAtUnixMs,proto3" json:"edited_at_unix_ms,omitempty"` // 0 when never edited
+	Deleted         bool                   `protobuf:"varint,11,opt,name=deleted,proto3" json:"deleted,omitempty"`                                         // revoked; body is empty
+	Reactions       []*ReactionCount       `protobuf:"bytes,12,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	QuotedMsgId     string                 `protobuf:"bytes,13,opt,name=quoted_msg_id,json=quotedMsgId,proto3" json:"quoted_msg_id,omitempty"` // message this one replies to, if any
+	QuotedSender    string                 `protobuf:"bytes,14,opt,name=quoted_sender,json=quotedSender,proto3" json:"quoted_sender,omitempty"`
+	QuotedBody      string                 `protobuf:"bytes,15,opt,name=quoted_body,json=quotedBody,proto3" json:"quoted_body,omitempty"` // current body of the quoted message, or the text the reply carried if it is not stored
+	Media           *MediaInfo             `protobuf:"bytes,16,opt,name=media,proto3" json:"media,omitempty"`                             // set for media messages; the caption is the body
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Message) Reset() {
 	*x = Message{}
-	mi := &file_wpp_v1_message_proto_msgTypes[1]
+	mi := &file_wpp_v1_message_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -102,7 +205,7 @@ func (x *Message) String() string {
 func (*Message) ProtoMessage() {}
 
 func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_wpp_v1_message_proto_msgTypes[1]
+	mi := &file_wpp_v1_message_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -115,7 +218,7 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Message.ProtoReflect.Descriptor instead.
 func (*Message) Descriptor() ([]byte, []int) {
-	return file_wpp_v1_message_proto_rawDescGZIP(), []int{1}
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Message) GetId() string {
@@ -181,6 +284,199 @@ func (x *Message) GetStatus() string {
 	return ""
 }
 
+func (x *Message) GetEditedAtUnixMs() int64 {
+	if x != nil {
+		return x.EditedAtUnixMs
+	}
+	return 0
+}
+
+func (x *Message) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *Message) GetReactions() []*ReactionCount {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+func (x *Message) GetQuotedMsgId() string {
+	if x != nil {
+		return x.QuotedMsgId
+	}
+	return ""
+}
+
+func (x *Message) GetQuotedSender() string {
+	if x != nil {
+		return x.QuotedSender
+	}
+	return ""
+}
+
+func (x *Message) GetQuotedBody() string {
+	if x != nil {
+		return x.QuotedBody
+	}
+	return ""
+}
+
+func (x *Message) GetMedia() *MediaInfo {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+type MediaInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MediaType     string                 `protobuf:"bytes,1,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"` // image, video, audio, document, sticker
+	Mimetype      string                 `protobuf:"bytes,2,opt,name=mimetype,proto3" json:"mimetype,omitempty"`
+	FileLength    int64                  `protobuf:"varint,3,opt,name=file_length,json=fileLength,proto3" json:"file_length,omitempty"`
+	FileName      string                 `protobuf:"bytes,4,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`    // documents only
+	LocalPath     string                 `protobuf:"bytes,5,opt,name=local_path,json=localPath,proto3" json:"local_path,omitempty"` // cached file, empty until downloaded
+	Voice         bool                   `protobuf:"varint,6,opt,name=voice,proto3" json:"voice,omitempty"`                         // audio recorded as a voice note
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MediaInfo) Reset() {
+	*x = MediaInfo{}
+	mi := &file_wpp_v1_message_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MediaInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaInfo) ProtoMessage() {}
+
+func (x *MediaInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MediaInfo.ProtoReflect.Descriptor instead.
+func (*MediaInfo) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MediaInfo) GetMediaType() string {
+	if x != nil {
+		return x.MediaType
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetMimetype() string {
+	if x != nil {
+		return x.Mimetype
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetFileLength() int64 {
+	if x != nil {
+		return x.FileLength
+	}
+	return 0
+}
+
+func (x *MediaInfo) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetLocalPath() string {
+	if x != nil {
+		return x.LocalPath
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetVoice() bool {
+	if x != nil {
+		return x.Voice
+	}
+	return false
+}
+
+type ReactionCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Emoji         string                 `protobuf:"bytes,1,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	FromMe        bool                   `protobuf:"varint,3,opt,name=from_me,json=fromMe,proto3" json:"from_me,omitempty"` // one of the reactions is ours
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactionCount) Reset() {
+	*x = ReactionCount{}
+	mi := &file_wpp_v1_message_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactionCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactionCount) ProtoMessage() {}
+
+func (x *ReactionCount) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactionCount.ProtoReflect.Descriptor instead.
+func (*ReactionCount) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReactionCount) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *ReactionCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ReactionCount) GetFromMe() bool {
+	if x != nil {
+		return x.FromMe
+	}
+	return false
+}
+
 type ListMessagesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
@@ -191,7 +487,7 @@ type ListMessagesResponse struct {
 
 func (x *ListMessagesResponse) Reset() {
 	*x = ListMessagesResponse{}
-	mi := &file_wpp_v1_message_proto_msgTypes[2]
+	mi := &file_wpp_v1_message_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -203,7 +499,7 @@ func (x *ListMessagesResponse) String() string {
 func (*ListMessagesResponse) ProtoMessage() {}
 
 func (x *ListMessagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_wpp_v1_message_proto_msgTypes[2]
+	mi := &file_wpp_v1_message_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -216,7 +512,7 @@ func (x *ListMessagesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListMessagesResponse.ProtoReflect.Descriptor instead.
 func (*ListMessagesResponse) Descriptor() ([]byte, []int) {
-	return file_wpp_v1_message_proto_rawDescGZIP(), []int{2}
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListMessagesResponse) GetMessages() []*Message {
@@ -244,7 +540,7 @@ type SearchMessagesRequest struct {
 
 func (x *SearchMessagesRequest) Reset() {
 	*x = SearchMessagesRequest{}
-	mi := &file_wpp_v1_message_proto_msgTypes[3]
+	mi := &file_wpp_v1_message_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -256,7 +552,7 @@ func (x *SearchMessagesRequest) String() string {
 func (*SearchMessagesRequest) ProtoMessage() {}
 
 func (x *SearchMessagesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_wpp_v1_message_proto_msgTypes[3]
+	mi := &file_wpp_v1_message_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -269,7 +565,7 @@ func (x *SearchMessagesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchMessagesRequest.ProtoReflect.Descriptor instead.
 func (*SearchMessagesRequest) Descriptor() ([]byte, []int) {
-	return file_wpp_v1_message_proto_rawDescGZIP(), []int{3}
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *SearchMessagesRequest) GetQuery() string {
@@ -303,7 +599,7 @@ type SearchResult struct {
 
 func (x *SearchResult) Reset() {
 	*x = SearchResult{}
-	mi := &file_wpp_v1_message_proto_msgTypes[4]
+	mi := &file_wpp_v1_message_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -315,7 +611,7 @@ func (x *SearchResult) String() string {
 func (*SearchResult) ProtoMessage() {}
 
 func (x *SearchResult) ProtoReflect() protoreflect.Message {
-	mi := &file_wpp_v1_message_proto_msgTypes[4]
+	mi := &file_wpp_v1_message_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -328,7 +624,7 @@ func (x *SearchResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
 func (*SearchResult) Descriptor() ([]byte, []int) {
-	return file_wpp_v1_message_proto_rawDescGZIP(), []int{4}
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *SearchResult) GetMessage() *Message {
@@ -355,7 +651,7 @@ type SearchMessagesResponse struct {
 
 func (x *SearchMessagesResponse) Reset() {
 	*x = SearchMessagesResponse{}
-	mi := &file_wpp_v1_message_proto_msgTypes[5]
+	mi := &file_wpp_v1_message_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -367,7 +663,7 @@ func (x *SearchMessagesResponse) String() string {
 func (*SearchMessagesResponse) ProtoMessage() {}
 
 func (x *SearchMessagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_wpp_v1_message_proto_msgTypes[5]
+	mi := &file_wpp_v1_message_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -380,7 +676,7 @@ func (x *SearchMessagesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchMessagesResponse.ProtoReflect.Descriptor instead.
 func (*SearchMessagesResponse) Descriptor() ([]byte, []int) {
-	return file_wpp_v1_message_proto_rawDescGZIP(), []int{5}
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SearchMessagesResponse) GetResults() []*SearchResult {
@@ -402,13 +698,15 @@ type SendTextRequest struct {
 	ClientMsgId   string                 `protobuf:"bytes,1,opt,name=client_msg_id,json=clientMsgId,proto3" json:"client_msg_id,omitempty"`
 	ChatJid       string                 `protobuf:"bytes,2,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
 	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	ReplyToMsgId  string                 `protobuf:"bytes,4,opt,name=reply_to_msg_id,json=replyToMsgId,proto3" json:"reply_to_msg_id,omitempty"`  // optional: message to quote
+	SendAtUnixMs  int64                  `protobuf:"varint,5,opt,name=send_at_unix_ms,json=sendAtUnixMs,proto3" json:"send_at_unix_ms,omitempty"` // optional: send at this time; ignored unless in the future
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SendTextRequest) Reset() {
 	*x = SendTextRequest{}
-	mi := &file_wpp_v1_message_proto_msgTypes[6]
+	mi := &file_wpp_v1_message_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -420,7 +718,7 @@ func (x *SendTextRequest) String() string {
 func (*SendTextRequest) ProtoMessage() {}
 
 func (x *SendTextRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_wpp_v1_message_proto_msgTypes[6]
+	mi := &file_wpp_v1_message_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -433,7 +731,7 @@ func (x *SendTextRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendTextRequest.ProtoReflect.Descriptor instead.
 func (*SendTextRequest) Descriptor() ([]byte, []int) {
-	return file_wpp_v1_message_proto_rawDescGZIP(), []int{6}
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *SendTextRequest) GetClientMsgId() string {
@@ -457,6 +755,20 @@ func (x *SendTextRequest) GetText() string {
 	return ""
 }
 
+func (x *SendTextRequest) GetReplyToMsgId() string {
+	if x != nil {
+		return x.ReplyToMsgId
+	}
+	return ""
+}
+
+func (x *SendTextRequest) GetSendAtUnixMs() int64 {
+	if x != nil {
+		return x.SendAtUnixMs
+	}
+	return 0
+}
+
 type SendTextResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
@@ -467,7 +779,7 @@ type SendTextResponse struct {
 
 func (x *SendTextResponse) Reset() {
 	*x = SendTextResponse{}
-	mi := &file_wpp_v1_message_proto_msgTypes[7]
+	mi := &file_wpp_v1_message_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -479,7 +791,7 @@ func (x *SendTextResponse) String() string {
 func (*SendTextResponse) ProtoMessage() {}
 
 func (x *SendTextResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_wpp_v1_message_proto_msgTypes[7]
+	mi := &file_wpp_v1_message_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -492,7 +804,7 @@ func (x *SendTextResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendTextResponse.ProtoReflect.Descriptor instead.
 func (*SendTextResponse) Descriptor() ([]byte, []int) {
-	return file_wpp_v1_message_proto_rawDescGZIP(), []int{7}
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SendTextResponse) GetAccepted() bool {
@@ -509,7 +821,1037 @@ func (x *SendTextResponse) GetMessage() string {
 	return ""
 }
 
-type WatchMessageEventsRequest struct {
+// SendMediaRequest sends a file from the daemon's filesystem as an
+// attachment. The file is copied when the request is accepted, so it may
+// be removed afterwards.
+type SendMediaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientMsgId   string                 `protobuf:"bytes,1,opt,name=client_msg_id,json=clientMsgId,proto3" json:"client_msg_id,omitempty"`
+	ChatJid       string                 `protobuf:"bytes,2,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`                                         // absolute path of the file to send
+	Caption       string                 `protobuf:"bytes,4,opt,name=caption,proto3" json:"caption,omitempty"`                                   // optional; not shown for audio and stickers
+	MediaType     string                 `protobuf:"bytes,5,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`              // optional: image, video, audio, voice, document or sticker; detected from the file when empty
+	FileName      string                 `protobuf:"bytes,6,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`                 // optional: name shown for documents; the file's base name when empty
+	ReplyToMsgId  string                 `protobuf:"bytes,7,opt,name=reply_to_msg_id,json=replyToMsgId,proto3" json:"reply_to_msg_id,omitempty"` // optional: message to quote
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendMediaRequest) Reset() {
+	*x = SendMediaRequest{}
+	mi := &file_wpp_v1_message_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMediaRequest) ProtoMessage() {}
+
+func (x *SendMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMediaRequest.ProtoReflect.Descriptor instead.
+func (*SendMediaRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SendMediaRequest) GetClientMsgId() string {
+	if x != nil {
+		return x.ClientMsgId
+	}
+	return ""
+}
+
+func (x *SendMediaRequest) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+func (x *SendMediaRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SendMediaRequest) GetCaption() string {
+	if x != nil {
+		return x.Caption
+	}
+	return ""
+}
+
+func (x *SendMediaRequest) GetMediaType() string {
+	if x != nil {
+		return x.MediaType
+	}
+	return ""
+}
+
+func (x *SendMediaRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *SendMediaRequest) GetReplyToMsgId() string {
+	if x != nil {
+		return x.ReplyToMsgId
+	}
+	return ""
+}
+
+type SendMediaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendMediaResponse) Reset() {
+	*x = SendMediaResponse{}
+	mi := &file_wpp_v1_message_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMediaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMediaResponse) ProtoMessage() {}
+
+func (x *SendMediaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMediaResponse.ProtoReflect.Descriptor instead.
+func (*SendMediaResponse) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SendMediaResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *SendMediaResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type SendReactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientMsgId   string                 `protobuf:"bytes,1,opt,name=client_msg_id,json=clientMsgId,proto3" json:"client_msg_id,omitempty"`
+	ChatJid       string                 `protobuf:"bytes,2,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	MsgId         string                 `protobuf:"bytes,3,opt,name=msg_id,json=msgId,proto3" json:"msg_id,omitempty"` // message to react to
+	Emoji         string                 `protobuf:"bytes,4,opt,name=emoji,proto3" json:"emoji,omitempty"`              // empty removes our reaction
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendReactionRequest) Reset() {
+	*x = SendReactionRequest{}
+	mi := &file_wpp_v1_message_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendReactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendReactionRequest) ProtoMessage() {}
+
+func (x *SendReactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendReactionRequest.ProtoReflect.Descriptor instead.
+func (*SendReactionRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SendReactionRequest) GetClientMsgId() string {
+	if x != nil {
+		return x.ClientMsgId
+	}
+	return ""
+}
+
+func (x *SendReactionRequest) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+func (x *SendReactionRequest) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+func (x *SendReactionRequest) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+type SendReactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendReactionResponse) Reset() {
+	*x = SendReactionResponse{}
+	mi := &file_wpp_v1_message_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendReactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendReactionResponse) ProtoMessage() {}
+
+func (x *SendReactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendReactionResponse.ProtoReflect.Descriptor instead.
+func (*SendReactionResponse) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SendReactionResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *SendReactionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// EditMessageRequest replaces the text of one of our sent text messages.
+type EditMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientMsgId   string                 `protobuf:"bytes,1,opt,name=client_msg_id,json=clientMsgId,proto3" json:"client_msg_id,omitempty"` // identifies the edit in the outbox
+	ChatJid       string                 `protobuf:"bytes,2,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	MsgId         string                 `protobuf:"bytes,3,opt,name=msg_id,json=msgId,proto3" json:"msg_id,omitempty"` // message to edit
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMessageRequest) Reset() {
+	*x = EditMessageRequest{}
+	mi := &file_wpp_v1_message_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMessageRequest) ProtoMessage() {}
+
+func (x *EditMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMessageRequest.ProtoReflect.Descriptor instead.
+func (*EditMessageRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *EditMessageRequest) GetClientMsgId() string {
+	if x != nil {
+		return x.ClientMsgId
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type EditMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMessageResponse) Reset() {
+	*x = EditMessageResponse{}
+	mi := &file_wpp_v1_message_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMessageResponse) ProtoMessage() {}
+
+func (x *EditMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMessageResponse.ProtoReflect.Descriptor instead.
+func (*EditMessageResponse) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *EditMessageResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *EditMessageResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// RevokeMessageRequest deletes one of our messages for everyone.
+type RevokeMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientMsgId   string                 `protobuf:"bytes,1,opt,name=client_msg_id,json=clientMsgId,proto3" json:"client_msg_id,omitempty"` // identifies the revoke in the outbox
+	ChatJid       string                 `protobuf:"bytes,2,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	MsgId         string                 `protobuf:"bytes,3,opt,name=msg_id,json=msgId,proto3" json:"msg_id,omitempty"` // message to delete
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeMessageRequest) Reset() {
+	*x = RevokeMessageRequest{}
+	mi := &file_wpp_v1_message_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeMessageRequest) ProtoMessage() {}
+
+func (x *RevokeMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeMessageRequest.ProtoReflect.Descriptor instead.
+func (*RevokeMessageRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RevokeMessageRequest) GetClientMsgId() string {
+	if x != nil {
+		return x.ClientMsgId
+	}
+	return ""
+}
+
+func (x *RevokeMessageRequest) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+func (x *RevokeMessageRequest) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+type RevokeMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeMessageResponse) Reset() {
+	*x = RevokeMessageResponse{}
+	mi := &file_wpp_v1_message_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeMessageResponse) ProtoMessage() {}
+
+func (x *RevokeMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeMessageResponse.ProtoReflect.Descriptor instead.
+func (*RevokeMessageResponse) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RevokeMessageResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *RevokeMessageResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// RetryMessageRequest resends a queued or dead outbox entry right away.
+type RetryMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientMsgId   string                 `protobuf:"bytes,1,opt,name=client_msg_id,json=clientMsgId,proto3" json:"client_msg_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryMessageRequest) Reset() {
+	*x = RetryMessageRequest{}
+	mi := &file_wpp_v1_message_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryMessageRequest) ProtoMessage() {}
+
+func (x *RetryMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryMessageRequest.ProtoReflect.Descriptor instead.
+func (*RetryMessageRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RetryMessageRequest) GetClientMsgId() string {
+	if x != nil {
+		return x.ClientMsgId
+	}
+	return ""
+}
+
+type RetryMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryMessageResponse) Reset() {
+	*x = RetryMessageResponse{}
+	mi := &file_wpp_v1_message_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryMessageResponse) ProtoMessage() {}
+
+func (x *RetryMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryMessageResponse.ProtoReflect.Descriptor instead.
+func (*RetryMessageResponse) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{21}
+}
+
+// CancelMessageRequest drops a queued or dead outbox entry; an unsent text
+// is removed from its chat.
+type CancelMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientMsgId   string                 `protobuf:"bytes,1,opt,name=client_msg_id,json=clientMsgId,proto3" json:"client_msg_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelMessageRequest) Reset() {
+	*x = CancelMessageRequest{}
+	mi := &file_wpp_v1_message_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelMessageRequest) ProtoMessage() {}
+
+func (x *CancelMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelMessageRequest.ProtoReflect.Descriptor instead.
+func (*CancelMessageRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CancelMessageRequest) GetClientMsgId() string {
+	if x != nil {
+		return x.ClientMsgId
+	}
+	return ""
+}
+
+type CancelMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelMessageResponse) Reset() {
+	*x = CancelMessageResponse{}
+	mi := &file_wpp_v1_message_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelMessageResponse) ProtoMessage() {}
+
+func (x *CancelMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelMessageResponse.ProtoReflect.Descriptor instead.
+func (*CancelMessageResponse) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{23}
+}
+
+// ScheduledMessage is a text queued to be sent at a later time.
+type ScheduledMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientMsgId   string                 `protobuf:"bytes,1,opt,name=client_msg_id,json=clientMsgId,proto3" json:"client_msg_id,omitempty"`
+	ChatJid       string                 `protobuf:"bytes,2,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	SendAtUnixMs  int64                  `protobuf:"varint,4,opt,name=send_at_unix_ms,json=sendAtUnixMs,proto3" json:"send_at_unix_ms,omitempty"`
+	ReplyToMsgId  string                 `protobuf:"bytes,5,opt,name=reply_to_msg_id,json=replyToMsgId,proto3" json:"reply_to_msg_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledMessage) Reset() {
+	*x = ScheduledMessage{}
+	mi := &file_wpp_v1_message_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledMessage) ProtoMessage() {}
+
+func (x *ScheduledMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledMessage.ProtoReflect.Descriptor instead.
+func (*ScheduledMessage) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ScheduledMessage) GetClientMsgId() string {
+	if x != nil {
+		return x.ClientMsgId
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetSendAtUnixMs() int64 {
+	if x != nil {
+		return x.SendAtUnixMs
+	}
+	return 0
+}
+
+func (x *ScheduledMessage) GetReplyToMsgId() string {
+	if x != nil {
+		return x.ReplyToMsgId
+	}
+	return ""
+}
+
+type ListScheduledRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatJid       string                 `protobuf:"bytes,1,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"` // optional: filter to specific chat
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledRequest) Reset() {
+	*x = ListScheduledRequest{}
+	mi := &file_wpp_v1_message_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledRequest) ProtoMessage() {}
+
+func (x *ListScheduledRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListScheduledRequest) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+type ListScheduledResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*ScheduledMessage    `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"` // soonest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledResponse) Reset() {
+	*x = ListScheduledResponse{}
+	mi := &file_wpp_v1_message_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledResponse) ProtoMessage() {}
+
+func (x *ListScheduledResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledResponse) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListScheduledResponse) GetMessages() []*ScheduledMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+// CancelScheduledRequest drops a text that is still scheduled for later.
+type CancelScheduledRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientMsgId   string                 `protobuf:"bytes,1,opt,name=client_msg_id,json=clientMsgId,proto3" json:"client_msg_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledRequest) Reset() {
+	*x = CancelScheduledRequest{}
+	mi := &file_wpp_v1_message_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledRequest) ProtoMessage() {}
+
+func (x *CancelScheduledRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduledRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CancelScheduledRequest) GetClientMsgId() string {
+	if x != nil {
+		return x.ClientMsgId
+	}
+	return ""
+}
+
+type CancelScheduledResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledResponse) Reset() {
+	*x = CancelScheduledResponse{}
+	mi := &file_wpp_v1_message_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledResponse) ProtoMessage() {}
+
+func (x *CancelScheduledResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledResponse.ProtoReflect.Descriptor instead.
+func (*CancelScheduledResponse) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{28}
+}
+
+type DownloadMediaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatJid       string                 `protobuf:"bytes,1,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	MsgId         string                 `protobuf:"bytes,2,opt,name=msg_id,json=msgId,proto3" json:"msg_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadMediaRequest) Reset() {
+	*x = DownloadMediaRequest{}
+	mi := &file_wpp_v1_message_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadMediaRequest) ProtoMessage() {}
+
+func (x *DownloadMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadMediaRequest.ProtoReflect.Descriptor instead.
+func (*DownloadMediaRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DownloadMediaRequest) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+func (x *DownloadMediaRequest) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+type DownloadMediaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // local path of the cached file
+	Mimetype      string                 `protobuf:"bytes,2,opt,name=mimetype,proto3" json:"mimetype,omitempty"`
+	FileName      string                 `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileLength    int64                  `protobuf:"varint,4,opt,name=file_length,json=fileLength,proto3" json:"file_length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadMediaResponse) Reset() {
+	*x = DownloadMediaResponse{}
+	mi := &file_wpp_v1_message_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadMediaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadMediaResponse) ProtoMessage() {}
+
+func (x *DownloadMediaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_message_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadMediaResponse.ProtoReflect.Descriptor instead.
+func (*DownloadMediaResponse) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DownloadMediaResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *DownloadMediaResponse) GetMimetype() string {
+	if x != nil {
+		return x.Mimetype
+	}
+	return ""
+}
+
+func (x *DownloadMediaResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *DownloadMediaResponse) GetFileLength() int64 {
+	if x != nil {
+		return x.FileLength
+	}
+	return 0
+}
+
+type WatchMessageEventsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChatJid       string                 `protobuf:"bytes,1,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"` // optional: filter to specific chat
 	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
@@ -519,7 +1861,7 @@ type WatchMessageEventsRequest struct {
 
 func (x *WatchMessageEventsRequest) Reset() {
 	*x = WatchMessageEventsRequest{}
-	mi := &file_wpp_v1_message_proto_msgTypes[8]
+	mi := &file_wpp_v1_message_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -531,7 +1873,7 @@ func (x *WatchMessageEventsRequest) String() string {
 func (*WatchMessageEventsRequest) ProtoMessage() {}
 
 func (x *WatchMessageEventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_wpp_v1_message_proto_msgTypes[8]
+	mi := &file_wpp_v1_message_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -544,7 +1886,7 @@ func (x *WatchMessageEventsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WatchMessageEventsRequest.ProtoReflect.Descriptor instead.
 func (*WatchMessageEventsRequest) Descriptor() ([]byte, []int) {
-	return file_wpp_v1_message_proto_rawDescGZIP(), []int{8}
+	return file_wpp_v1_message_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *WatchMessageEventsRequest) GetChatJid() string {
@@ -570,7 +1912,12 @@ const file_wpp_v1_message_proto_rawDesc = "" +
 	"\bchat_jid\x18\x01 \x01(\tR\achatJid\x122\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2\x12.wpp.v1.PaginationR\n" +
-	"pagination\"\x88\x02\n" +
+	"pagination\"E\n" +
+	"\x11GetMessageRequest\x12\x19\n" +
+	"\bchat_jid\x18\x01 \x01(\tR\achatJid\x12\x15\n" +
+	"\x06msg_id\x18\x02 \x01(\tR\x05msgId\"?\n" +
+	"\x12GetMessageResponse\x12)\n" +
+	"\amessage\x18\x01 \x01(\v2\x0f.wpp.v1.MessageR\amessage\"\x95\x04\n" +
 	"\aMessage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\bchat_jid\x18\x02 \x01(\tR\achatJid\x12\x1d\n" +
@@ -582,7 +1929,30 @@ const file_wpp_v1_message_proto_rawDesc = "" +
 	"\x11timestamp_unix_ms\x18\x06 \x01(\x03R\x0ftimestampUnixMs\x12\x17\n" +
 	"\afrom_me\x18\a \x01(\bR\x06fromMe\x12!\n" +
 	"\fmessage_type\x18\b \x01(\tR\vmessageType\x12\x16\n" +
-	"\x06status\x18\t \x01(\tR\x06status\"r\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12)\n" +
+	"\x11edited_at_unix_ms\x18\n" +
+	" \x01(\x03R\x0eeditedAtUnixMs\x12\x18\n" +
+	"\adeleted\x18\v \x01(\bR\adeleted\x123\n" +
+	"\treactions\x18\f \x03(\v2\x15.wpp.v1.ReactionCountR\treactions\x12\"\n" +
+	"\rquoted_msg_id\x18\r \x01(\tR\vquotedMsgId\x12#\n" +
+	"\rquoted_sender\x18\x0e \x01(\tR\fquotedSender\x12\x1f\n" +
+	"\vquoted_body\x18\x0f \x01(\tR\n" +
+	"quotedBody\x12'\n" +
+	"\x05media\x18\x10 \x01(\v2\x11.wpp.v1.MediaInfoR\x05media\"\xb9\x01\n" +
+	"\tMediaInfo\x12\x1d\n" +
+	"\n" +
+	"media_type\x18\x01 \x01(\tR\tmediaType\x12\x1a\n" +
+	"\bmimetype\x18\x02 \x01(\tR\bmimetype\x12\x1f\n" +
+	"\vfile_length\x18\x03 \x01(\x03R\n" +
+	"fileLength\x12\x1b\n" +
+	"\tfile_name\x18\x04 \x01(\tR\bfileName\x12\x1d\n" +
+	"\n" +
+	"local_path\x18\x05 \x01(\tR\tlocalPath\x12\x14\n" +
+	"\x05voice\x18\x06 \x01(\bR\x05voice\"T\n" +
+	"\rReactionCount\x12\x14\n" +
+	"\x05emoji\x18\x01 \x01(\tR\x05emoji\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x17\n" +
+	"\afrom_me\x18\x03 \x01(\bR\x06fromMe\"r\n" +
 	"\x14ListMessagesResponse\x12+\n" +
 	"\bmessages\x18\x01 \x03(\v2\x0f.wpp.v1.MessageR\bmessages\x12-\n" +
 	"\tpage_info\x18\x02 \x01(\v2\x10.wpp.v1.PageInfoR\bpageInfo\"|\n" +
@@ -597,21 +1967,97 @@ const file_wpp_v1_message_proto_rawDesc = "" +
 	"\asnippet\x18\x02 \x01(\tR\asnippet\"w\n" +
 	"\x16SearchMessagesResponse\x12.\n" +
 	"\aresults\x18\x01 \x03(\v2\x14.wpp.v1.SearchResultR\aresults\x12-\n" +
-	"\tpage_info\x18\x02 \x01(\v2\x10.wpp.v1.PageInfoR\bpageInfo\"d\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x10.wpp.v1.PageInfoR\bpageInfo\"\xb2\x01\n" +
 	"\x0fSendTextRequest\x12\"\n" +
 	"\rclient_msg_id\x18\x01 \x01(\tR\vclientMsgId\x12\x19\n" +
 	"\bchat_jid\x18\x02 \x01(\tR\achatJid\x12\x12\n" +
-	"\x04text\x18\x03 \x01(\tR\x04text\"H\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12%\n" +
+	"\x0freply_to_msg_id\x18\x04 \x01(\tR\freplyToMsgId\x12%\n" +
+	"\x0fsend_at_unix_ms\x18\x05 \x01(\x03R\fsendAtUnixMs\"H\n" +
 	"\x10SendTextResponse\x12\x1a\n" +
 	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"N\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xe2\x01\n" +
+	"\x10SendMediaRequest\x12\"\n" +
+	"\rclient_msg_id\x18\x01 \x01(\tR\vclientMsgId\x12\x19\n" +
+	"\bchat_jid\x18\x02 \x01(\tR\achatJid\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12\x18\n" +
+	"\acaption\x18\x04 \x01(\tR\acaption\x12\x1d\n" +
+	"\n" +
+	"media_type\x18\x05 \x01(\tR\tmediaType\x12\x1b\n" +
+	"\tfile_name\x18\x06 \x01(\tR\bfileName\x12%\n" +
+	"\x0freply_to_msg_id\x18\a \x01(\tR\freplyToMsgId\"I\n" +
+	"\x11SendMediaResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x81\x01\n" +
+	"\x13SendReactionRequest\x12\"\n" +
+	"\rclient_msg_id\x18\x01 \x01(\tR\vclientMsgId\x12\x19\n" +
+	"\bchat_jid\x18\x02 \x01(\tR\achatJid\x12\x15\n" +
+	"\x06msg_id\x18\x03 \x01(\tR\x05msgId\x12\x14\n" +
+	"\x05emoji\x18\x04 \x01(\tR\x05emoji\"L\n" +
+	"\x14SendReactionResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"~\n" +
+	"\x12EditMessageRequest\x12\"\n" +
+	"\rclient_msg_id\x18\x01 \x01(\tR\vclientMsgId\x12\x19\n" +
+	"\bchat_jid\x18\x02 \x01(\tR\achatJid\x12\x15\n" +
+	"\x06msg_id\x18\x03 \x01(\tR\x05msgId\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\"K\n" +
+	"\x13EditMessageResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"l\n" +
+	"\x14RevokeMessageRequest\x12\"\n" +
+	"\rclient_msg_id\x18\x01 \x01(\tR\vclientMsgId\x12\x19\n" +
+	"\bchat_jid\x18\x02 \x01(\tR\achatJid\x12\x15\n" +
+	"\x06msg_id\x18\x03 \x01(\tR\x05msgId\"M\n" +
+	"\x15RevokeMessageResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"9\n" +
+	"\x13RetryMessageRequest\x12\"\n" +
+	"\rclient_msg_id\x18\x01 \x01(\tR\vclientMsgId\"\x16\n" +
+	"\x14RetryMessageResponse\":\n" +
+	"\x14CancelMessageRequest\x12\"\n" +
+	"\rclient_msg_id\x18\x01 \x01(\tR\vclientMsgId\"\x17\n" +
+	"\x15CancelMessageResponse\"\xb3\x01\n" +
+	"\x10ScheduledMessage\x12\"\n" +
+	"\rclient_msg_id\x18\x01 \x01(\tR\vclientMsgId\x12\x19\n" +
+	"\bchat_jid\x18\x02 \x01(\tR\achatJid\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12%\n" +
+	"\x0fsend_at_unix_ms\x18\x04 \x01(\x03R\fsendAtUnixMs\x12%\n" +
+	"\x0freply_to_msg_id\x18\x05 \x01(\tR\freplyToMsgId\"1\n" +
+	"\x14ListScheduledRequest\x12\x19\n" +
+	"\bchat_jid\x18\x01 \x01(\tR\achatJid\"M\n" +
+	"\x15ListScheduledResponse\x124\n" +
+	"\bmessages\x18\x01 \x03(\v2\x18.wpp.v1.ScheduledMessageR\bmessages\"<\n" +
+	"\x16CancelScheduledRequest\x12\"\n" +
+	"\rclient_msg_id\x18\x01 \x01(\tR\vclientMsgId\"\x19\n" +
+	"\x17CancelScheduledResponse\"H\n" +
+	"\x14DownloadMediaRequest\x12\x19\n" +
+	"\bchat_jid\x18\x01 \x01(\tR\achatJid\x12\x15\n" +
+	"\x06msg_id\x18\x02 \x01(\tR\x05msgId\"\x85\x01\n" +
+	"\x15DownloadMediaResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1a\n" +
+	"\bmimetype\x18\x02 \x01(\tR\bmimetype\x12\x1b\n" +
+	"\tfile_name\x18\x03 \x01(\tR\bfileName\x12\x1f\n" +
+	"\vfile_length\x18\x04 \x01(\x03R\n" +
+	"fileLength\"N\n" +
 	"\x19WatchMessageEventsRequest\x12\x19\n" +
 	"\bchat_jid\x18\x01 \x01(\tR\achatJid\x12\x16\n" +
-	"\x06cursor\x18\x02 \x01(\tR\x06cursor2\xbd\x02\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor2\xae\b\n" +
 	"\x0eMessageService\x12I\n" +
-	"\fListMessages\x12\x1b.wpp.v1.ListMessagesRequest\x1a\x1c.wpp.v1.ListMessagesResponse\x12O\n" +
+	"\fListMessages\x12\x1b.wpp.v1.ListMessagesRequest\x1a\x1c.wpp.v1.ListMessagesResponse\x12C\n" +
+	"\n" +
+	"GetMessage\x12\x19.wpp.v1.GetMessageRequest\x1a\x1a.wpp.v1.GetMessageResponse\x12O\n" +
 	"\x0eSearchMessages\x12\x1d.wpp.v1.SearchMessagesRequest\x1a\x1e.wpp.v1.SearchMessagesResponse\x12=\n" +
-	"\bSendText\x12\x17.wpp.v1.SendTextRequest\x1a\x18.wpp.v1.SendTextResponse\x12P\n" +
+	"\bSendText\x12\x17.wpp.v1.SendTextRequest\x1a\x18.wpp.v1.SendTextResponse\x12@\n" +
+	"\tSendMedia\x12\x18.wpp.v1.SendMediaRequest\x1a\x19.wpp.v1.SendMediaResponse\x12I\n" +
+	"\fSendReaction\x12\x1b.wpp.v1.SendReactionRequest\x1a\x1c.wpp.v1.SendReactionResponse\x12F\n" +
+	"\vEditMessage\x12\x1a.wpp.v1.EditMessageRequest\x1a\x1b.wpp.v1.EditMessageResponse\x12L\n" +
+	"\rRevokeMessage\x12\x1c.wpp.v1.RevokeMessageRequest\x1a\x1d.wpp.v1.RevokeMessageResponse\x12I\n" +
+	"\fRetryMessage\x12\x1b.wpp.v1.RetryMessageRequest\x1a\x1c.wpp.v1.RetryMessageResponse\x12L\n" +
+	"\rCancelMessage\x12\x1c.wpp.v1.CancelMessageRequest\x1a\x1d.wpp.v1.CancelMessageResponse\x12L\n" +
+	"\rListScheduled\x12\x1c.wpp.v1.ListScheduledRequest\x1a\x1d.wpp.v1.ListScheduledResponse\x12R\n" +
+	"\x0fCancelScheduled\x12\x1e.wpp.v1.CancelScheduledRequest\x1a\x1f.wpp.v1.CancelScheduledResponse\x12L\n" +
+	"\rDownloadMedia\x12\x1c.wpp.v1.DownloadMediaRequest\x1a\x1d.wpp.v1.DownloadMediaResponse\x12P\n" +
 	"\x12WatchMessageEvents\x12!.wpp.v1.WatchMessageEventsRequest\x1a\x15.wpp.v1.EventEnvelope0\x01B-Z+github.com/matheus3301/wpp/gen/wpp/v1;wppv1b\x06proto3"
 
 var (
@@ -626,42 +2072,89 @@ func file_wpp_v1_message_proto_rawDescGZIP() []byte {
 	return file_wpp_v1_message_proto_rawDescData
 }
 
-var file_wpp_v1_message_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_wpp_v1_message_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_wpp_v1_message_proto_goTypes = []any{
 	(*ListMessagesRequest)(nil),       // 0: wpp.v1.ListMessagesRequest
-	(*Message)(nil),                   // 1: wpp.v1.Message
-	(*ListMessagesResponse)(nil),      // 2: wpp.v1.ListMessagesResponse
-	(*SearchMessagesRequest)(nil),     // 3: wpp.v1.SearchMessagesRequest
-	(*SearchResult)(nil),              // 4: wpp.v1.SearchResult
-	(*SearchMessagesResponse)(nil),    // 5: wpp.v1.SearchMessagesResponse
-	(*SendTextRequest)(nil),           // 6: wpp.v1.SendTextRequest
-	(*SendTextResponse)(nil),          // 7: wpp.v1.SendTextResponse
-	(*WatchMessageEventsRequest)(nil), // 8: wpp.v1.WatchMessageEventsRequest
-	(*Pagination)(nil),                // 9: wpp.v1.Pagination
-	(*PageInfo)(nil),                  // 10: wpp.v1.PageInfo
-	(*EventEnvelope)(nil),             // 11: wpp.v1.EventEnvelope
+	(*GetMessageRequest)(nil),         // 1: wpp.v1.GetMessageRequest
+	(*GetMessageResponse)(nil),        // 2: wpp.v1.GetMessageResponse
+	(*Message)(nil),                   // 3: wpp.v1.Message
+	(*MediaInfo)(nil),                 // 4: wpp.v1.MediaInfo
+	(*ReactionCount)(nil),             // 5: wpp.v1.ReactionCount
+	(*ListMessagesResponse)(nil),      // 6: wpp.v1.ListMessagesResponse
+	(*SearchMessagesRequest)(nil),     // 7: wpp.v1.SearchMessagesRequest
+	(*SearchResult)(nil),              // 8: wpp.v1.SearchResult
+	(*SearchMessagesResponse)(nil),    // 9: wpp.v1.SearchMessagesResponse
+	(*SendTextRequest)(nil),           // 10: wpp.v1.SendTextRequest
+	(*SendTextResponse)(nil),          // 11: wpp.v1.SendTextResponse
+	(*SendMediaRequest)(nil),          // 12: wpp.v1.SendMediaRequest
+	(*SendMediaResponse)(nil),         // 13: wpp.v1.SendMediaResponse
+	(*SendReactionRequest)(nil),       // 14: wpp.v1.SendReactionRequest
+	(*SendReactionResponse)(nil),      // 15: wpp.v1.SendReactionResponse
+	(*EditMessageRequest)(nil),        // 16: wpp.v1.EditMessageRequest
+	(*EditMessageResponse)(nil),       // 17: wpp.v1.EditMessageResponse
+	(*RevokeMessageRequest)(nil),      // 18: wpp.v1.RevokeMessageRequest
+	(*RevokeMessageResponse)(nil),     // 19: wpp.v1.RevokeMessageResponse
+	(*RetryMessageRequest)(nil),       // 20: wpp.v1.RetryMessageRequest
+	(*RetryMessageResponse)(nil),      // 21: wpp.v1.RetryMessageResponse
+	(*CancelMessageRequest)(nil),      // 22: wpp.v1.CancelMessageRequest
+	(*CancelMessageResponse)(nil),     // 23: wpp.v1.CancelMessageResponse
+	(*ScheduledMessage)(nil),          // 24: wpp.v1.ScheduledMessage
+	(*ListScheduledRequest)(nil),      // 25: wpp.v1.ListScheduledRequest
+	(*ListScheduledResponse)(nil),     // 26: wpp.v1.ListScheduledResponse
+	(*CancelScheduledRequest)(nil),    // 27: wpp.v1.CancelScheduledRequest
+	(*CancelScheduledResponse)(nil),   // 28: wpp.v1.CancelScheduledResponse
+	(*DownloadMediaRequest)(nil),      // 29: wpp.v1.DownloadMediaRequest
+	(*DownloadMediaResponse)(nil),     // 30: wpp.v1.DownloadMediaResponse
+	(*WatchMessageEventsRequest)(nil), // 31: wpp.v1.WatchMessageEventsRequest
+	(*Pagination)(nil),                // 32: wpp.v1.Pagination
+	(*PageInfo)(nil),                  // 33: wpp.v1.PageInfo
+	(*EventEnvelope)(nil),             // 34: wpp.v1.EventEnvelope
 }
 var file_wpp_v1_message_proto_depIdxs = []int32{
-	9,  // 0: wpp.v1.ListMessagesRequest.pagination:type_name -> wpp.v1.Pagination
-	1,  // 1: wpp.v1.ListMessagesResponse.messages:type_name -> wpp.v1.Message
-	10, // 2: wpp.v1.ListMessagesResponse.page_info:type_name -> wpp.v1.PageInfo
-	9,  // 3: wpp.v1.SearchMessagesRequest.pagination:type_name -> wpp.v1.Pagination
-	1,  // 4: wpp.v1.SearchResult.message:type_name -> wpp.v1.Message
-	4,  // 5: wpp.v1.SearchMessagesResponse.results:type_name -> wpp.v1.SearchResult
-	10, // 6: wpp.v1.SearchMessagesResponse.page_info:type_name -> wpp.v1.PageInfo
-	0,  // 7: wpp.v1.MessageService.ListMessages:input_type -> wpp.v1.ListMessagesRequest
-	3,  // 8: wpp.v1.MessageService.SearchMessages:input_type -> wpp.v1.SearchMessagesRequest
-	6,  // 9: wpp.v1.MessageService.SendText:input_type -> wpp.v1.SendTextRequest
-	8,  // 10: wpp.v1.MessageService.WatchMessageEvents:input_type -> wpp.v1.WatchMessageEventsRequest
-	2,  // 11: wpp.v1.MessageService.ListMessages:output_type -> wpp.v1.ListMessagesResponse
-	5,  // 12: wpp.v1.MessageService.SearchMessages:output_type -> wpp.v1.SearchMessagesResponse
-	7,  // 13: wpp.v1.MessageService.SendText:output_type -> wpp.v1.SendTextResponse
-	11, // 14: wpp.v1.MessageService.WatchMessageEvents:output_type -> wpp.v1.EventEnvelope
-	11, // [11:15] is the sub-list for method output_type
-	7,  // [7:11] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	32, // 0: wpp.v1.ListMessagesRequest.pagination:type_name -> wpp.v1.Pagination
+	3,  // 1: wpp.v1.GetMessageResponse.message:type_name -> wpp.v1.Message
+	5,  // 2: wpp.v1.Message.reactions:type_name -> wpp.v1.ReactionCount
+	4,  // 3: wpp.v1.Message.media:type_name -> wpp.v1.MediaInfo
+	3,  // 4: wpp.v1.ListMessagesResponse.messages:type_name -> wpp.v1.Message
+	33, // 5: wpp.v1.ListMessagesResponse.page_info:type_name -> wpp.v1.PageInfo
+	32, // 6: wpp.v1.SearchMessagesRequest.pagination:type_name -> wpp.v1.Pagination
+	3,  // 7: wpp.v1.SearchResult.message:type_name -> wpp.v1.Message
+	8,  // 8: wpp.v1.SearchMessagesResponse.results:type_name -> wpp.v1.SearchResult
+	33, // 9: wpp.v1.SearchMessagesResponse.page_info:type_name -> wpp.v1.PageInfo
+	24, // 10: wpp.v1.ListScheduledResponse.messages:type_name -> wpp.v1.ScheduledMessage
+	0,  // 11: wpp.v1.MessageService.ListMessages:input_type -> wpp.v1.ListMessagesRequest
+	1,  // 12: wpp.v1.MessageService.GetMessage:input_type -> wpp.v1.GetMessageRequest
+	7,  // 13: wpp.v1.MessageService.SearchMessages:input_type -> wpp.v1.SearchMessagesRequest
+	10, // 14: wpp.v1.MessageService.SendText:input_type -> wpp.v1.SendTextRequest
+	12, // 15: wpp.v1.MessageService.SendMedia:input_type -> wpp.v1.SendMediaRequest
+	14, // 16: wpp.v1.MessageService.SendReaction:input_type -> wpp.v1.SendReactionRequest
+	16, // 17: wpp.v1.MessageService.EditMessage:input_type -> wpp.v1.EditMessageRequest
+	18, // 18: wpp.v1.MessageService.RevokeMessage:input_type -> wpp.v1.RevokeMessageRequest
+	20, // 19: wpp.v1.MessageService.RetryMessage:input_type -> wpp.v1.RetryMessageRequest
+	22, // 20: wpp.v1.MessageService.CancelMessage:input_type -> wpp.v1.CancelMessageRequest
+	25, // 21: wpp.v1.MessageService.ListScheduled:input_type -> wpp.v1.ListScheduledRequest
+	27, // 22: wpp.v1.MessageService.CancelScheduled:input_type -> wpp.v1.CancelScheduledRequest
+	29, // 23: wpp.v1.MessageService.DownloadMedia:input_type -> wpp.v1.DownloadMediaRequest
+	31, // 24: wpp.v1.MessageService.WatchMessageEvents:input_type -> wpp.v1.WatchMessageEventsRequest
+	6,  // 25: wpp.v1.MessageService.ListMessages:output_type -> wpp.v1.ListMessagesResponse
+	2,  // 26: wpp.v1.MessageService.GetMessage:output_type -> wpp.v1.GetMessageResponse
+	9,  // 27: wpp.v1.MessageService.SearchMessages:output_type -> wpp.v1.SearchMessagesResponse
+	11, // 28: wpp.v1.MessageService.SendText:output_type -> wpp.v1.SendTextResponse
+	13, // 29: wpp.v1.MessageService.SendMedia:output_type -> wpp.v1.SendMediaResponse
+	15, // 30: wpp.v1.MessageService.SendReaction:output_type -> wpp.v1.SendReactionResponse
+	17, // 31: wpp.v1.MessageService.EditMessage:output_type -> wpp.v1.EditMessageResponse
+	19, // 32: wpp.v1.MessageService.RevokeMessage:output_type -> wpp.v1.RevokeMessageResponse
+	21, // 33: wpp.v1.MessageService.RetryMessage:output_type -> wpp.v1.RetryMessageResponse
+	23, // 34: wpp.v1.MessageService.CancelMessage:output_type -> wpp.v1.CancelMessageResponse
+	26, // 35: wpp.v1.MessageService.ListScheduled:output_type -> wpp.v1.ListScheduledResponse
+	28, // 36: wpp.v1.MessageService.CancelScheduled:output_type -> wpp.v1.CancelScheduledResponse
+	30, // 37: wpp.v1.MessageService.DownloadMedia:output_type -> wpp.v1.DownloadMediaResponse
+	34, // 38: wpp.v1.MessageService.WatchMessageEvents:output_type -> wpp.v1.EventEnvelope
+	25, // [25:39] is the sub-list for method output_type
+	11, // [11:25] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_wpp_v1_message_proto_init() }
@@ -676,7 +2169,7 @@ func file_wpp_v1_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_wpp_v1_message_proto_rawDesc), len(file_wpp_v1_message_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
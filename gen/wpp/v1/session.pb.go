@@ -473,6 +473,186 @@ func (x *ListSessionsResponse) GetSessions() []*SessionDescriptor {
 	return nil
 }
 
+type GetBusStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBusStatsRequest) Reset() {
+	*x = GetBusStatsRequest{}
+	mi := &file_wpp_v1_session_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBusStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBusStatsRequest) ProtoMessage() {}
+
+func (x *GetBusStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_session_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBusStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetBusStatsRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_session_proto_rawDescGZIP(), []int{9}
+}
+
+type BusSubscriber struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Lossless      bool                   `protobuf:"varint,3,opt,name=lossless,proto3" json:"lossless,omitempty"` // queued without bound instead of dropped when full
+	Delivered     int64                  `protobuf:"varint,4,opt,name=delivered,proto3" json:"delivered,omitempty"`
+	Dropped       int64                  `protobuf:"varint,5,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	Queued        int32                  `protobuf:"varint,6,opt,name=queued,proto3" json:"queued,omitempty"` // events waiting in a lossless queue
+	MaxQueued     int32                  `protobuf:"varint,7,opt,name=max_queued,json=maxQueued,proto3" json:"max_queued,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BusSubscriber) Reset() {
+	*x = BusSubscriber{}
+	mi := &file_wpp_v1_session_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BusSubscriber) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BusSubscriber) ProtoMessage() {}
+
+func (x *BusSubscriber) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_session_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BusSubscriber.ProtoReflect.Descriptor instead.
+func (*BusSubscriber) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_session_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BusSubscriber) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BusSubscriber) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *BusSubscriber) GetLossless() bool {
+	if x != nil {
+		return x.Lossless
+	}
+	return false
+}
+
+func (x *BusSubscriber) GetDelivered() int64 {
+	if x != nil {
+		return x.Delivered
+	}
+	return 0
+}
+
+func (x *BusSubscriber) GetDropped() int64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+func (x *BusSubscriber) GetQueued() int32 {
+	if x != nil {
+		return x.Queued
+	}
+	return 0
+}
+
+func (x *BusSubscriber) GetMaxQueued() int32 {
+	if x != nil {
+		return x.MaxQueued
+	}
+	return 0
+}
+
+type GetBusStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscribers   []*BusSubscriber       `protobuf:"bytes,1,rep,name=subscribers,proto3" json:"subscribers,omitempty"`
+	TotalDropped  int64                  `protobuf:"varint,2,opt,name=total_dropped,json=totalDropped,proto3" json:"total_dropped,omitempty"` // drops since start, including closed subscriptions
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBusStatsResponse) Reset() {
+	*x = GetBusStatsResponse{}
+	mi := &file_wpp_v1_session_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBusStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBusStatsResponse) ProtoMessage() {}
+
+func (x *GetBusStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_session_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBusStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetBusStatsResponse) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_session_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetBusStatsResponse) GetSubscribers() []*BusSubscriber {
+	if x != nil {
+		return x.Subscribers
+	}
+	return nil
+}
+
+func (x *GetBusStatsResponse) GetTotalDropped() int64 {
+	if x != nil {
+		return x.TotalDropped
+	}
+	return 0
+}
+
 var File_wpp_v1_session_proto protoreflect.FileDescriptor
 
 const file_wpp_v1_session_proto_rawDesc = "" +
@@ -504,12 +684,26 @@ const file_wpp_v1_session_proto_rawDesc = "" +
 	"\x04path\x18\x02 \x01(\tR\x04path\x12%\n" +
 	"\x0edaemon_running\x18\x03 \x01(\bR\rdaemonRunning\"M\n" +
 	"\x14ListSessionsResponse\x125\n" +
-	"\bsessions\x18\x01 \x03(\v2\x19.wpp.v1.SessionDescriptorR\bsessions2\xa7\x02\n" +
+	"\bsessions\x18\x01 \x03(\v2\x19.wpp.v1.SessionDescriptorR\bsessions\"\x14\n" +
+	"\x12GetBusStatsRequest\"\xcc\x01\n" +
+	"\rBusSubscriber\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x1a\n" +
+	"\blossless\x18\x03 \x01(\bR\blossless\x12\x1c\n" +
+	"\tdelivered\x18\x04 \x01(\x03R\tdelivered\x12\x18\n" +
+	"\adropped\x18\x05 \x01(\x03R\adropped\x12\x16\n" +
+	"\x06queued\x18\x06 \x01(\x05R\x06queued\x12\x1d\n" +
+	"\n" +
+	"max_queued\x18\a \x01(\x05R\tmaxQueued\"s\n" +
+	"\x13GetBusStatsResponse\x127\n" +
+	"\vsubscribers\x18\x01 \x03(\v2\x15.wpp.v1.BusSubscriberR\vsubscribers\x12#\n" +
+	"\rtotal_dropped\x18\x02 \x01(\x03R\ftotalDropped2\xef\x02\n" +
 	"\x0eSessionService\x12U\n" +
 	"\x10GetSessionStatus\x12\x1f.wpp.v1.GetSessionStatusRequest\x1a .wpp.v1.GetSessionStatusResponse\x12:\n" +
 	"\tStartAuth\x12\x18.wpp.v1.StartAuthRequest\x1a\x11.wpp.v1.AuthEvent0\x01\x127\n" +
 	"\x06Logout\x12\x15.wpp.v1.LogoutRequest\x1a\x16.wpp.v1.LogoutResponse\x12I\n" +
-	"\fListSessions\x12\x1b.wpp.v1.ListSessionsRequest\x1a\x1c.wpp.v1.ListSessionsResponseB-Z+github.com/matheus3301/wpp/gen/wpp/v1;wppv1b\x06proto3"
+	"\fListSessions\x12\x1b.wpp.v1.ListSessionsRequest\x1a\x1c.wpp.v1.ListSessionsResponse\x12F\n" +
+	"\vGetBusStats\x12\x1a.wpp.v1.GetBusStatsRequest\x1a\x1b.wpp.v1.GetBusStatsResponseB-Z+github.com/matheus3301/wpp/gen/wpp/v1;wppv1b\x06proto3"
 
 var (
 	file_wpp_v1_session_proto_rawDescOnce sync.Once
@@ -523,7 +717,7 @@ func file_wpp_v1_session_proto_rawDescGZIP() []byte {
 	return file_wpp_v1_session_proto_rawDescData
 }
 
-var file_wpp_v1_session_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_wpp_v1_session_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_wpp_v1_session_proto_goTypes = []any{
 	(*GetSessionStatusRequest)(nil),  // 0: wpp.v1.GetSessionStatusRequest
 	(*GetSessionStatusResponse)(nil), // 1: wpp.v1.GetSessionStatusResponse
@@ -534,24 +728,30 @@ var file_wpp_v1_session_proto_goTypes = []any{
 	(*ListSessionsRequest)(nil),      // 6: wpp.v1.ListSessionsRequest
 	(*SessionDescriptor)(nil),        // 7: wpp.v1.SessionDescriptor
 	(*ListSessionsResponse)(nil),     // 8: wpp.v1.ListSessionsResponse
-	(SessionStatus)(0),               // 9: wpp.v1.SessionStatus
+	(*GetBusStatsRequest)(nil),       // 9: wpp.v1.GetBusStatsRequest
+	(*BusSubscriber)(nil),            // 10: wpp.v1.BusSubscriber
+	(*GetBusStatsResponse)(nil),      // 11: wpp.v1.GetBusStatsResponse
+	(SessionStatus)(0),               // 12: wpp.v1.SessionStatus
 }
 var file_wpp_v1_session_proto_depIdxs = []int32{
-	9, // 0: wpp.v1.GetSessionStatusResponse.status:type_name -> wpp.v1.SessionStatus
-	7, // 1: wpp.v1.ListSessionsResponse.sessions:type_name -> wpp.v1.SessionDescriptor
-	0, // 2: wpp.v1.SessionService.GetSessionStatus:input_type -> wpp.v1.GetSessionStatusRequest
-	2, // 3: wpp.v1.SessionService.StartAuth:input_type -> wpp.v1.StartAuthRequest
-	4, // 4: wpp.v1.SessionService.Logout:input_type -> wpp.v1.LogoutRequest
-	6, // 5: wpp.v1.SessionService.ListSessions:input_type -> wpp.v1.ListSessionsRequest
-	1, // 6: wpp.v1.SessionService.GetSessionStatus:output_type -> wpp.v1.GetSessionStatusResponse
-	3, // 7: wpp.v1.SessionService.StartAuth:output_type -> wpp.v1.AuthEvent
-	5, // 8: wpp.v1.SessionService.Logout:output_type -> wpp.v1.LogoutResponse
-	8, // 9: wpp.v1.SessionService.ListSessions:output_type -> wpp.v1.ListSessionsResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	12, // 0: wpp.v1.GetSessionStatusResponse.status:type_name -> wpp.v1.SessionStatus
+	7,  // 1: wpp.v1.ListSessionsResponse.sessions:type_name -> wpp.v1.SessionDescriptor
+	10, // 2: wpp.v1.GetBusStatsResponse.subscribers:type_name -> wpp.v1.BusSubscriber
+	0,  // 3: wpp.v1.SessionService.GetSessionStatus:input_type -> wpp.v1.GetSessionStatusRequest
+	2,  // 4: wpp.v1.SessionService.StartAuth:input_type -> wpp.v1.StartAuthRequest
+	4,  // 5: wpp.v1.SessionService.Logout:input_type -> wpp.v1.LogoutRequest
+	6,  // 6: wpp.v1.SessionService.ListSessions:input_type -> wpp.v1.ListSessionsRequest
+	9,  // 7: wpp.v1.SessionService.GetBusStats:input_type -> wpp.v1.GetBusStatsRequest
+	1,  // 8: wpp.v1.SessionService.GetSessionStatus:output_type -> wpp.v1.GetSessionStatusResponse
+	3,  // 9: wpp.v1.SessionService.StartAuth:output_type -> wpp.v1.AuthEvent
+	5,  // 10: wpp.v1.SessionService.Logout:output_type -> wpp.v1.LogoutResponse
+	8,  // 11: wpp.v1.SessionService.ListSessions:output_type -> wpp.v1.ListSessionsResponse
+	11, // 12: wpp.v1.SessionService.GetBusStats:output_type -> wpp.v1.GetBusStatsResponse
+	8,  // [8:13] is the sub-list for method output_type
+	3,  // [3:8] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_wpp_v1_session_proto_init() }
@@ -566,7 +766,7 @@ func file_wpp_v1_session_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_wpp_v1_session_proto_rawDesc), len(file_wpp_v1_session_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,6 +23,7 @@ const (
 	SessionService_StartAuth_FullMethodName        = "/wpp.v1.SessionService/StartAuth"
 	SessionService_Logout_FullMethodName           = "/wpp.v1.SessionService/Logout"
 	SessionService_ListSessions_FullMethodName     = "/wpp.v1.SessionService/ListSessions"
+	SessionService_GetBusStats_FullMethodName      = "/wpp.v1.SessionService/GetBusStats"
 )
 
 // SessionServiceClient is the client API for SessionService service.
@@ -33,6 +34,7 @@ type SessionServiceClient interface {
 	StartAuth(ctx context.Context, in *StartAuthRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuthEvent], error)
 	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
 	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	GetBusStats(ctx context.Context, in *GetBusStatsRequest, opts ...grpc.CallOption) (*GetBusStatsResponse, error)
 }
 
 type sessionServiceClient struct {
@@ -92,6 +94,16 @@ func (c *sessionServiceClient) ListSessions(ctx context.Context, in *ListSession
 	return out, nil
 }
 
+func (c *sessionServiceClient) GetBusStats(ctx context.Context, in *GetBusStatsRequest, opts ...grpc.CallOption) (*GetBusStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBusStatsResponse)
+	err := c.cc.Invoke(ctx, SessionService_GetBusStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SessionServiceServer is the server API for SessionService service.
 // All implementations must embed UnimplementedSessionServiceServer
 // for forward compatibility.
@@ -100,6 +112,7 @@ type SessionServiceServer interface {
 	StartAuth(*StartAuthRequest, grpc.ServerStreamingServer[AuthEvent]) error
 	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
 	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	GetBusStats(context.Context, *GetBusStatsRequest) (*GetBusStatsResponse, error)
 	mustEmbedUnimplementedSessionServiceServer()
 }
 
@@ -122,6 +135,9 @@ func (UnimplementedSessionServiceServer) Logout(context.Context, *LogoutRequest)
 func (UnimplementedSessionServiceServer) ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListSessions not implemented")
 }
+func (UnimplementedSessionServiceServer) GetBusStats(context.Context, *GetBusStatsRequest) (*GetBusStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBusStats not implemented")
+}
 func (UnimplementedSessionServiceServer) mustEmbedUnimplementedSessionServiceServer() {}
 func (UnimplementedSessionServiceServer) testEmbeddedByValue()                        {}
 
@@ -208,6 +224,24 @@ func _SessionService_ListSessions_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SessionService_GetBusStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBusStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).GetBusStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionService_GetBusStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).GetBusStats(ctx, req.(*GetBusStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SessionService_ServiceDesc is the grpc.ServiceDesc for SessionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -227,6 +261,10 @@ var SessionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSessions",
 			Handler:    _SessionService_ListSessions_Handler,
 		},
+		{
+			MethodName: "GetBusStats",
+			Handler:    _SessionService_GetBusStats_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
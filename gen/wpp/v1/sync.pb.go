@@ -64,8 +64,12 @@ type GetSyncStatusResponse struct {
 	MessagesSynced   int64                  `protobuf:"varint,3,opt,name=messages_synced,json=messagesSynced,proto3" json:"messages_synced,omitempty"`
 	ChatsSynced      int64                  `protobuf:"varint,4,opt,name=chats_synced,json=chatsSynced,proto3" json:"chats_synced,omitempty"`
 	ErrorMessage     string                 `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// Latest history sync chunk other than an on-demand one: its sync type
+	// and percent done.
+	HistorySyncType string `protobuf:"bytes,6,opt,name=history_sync_type,json=historySyncType,proto3" json:"history_sync_type,omitempty"`
+	HistoryProgress int32  `protobuf:"varint,7,opt,name=history_progress,json=historyProgress,proto3" json:"history_progress,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *GetSyncStatusResponse) Reset() {
@@ -133,6 +137,20 @@ func (x *GetSyncStatusResponse) GetErrorMessage() string {
 	return ""
 }
 
+func (x *GetSyncStatusResponse) GetHistorySyncType() string {
+	if x != nil {
+		return x.HistorySyncType
+	}
+	return ""
+}
+
+func (x *GetSyncStatusResponse) GetHistoryProgress() int32 {
+	if x != nil {
+		return x.HistoryProgress
+	}
+	return 0
+}
+
 type StartSyncRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -353,18 +371,129 @@ func (x *WatchSyncEventsRequest) GetCursor() string {
 	return ""
 }
 
+// BackfillChatRequest asks the phone for messages older than the oldest
+// stored in a chat. They arrive as a sync.history_batch event carrying
+// the returned request_id.
+type BackfillChatRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ChatJid string                 `protobuf:"bytes,1,opt,name=chat_jid,json=chatJid,proto3" json:"chat_jid,omitempty"`
+	// Messages to ask for; 0 means 50.
+	Count         int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackfillChatRequest) Reset() {
+	*x = BackfillChatRequest{}
+	mi := &file_wpp_v1_sync_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackfillChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackfillChatRequest) ProtoMessage() {}
+
+func (x *BackfillChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_sync_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackfillChatRequest.ProtoReflect.Descriptor instead.
+func (*BackfillChatRequest) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_sync_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BackfillChatRequest) GetChatJid() string {
+	if x != nil {
+		return x.ChatJid
+	}
+	return ""
+}
+
+func (x *BackfillChatRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type BackfillChatResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	RequestId string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// The message the request is anchored at.
+	OldestMsgId   string `protobuf:"bytes,2,opt,name=oldest_msg_id,json=oldestMsgId,proto3" json:"oldest_msg_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackfillChatResponse) Reset() {
+	*x = BackfillChatResponse{}
+	mi := &file_wpp_v1_sync_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackfillChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackfillChatResponse) ProtoMessage() {}
+
+func (x *BackfillChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wpp_v1_sync_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackfillChatResponse.ProtoReflect.Descriptor instead.
+func (*BackfillChatResponse) Descriptor() ([]byte, []int) {
+	return file_wpp_v1_sync_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BackfillChatResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *BackfillChatResponse) GetOldestMsgId() string {
+	if x != nil {
+		return x.OldestMsgId
+	}
+	return ""
+}
+
 var File_wpp_v1_sync_proto protoreflect.FileDescriptor
 
 const file_wpp_v1_sync_proto_rawDesc = "" +
 	"\n" +
 	"\x11wpp/v1/sync.proto\x12\x06wpp.v1\x1a\x13wpp/v1/common.proto\"\x16\n" +
-	"\x14GetSyncStatusRequest\"\xd2\x01\n" +
+	"\x14GetSyncStatusRequest\"\xa9\x02\n" +
 	"\x15GetSyncStatusResponse\x12\x18\n" +
 	"\asyncing\x18\x01 \x01(\bR\asyncing\x12.\n" +
 	"\x14last_sync_at_unix_ms\x18\x02 \x01(\x03R\x10lastSyncAtUnixMs\x12'\n" +
 	"\x0fmessages_synced\x18\x03 \x01(\x03R\x0emessagesSynced\x12!\n" +
 	"\fchats_synced\x18\x04 \x01(\x03R\vchatsSynced\x12#\n" +
-	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage\"\x12\n" +
+	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage\x12*\n" +
+	"\x11history_sync_type\x18\x06 \x01(\tR\x0fhistorySyncType\x12)\n" +
+	"\x10history_progress\x18\a \x01(\x05R\x0fhistoryProgress\"\x12\n" +
 	"\x10StartSyncRequest\"G\n" +
 	"\x11StartSyncResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
@@ -374,12 +503,20 @@ const file_wpp_v1_sync_proto_rawDesc = "" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"0\n" +
 	"\x16WatchSyncEventsRequest\x12\x16\n" +
-	"\x06cursor\x18\x01 \x01(\tR\x06cursor2\xa8\x02\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\"F\n" +
+	"\x13BackfillChatRequest\x12\x19\n" +
+	"\bchat_jid\x18\x01 \x01(\tR\achatJid\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"Y\n" +
+	"\x14BackfillChatResponse\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\"\n" +
+	"\roldest_msg_id\x18\x02 \x01(\tR\voldestMsgId2\xf3\x02\n" +
 	"\vSyncService\x12L\n" +
 	"\rGetSyncStatus\x12\x1c.wpp.v1.GetSyncStatusRequest\x1a\x1d.wpp.v1.GetSyncStatusResponse\x12@\n" +
 	"\tStartSync\x12\x18.wpp.v1.StartSyncRequest\x1a\x19.wpp.v1.StartSyncResponse\x12=\n" +
 	"\bStopSync\x12\x17.wpp.v1.StopSyncRequest\x1a\x18.wpp.v1.StopSyncResponse\x12J\n" +
-	"\x0fWatchSyncEvents\x12\x1e.wpp.v1.WatchSyncEventsRequest\x1a\x15.wpp.v1.EventEnvelope0\x01B-Z+github.com/matheus3301/wpp/gen/wpp/v1;wppv1b\x06proto3"
+	"\x0fWatchSyncEvents\x12\x1e.wpp.v1.WatchSyncEventsRequest\x1a\x15.wpp.v1.EventEnvelope0\x01\x12I\n" +
+	"\fBackfillChat\x12\x1b.wpp.v1.BackfillChatRequest\x1a\x1c.wpp.v1.BackfillChatResponseB-Z+github.com/matheus3301/wpp/gen/wpp/v1;wppv1b\x06proto3"
 
 var (
 	file_wpp_v1_sync_proto_rawDescOnce sync.Once
@@ -393,7 +530,7 @@ func file_wpp_v1_sync_proto_rawDescGZIP() []byte {
 	return file_wpp_v1_sync_proto_rawDescData
 }
 
-var file_wpp_v1_sync_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_wpp_v1_sync_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_wpp_v1_sync_proto_goTypes = []any{
 	(*GetSyncStatusRequest)(nil),   // 0: wpp.v1.GetSyncStatusRequest
 	(*GetSyncStatusResponse)(nil),  // 1: wpp.v1.GetSyncStatusResponse
@@ -402,19 +539,23 @@ var file_wpp_v1_sync_proto_goTypes = []any{
 	(*StopSyncRequest)(nil),        // 4: wpp.v1.StopSyncRequest
 	(*StopSyncResponse)(nil),       // 5: wpp.v1.StopSyncResponse
 	(*WatchSyncEventsRequest)(nil), // 6: wpp.v1.WatchSyncEventsRequest
-	(*EventEnvelope)(nil),          // 7: wpp.v1.EventEnvelope
+	(*BackfillChatRequest)(nil),    // 7: wpp.v1.BackfillChatRequest
+	(*BackfillChatResponse)(nil),   // 8: wpp.v1.BackfillChatResponse
+	(*EventEnvelope)(nil),          // 9: wpp.v1.EventEnvelope
 }
 var file_wpp_v1_sync_proto_depIdxs = []int32{
 	0, // 0: wpp.v1.SyncService.GetSyncStatus:input_type -> wpp.v1.GetSyncStatusRequest
 	2, // 1: wpp.v1.SyncService.StartSync:input_type -> wpp.v1.StartSyncRequest
 	4, // 2: wpp.v1.SyncService.StopSync:input_type -> wpp.v1.StopSyncRequest
 	6, // 3: wpp.v1.SyncService.WatchSyncEvents:input_type -> wpp.v1.WatchSyncEventsRequest
-	1, // 4: wpp.v1.SyncService.GetSyncStatus:output_type -> wpp.v1.GetSyncStatusResponse
-	3, // 5: wpp.v1.SyncService.StartSync:output_type -> wpp.v1.StartSyncResponse
-	5, // 6: wpp.v1.SyncService.StopSync:output_type -> wpp.v1.StopSyncResponse
-	7, // 7: wpp.v1.SyncService.WatchSyncEvents:output_type -> wpp.v1.EventEnvelope
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
+	7, // 4: wpp.v1.SyncService.BackfillChat:input_type -> wpp.v1.BackfillChatRequest
+	1, // 5: wpp.v1.SyncService.GetSyncStatus:output_type -> wpp.v1.GetSyncStatusResponse
+	3, // 6: wpp.v1.SyncService.StartSync:output_type -> wpp.v1.StartSyncResponse
+	5, // 7: wpp.v1.SyncService.StopSync:output_type -> wpp.v1.StopSyncResponse
+	9, // 8: wpp.v1.SyncService.WatchSyncEvents:output_type -> wpp.v1.EventEnvelope
+	8, // 9: wpp.v1.SyncService.BackfillChat:output_type -> wpp.v1.BackfillChatResponse
+	5, // [5:10] is the sub-list for method output_type
+	0, // [0:5] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -432,7 +573,7 @@ func file_wpp_v1_sync_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_wpp_v1_sync_proto_rawDesc), len(file_wpp_v1_sync_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,6 +23,7 @@ const (
 	SyncService_StartSync_FullMethodName       = "/wpp.v1.SyncService/StartSync"
 	SyncService_StopSync_FullMethodName        = "/wpp.v1.SyncService/StopSync"
 	SyncService_WatchSyncEvents_FullMethodName = "/wpp.v1.SyncService/WatchSyncEvents"
+	SyncService_BackfillChat_FullMethodName    = "/wpp.v1.SyncService/BackfillChat"
 )
 
 // SyncServiceClient is the client API for SyncService service.
@@ -33,6 +34,7 @@ type SyncServiceClient interface {
 	StartSync(ctx context.Context, in *StartSyncRequest, opts ...grpc.CallOption) (*StartSyncResponse, error)
 	StopSync(ctx context.Context, in *StopSyncRequest, opts ...grpc.CallOption) (*StopSyncResponse, error)
 	WatchSyncEvents(ctx context.Context, in *WatchSyncEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EventEnvelope], error)
+	BackfillChat(ctx context.Context, in *BackfillChatRequest, opts ...grpc.CallOption) (*BackfillChatResponse, error)
 }
 
 type syncServiceClient struct {
@@ -92,6 +94,16 @@ func (c *syncServiceClient) WatchSyncEvents(ctx context.Context, in *WatchSyncEv
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type SyncService_WatchSyncEventsClient = grpc.ServerStreamingClient[EventEnvelope]
 
+func (c *syncServiceClient) BackfillChat(ctx context.Context, in *BackfillChatRequest, opts ...grpc.CallOption) (*BackfillChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BackfillChatResponse)
+	err := c.cc.Invoke(ctx, SyncService_BackfillChat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SyncServiceServer is the server API for SyncService service.
 // All implementations must embed UnimplementedSyncServiceServer
 // for forward compatibility.
@@ -100,6 +112,7 @@ type SyncServiceServer interface {
 	StartSync(context.Context, *StartSyncRequest) (*StartSyncResponse, error)
 	StopSync(context.Context, *StopSyncRequest) (*StopSyncResponse, error)
 	WatchSyncEvents(*WatchSyncEventsRequest, grpc.ServerStreamingServer[EventEnvelope]) error
+	BackfillChat(context.Context, *BackfillChatRequest) (*BackfillChatResponse, error)
 	mustEmbedUnimplementedSyncServiceServer()
 }
 
@@ -122,6 +135,9 @@ func (UnimplementedSyncServiceServer) StopSync(context.Context, *StopSyncRequest
 func (UnimplementedSyncServiceServer) WatchSyncEvents(*WatchSyncEventsRequest, grpc.ServerStreamingServer[EventEnvelope]) error {
 	return status.Error(codes.Unimplemented, "method WatchSyncEvents not implemented")
 }
+func (UnimplementedSyncServiceServer) BackfillChat(context.Context, *BackfillChatRequest) (*BackfillChatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BackfillChat not implemented")
+}
 func (UnimplementedSyncServiceServer) mustEmbedUnimplementedSyncServiceServer() {}
 func (UnimplementedSyncServiceServer) testEmbeddedByValue()                     {}
 
@@ -208,6 +224,24 @@ func _SyncService_WatchSyncEvents_Handler(srv interface{}, stream grpc.ServerStr
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type SyncService_WatchSyncEventsServer = grpc.ServerStreamingServer[EventEnvelope]
 
+func _SyncService_BackfillChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackfillChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncServiceServer).BackfillChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SyncService_BackfillChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncServiceServer).BackfillChat(ctx, req.(*BackfillChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SyncService_ServiceDesc is the grpc.ServiceDesc for SyncService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -227,6 +261,10 @@ var SyncService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StopSync",
 			Handler:    _SyncService_StopSync_Handler,
 		},
+		{
+			MethodName: "BackfillChat",
+			Handler:    _SyncService_BackfillChat_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
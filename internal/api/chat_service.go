@@ -2,11 +2,13 @@ package api
 
 import (
 	"context"
+	"time"
 
-	"github.com/google/uuid"
 	wppv1 "github.com/matheus3301/wpp/gen/wpp/v1"
 	"github.com/matheus3301/wpp/internal/bus"
+	"github.com/matheus3301/wpp/internal/journal"
 	"github.com/matheus3301/wpp/internal/store"
+	"github.com/matheus3301/wpp/internal/wa"
 	"google.golang.org/grpc/codes"
 	grpcstatus "google.golang.org/grpc/status"
 )
@@ -16,37 +18,46 @@ type ChatService struct {
 	wppv1.UnimplementedChatServiceServer
 
 	db          *store.DB
+	adapter     *wa.Adapter
 	bus         *bus.Bus
+	journal     *journal.Journal
 	sessionName string
 }
 
 // NewChatService creates a new chat service backed by the store.
-func NewChatService(db *store.DB, b *bus.Bus, sessionName string) *ChatService {
-	return &ChatService{db: db, bus: b, sessionName: sessionName}
+func NewChatService(db *store.DB, adapter *wa.Adapter, b *bus.Bus, j *journal.Journal, sessionName string) *ChatService {
+	return &ChatService{db: db, adapter: adapter, bus: b, journal: j, sessionName: sessionName}
 }
 
 func (s *ChatService) ListChats(_ context.Context, req *wppv1.ListChatsRequest) (*wppv1.ListChatsResponse, error) {
-	limit := 50
-	offset := 0
-	if req.Pagination != nil && req.Pagination.Limit > 0 {
-		limit = int(req.Pagination.Limit)
+	limit, after, err := pageRequest(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := store.ParseChatFilter(req.Filter)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
 	}
 
-	chats, err := s.db.ListChats(limit, offset)
+	chats, err := s.db.ListChats(limit+1, after, filter)
 	if err != nil {
 		return nil, grpcstatus.Errorf(codes.Internal, "list chats: %v", err)
 	}
+	fetched := len(chats)
+	if fetched > limit {
+		chats = chats[:limit]
+	}
 
 	var pbChats []*wppv1.Chat
+	var last store.Cursor
 	for _, c := range chats {
 		pbChats = append(pbChats, chatToProto(&c))
+		last = store.Cursor{Timestamp: c.LastMessageAt, JID: c.JID}
 	}
 
 	return &wppv1.ListChatsResponse{
-		Chats: pbChats,
-		PageInfo: &wppv1.PageInfo{
-			HasMore: len(chats) == limit,
-		},
+		Chats:    pbChats,
+		PageInfo: pageInfo(fetched, limit, last),
 	}, nil
 }
 
@@ -61,26 +72,176 @@ func (s *ChatService) GetChat(_ context.Context, req *wppv1.GetChatRequest) (*wp
 	return &wppv1.GetChatResponse{Chat: chatToProto(c)}, nil
 }
 
-func (s *ChatService) WatchChatUpdates(_ *wppv1.WatchChatUpdatesRequest, stream wppv1.ChatService_WatchChatUpdatesServer) error {
-	ch, unsub := s.bus.Subscribe("message.", 256)
-	defer unsub()
-
-	for {
-		select {
-		case evt := <-ch:
-			if err := stream.Send(&wppv1.EventEnvelope{
-				EventId:          uuid.New().String(),
-				Session:          s.sessionName,
-				OccurredAtUnixMs: evt.Timestamp.UnixMilli(),
-				Kind:             evt.Kind,
-				PayloadVersion:   1,
-			}); err != nil {
-				return err
-			}
-		case <-stream.Context().Done():
-			return nil
+// MarkRead sends read receipts for the chat's unread messages and resets
+// its unread count.
+func (s *ChatService) MarkRead(ctx context.Context, req *wppv1.MarkReadRequest) (*wppv1.MarkReadResponse, error) {
+	c, err := s.db.GetChat(req.ChatJid)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "get chat: %v", err)
+	}
+	if c == nil {
+		return nil, grpcstatus.Errorf(codes.NotFound, "chat %q not found", req.ChatJid)
+	}
+	if c.UnreadCount == 0 {
+		return &wppv1.MarkReadResponse{}, nil
+	}
+
+	unread, err := s.db.UnreadMessages(req.ChatJid)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "list unread messages: %v", err)
+	}
+	if len(unread) > 0 {
+		if s.adapter == nil {
+			return nil, grpcstatus.Errorf(codes.Unavailable, "adapter not initialized")
 		}
+		if err := s.adapter.MarkRead(ctx, req.ChatJid, unread); err != nil {
+			return nil, grpcstatus.Errorf(codes.Internal, "mark read: %v", err)
+		}
+	}
+	if _, err := s.db.SetChatUnread(req.ChatJid, 0); err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "reset unread count: %v", err)
 	}
+
+	s.bus.Publish(bus.Event{
+		Kind:      "chat.updated",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"chat_jid": req.ChatJid,
+		},
+	})
+	return &wppv1.MarkReadResponse{MarkedCount: int32(len(unread))}, nil
+}
+
+func (s *ChatService) GetGroupInfo(_ context.Context, req *wppv1.GetGroupInfoRequest) (*wppv1.GetGroupInfoResponse, error) {
+	g, err := s.db.GetGroup(req.Jid)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "get group: %v", err)
+	}
+	if g == nil {
+		return nil, grpcstatus.Errorf(codes.NotFound, "group %q not found", req.Jid)
+	}
+	return &wppv1.GetGroupInfoResponse{Group: groupToProto(g)}, nil
+}
+
+func (s *ChatService) WatchChatUpdates(req *wppv1.WatchChatUpdatesRequest, stream wppv1.ChatService_WatchChatUpdatesServer) error {
+	return watchEvents(stream.Context(), s.journal, s.sessionName, req.Cursor, []string{"chat.", "message."}, "", stream.Send)
+}
+
+// WatchPresence streams typing and online state as they are received,
+// keeping us online to contacts while it is open.
+func (s *ChatService) WatchPresence(req *wppv1.WatchPresenceRequest, stream wppv1.ChatService_WatchPresenceServer) error {
+	if s.adapter != nil {
+		release := s.adapter.WatchPresence(stream.Context(), req.ChatJid)
+		defer release()
+	}
+	return watchLiveEvents(stream.Context(), s.journal, s.sessionName, "presence.", req.ChatJid, stream.Send)
+}
+
+// SendChatPresence tells a chat whether we are typing.
+func (s *ChatService) SendChatPresence(ctx context.Context, req *wppv1.SendChatPresenceRequest) (*wppv1.SendChatPresenceResponse, error) {
+	switch req.State {
+	case "composing", "recording", "paused":
+	default:
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "unknown state %q, want composing, recording or paused", req.State)
+	}
+	if s.adapter == nil {
+		return nil, grpcstatus.Errorf(codes.Unavailable, "adapter not initialized")
+	}
+	if err := s.adapter.SendChatPresence(ctx, req.ChatJid, req.State); err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "send chat presence: %v", err)
+	}
+	return &wppv1.SendChatPresenceResponse{}, nil
+}
+
+// ArchiveChat archives or unarchives a chat on WhatsApp. Archiving also
+// unpins it, as WhatsApp does.
+func (s *ChatService) ArchiveChat(ctx context.Context, req *wppv1.ArchiveChatRequest) (*wppv1.ArchiveChatResponse, error) {
+	settings := &store.ChatSettings{ChatJID: req.ChatJid, Archived: &req.Archived}
+	if req.Archived {
+		var unpinned int64
+		settings.PinnedAt = &unpinned
+	}
+	c, err := s.updateChatSettings(ctx, settings, "archive chat", func() error {
+		return s.adapter.ArchiveChat(ctx, req.ChatJid, req.Archived)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &wppv1.ArchiveChatResponse{Chat: c}, nil
+}
+
+// PinChat pins or unpins a chat on WhatsApp.
+func (s *ChatService) PinChat(ctx context.Context, req *wppv1.PinChatRequest) (*wppv1.PinChatResponse, error) {
+	var pinnedAt int64
+	if req.Pinned {
+		pinnedAt = time.Now().UnixMilli()
+	}
+	c, err := s.updateChatSettings(ctx, &store.ChatSettings{ChatJID: req.ChatJid, PinnedAt: &pinnedAt}, "pin chat", func() error {
+		return s.adapter.PinChat(ctx, req.ChatJid, req.Pinned)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &wppv1.PinChatResponse{Chat: c}, nil
+}
+
+// MuteChat mutes a chat on WhatsApp for a duration, indefinitely when it
+// is zero, or unmutes it.
+func (s *ChatService) MuteChat(ctx context.Context, req *wppv1.MuteChatRequest) (*wppv1.MuteChatResponse, error) {
+	if req.DurationSeconds < 0 {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "duration must not be negative")
+	}
+	var mutedUntil int64
+	switch {
+	case !req.Muted:
+	case req.DurationSeconds == 0:
+		mutedUntil = -1
+	default:
+		mutedUntil = time.Now().Add(time.Duration(req.DurationSeconds) * time.Second).UnixMilli()
+	}
+	c, err := s.updateChatSettings(ctx, &store.ChatSettings{ChatJID: req.ChatJid, MutedUntil: &mutedUntil}, "mute chat", func() error {
+		return s.adapter.MuteChat(ctx, req.ChatJid, mutedUntil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &wppv1.MuteChatResponse{Chat: c}, nil
+}
+
+// updateChatSettings sends an app state change of a stored chat to
+// WhatsApp, then applies it locally and publishes chat.updated.
+func (s *ChatService) updateChatSettings(ctx context.Context, settings *store.ChatSettings, what string, send func() error) (*wppv1.Chat, error) {
+	c, err := s.db.GetChat(settings.ChatJID)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "get chat: %v", err)
+	}
+	if c == nil {
+		return nil, grpcstatus.Errorf(codes.NotFound, "chat %q not found", settings.ChatJID)
+	}
+	if s.adapter == nil {
+		return nil, grpcstatus.Errorf(codes.Unavailable, "adapter not initialized")
+	}
+	if err := send(); err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "%s: %v", what, err)
+	}
+	if _, err := s.db.SetChatSettings(settings); err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "update chat: %v", err)
+	}
+
+	s.bus.Publish(bus.Event{
+		Kind:      "chat.updated",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"chat_jid": settings.ChatJID,
+		},
+	})
+	if c, err = s.db.GetChat(settings.ChatJID); err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "get chat: %v", err)
+	}
+	if c == nil {
+		return nil, grpcstatus.Errorf(codes.NotFound, "chat %q not found", settings.ChatJID)
+	}
+	return chatToProto(c), nil
 }
 
 func chatToProto(c *store.Chat) *wppv1.Chat {
@@ -91,5 +252,27 @@ func chatToProto(c *store.Chat) *wppv1.Chat {
 		LastMessageAtUnixMs: c.LastMessageAt,
 		UnreadCount:         int32(c.UnreadCount),
 		IsGroup:             c.IsGroup,
+		Archived:            c.Archived,
+		PinnedAtUnixMs:      c.PinnedAt,
+		MutedUntilUnixMs:    c.MutedUntil,
+	}
+}
+
+func groupToProto(g *store.Group) *wppv1.GroupInfo {
+	pb := &wppv1.GroupInfo{
+		Jid:             g.JID,
+		Name:            g.Name,
+		Topic:           g.Topic,
+		OwnerJid:        g.OwnerJID,
+		CreatedAtUnixMs: g.CreatedAt,
+	}
+	for _, p := range g.Participants {
+		pb.Participants = append(pb.Participants, &wppv1.GroupParticipant{
+			Jid:          p.JID,
+			Name:         p.Name,
+			IsAdmin:      p.IsAdmin,
+			IsSuperAdmin: p.IsSuperAdmin,
+		})
 	}
+	return pb
 }
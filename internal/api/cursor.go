@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	wppv1 "github.com/matheus3301/wpp/gen/wpp/v1"
+	"github.com/matheus3301/wpp/internal/store"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// defaultPageSize is the page size of list RPCs called without a limit.
+const defaultPageSize = 50
+
+// pageRequest returns the limit and start cursor of a paginated request.
+// Cursors are opaque to clients; a malformed one is InvalidArgument.
+func pageRequest(p *wppv1.Pagination) (int, store.Cursor, error) {
+	limit := defaultPageSize
+	if p == nil {
+		return limit, store.Cursor{}, nil
+	}
+	if p.Limit > 0 {
+		limit = int(p.Limit)
+	}
+	if p.Cursor == "" {
+		return limit, store.Cursor{}, nil
+	}
+	c, err := decodeCursor(p.Cursor)
+	if err != nil {
+		return 0, store.Cursor{}, grpcstatus.Errorf(codes.InvalidArgument, "invalid cursor: %v", err)
+	}
+	return limit, c, nil
+}
+
+// pageInfo builds the PageInfo of a page queried with limit+1 rows: an
+// extra row means there is a next page, which starts after last, the
+// cursor of the last row returned to the client.
+func pageInfo(fetched, limit int, last store.Cursor) *wppv1.PageInfo {
+	if fetched <= limit {
+		return &wppv1.PageInfo{}
+	}
+	return &wppv1.PageInfo{NextCursor: encodeCursor(last), HasMore: true}
+}
+
+func encodeCursor(c store.Cursor) string {
+	return base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, "%d:%d:%s", c.Timestamp, c.ID, c.JID))
+}
+
+// decodeCursor parses a cursor made by encodeCursor. Anything else, even
+// with a valid prefix, is an error.
+func decodeCursor(s string) (store.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return store.Cursor{}, err
+	}
+	parts := strings.SplitN(string(raw), ":", 3)
+	if len(parts) != 3 {
+		return store.Cursor{}, errors.New("wrong number of fields")
+	}
+	var c store.Cursor
+	if c.Timestamp, err = strconv.ParseInt(parts[0], 10, 64); err != nil {
+		return store.Cursor{}, err
+	}
+	if c.ID, err = strconv.ParseInt(parts[1], 10, 64); err != nil {
+		return store.Cursor{}, err
+	}
+	c.JID = parts[2]
+	return c, nil
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	wppv1 "github.com/matheus3301/wpp/gen/wpp/v1"
+	"github.com/matheus3301/wpp/internal/journal"
+	"github.com/matheus3301/wpp/internal/store"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// watchEvents streams the journaled events matching prefixes and chatJID
+// as envelopes, resuming after cursor. It backs every Watch* RPC.
+func watchEvents(ctx context.Context, j *journal.Journal, sessionName, cursor string, prefixes []string, chatJID string, send func(*wppv1.EventEnvelope) error) error {
+	err := j.Watch(ctx, cursor, prefixes, chatJID, func(e store.Event) error {
+		return send(&wppv1.EventEnvelope{
+			EventId:          strconv.FormatInt(e.ID, 10),
+			Session:          sessionName,
+			OccurredAtUnixMs: e.OccurredAt,
+			Kind:             e.Kind,
+			PayloadVersion:   1,
+			CorrelationId:    e.CorrelationID,
+			Payload:          e.Payload,
+		})
+	})
+	return watchError(err)
+}
+
+// watchLiveEvents streams the unjournaled events of namespace about
+// chatJID as envelopes without event ids, from the live tail.
+func watchLiveEvents(ctx context.Context, j *journal.Journal, sessionName, namespace, chatJID string, send func(*wppv1.EventEnvelope) error) error {
+	err := j.WatchLive(ctx, namespace, chatJID, func(e store.Event) error {
+		return send(&wppv1.EventEnvelope{
+			Session:          sessionName,
+			OccurredAtUnixMs: e.OccurredAt,
+			Kind:             e.Kind,
+			PayloadVersion:   1,
+			Payload:          e.Payload,
+		})
+	})
+	return watchError(err)
+}
+
+// watchError maps journal errors to statuses.
+func watchError(err error) error {
+	switch {
+	case errors.Is(err, journal.ErrInvalidCursor):
+		return grpcstatus.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, journal.ErrCursorExpired):
+		return grpcstatus.Errorf(codes.OutOfRange, "%v", err)
+	}
+	return err
+}
@@ -1,13 +1,22 @@
 package api
 
 import (
+	"bytes"
+	"cmp"
 	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
 	"time"
 
-	"github.com/google/uuid"
 	wppv1 "github.com/matheus3301/wpp/gen/wpp/v1"
 	"github.com/matheus3301/wpp/internal/bus"
+	"github.com/matheus3301/wpp/internal/journal"
+	"github.com/matheus3301/wpp/internal/media"
+	"github.com/matheus3301/wpp/internal/outbox"
 	"github.com/matheus3301/wpp/internal/store"
+	"github.com/matheus3301/wpp/internal/wa"
 	"google.golang.org/grpc/codes"
 	grpcstatus "google.golang.org/grpc/status"
 )
@@ -17,104 +26,450 @@ type MessageService struct {
 	wppv1.UnimplementedMessageServiceServer
 
 	db          *store.DB
+	adapter     *wa.Adapter
 	bus         *bus.Bus
+	journal     *journal.Journal
+	media       *media.Cache
+	outbox      *outbox.Sender
 	sessionName string
 }
 
 // NewMessageService creates a new message service backed by the store.
-func NewMessageService(db *store.DB, b *bus.Bus, sessionName string) *MessageService {
-	return &MessageService{db: db, bus: b, sessionName: sessionName}
+// Entries it queues are sent by the outbox sender, which it wakes.
+func NewMessageService(db *store.DB, adapter *wa.Adapter, b *bus.Bus, j *journal.Journal, cache *media.Cache, sender *outbox.Sender, sessionName string) *MessageService {
+	return &MessageService{db: db, adapter: adapter, bus: b, journal: j, media: cache, outbox: sender, sessionName: sessionName}
+}
+
+// wakeOutbox tells the outbox sender that an entry was queued or made due.
+func (s *MessageService) wakeOutbox() {
+	if s.outbox != nil {
+		s.outbox.Wake()
+	}
+}
+
+// newMessageID assigns the WhatsApp message ID of a message being queued,
+// or "" to let WhatsApp assign one when sending.
+func (s *MessageService) newMessageID() string {
+	if s.adapter == nil {
+		return ""
+	}
+	return s.adapter.GenerateMessageID()
 }
 
 func (s *MessageService) ListMessages(_ context.Context, req *wppv1.ListMessagesRequest) (*wppv1.ListMessagesResponse, error) {
-	limit := 50
-	var beforeTs int64
-	if req.Pagination != nil {
-		if req.Pagination.Limit > 0 {
-			limit = int(req.Pagination.Limit)
-		}
+	limit, before, err := pageRequest(req.Pagination)
+	if err != nil {
+		return nil, err
 	}
 
-	msgs, err := s.db.ListMessages(req.ChatJid, beforeTs, limit)
+	msgs, err := s.db.ListMessages(req.ChatJid, before, limit+1)
 	if err != nil {
 		return nil, grpcstatus.Errorf(codes.Internal, "list messages: %v", err)
 	}
+	fetched := len(msgs)
+	if fetched > limit {
+		msgs = msgs[:limit]
+	}
 
 	var pbMsgs []*wppv1.Message
+	var last store.Cursor
 	for _, m := range msgs {
 		pbMsgs = append(pbMsgs, messageToProto(&m))
+		last = store.Cursor{Timestamp: m.Timestamp, ID: m.ID}
 	}
 
 	return &wppv1.ListMessagesResponse{
 		Messages: pbMsgs,
-		PageInfo: &wppv1.PageInfo{
-			HasMore: len(msgs) == limit,
-		},
+		PageInfo: pageInfo(fetched, limit, last),
 	}, nil
 }
 
+func (s *MessageService) GetMessage(_ context.Context, req *wppv1.GetMessageRequest) (*wppv1.GetMessageResponse, error) {
+	m, err := s.db.GetMessage(req.ChatJid, req.MsgId)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "get message: %v", err)
+	}
+	if m == nil {
+		return nil, grpcstatus.Errorf(codes.NotFound, "message %q not found in chat %q", req.MsgId, req.ChatJid)
+	}
+	return &wppv1.GetMessageResponse{Message: messageToProto(m)}, nil
+}
+
 func (s *MessageService) SearchMessages(_ context.Context, req *wppv1.SearchMessagesRequest) (*wppv1.SearchMessagesResponse, error) {
-	limit := 50
-	if req.Pagination != nil && req.Pagination.Limit > 0 {
-		limit = int(req.Pagination.Limit)
+	limit, before, err := pageRequest(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := store.ParseSearchQuery(req.Query)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid query: %v", err)
 	}
 
-	results, err := s.db.SearchMessages(req.Query, req.ChatJid, limit)
+	results, err := s.db.SearchMessages(query, req.ChatJid, before, limit+1)
 	if err != nil {
 		return nil, grpcstatus.Errorf(codes.Internal, "search messages: %v", err)
 	}
+	fetched := len(results)
+	if fetched > limit {
+		results = results[:limit]
+	}
 
 	var pbResults []*wppv1.SearchResult
+	var last store.Cursor
 	for _, r := range results {
 		pbResults = append(pbResults, &wppv1.SearchResult{
 			Message: messageToProto(&r.Message),
 			Snippet: r.Snippet,
 		})
+		last = store.Cursor{Timestamp: r.Message.Timestamp, ID: r.Message.ID}
 	}
 
 	return &wppv1.SearchMessagesResponse{
-		Results: pbResults,
-		PageInfo: &wppv1.PageInfo{
-			HasMore: len(results) == limit,
-		},
+		Results:  pbResults,
+		PageInfo: pageInfo(fetched, limit, last),
 	}, nil
 }
 
 func (s *MessageService) SendText(_ context.Context, req *wppv1.SendTextRequest) (*wppv1.SendTextResponse, error) {
-	if err := s.db.QueueOutboxWithMessage(req.ClientMsgId, req.ChatJid, req.Text); err != nil {
+	if err := s.checkReplyTo(req.ChatJid, req.ReplyToMsgId); err != nil {
+		return nil, err
+	}
+	if req.SendAtUnixMs > time.Now().UnixMilli() {
+		// Nothing shows in the chat until the sender picks it up.
+		if err := s.db.ScheduleOutbox(req.ClientMsgId, s.newMessageID(), req.ChatJid, req.Text, req.ReplyToMsgId, req.SendAtUnixMs); err != nil {
+			return nil, grpcstatus.Errorf(codes.Internal, "schedule outbox: %v", err)
+		}
+		s.wakeOutbox()
+		return &wppv1.SendTextResponse{Accepted: true, Message: "scheduled"}, nil
+	}
+	if err := s.db.QueueOutboxWithMessage(req.ClientMsgId, s.newMessageID(), req.ChatJid, req.Text, req.ReplyToMsgId); err != nil {
 		return nil, grpcstatus.Errorf(codes.Internal, "queue outbox: %v", err)
 	}
+	s.wakeOutbox()
 
 	// Notify TUI immediately so the message appears before the sender picks it up.
 	s.bus.Publish(bus.Event{
 		Kind:      "message.upserted",
 		Timestamp: time.Now(),
-		Payload:   map[string]string{"chat_jid": req.ChatJid, "msg_id": req.ClientMsgId},
+		Payload: map[string]string{
+			"chat_jid":      req.ChatJid,
+			"msg_id":        req.ClientMsgId,
+			"client_msg_id": req.ClientMsgId,
+			"is_new":        "true",
+		},
 	})
 
 	return &wppv1.SendTextResponse{Accepted: true, Message: "queued"}, nil
 }
 
-func (s *MessageService) WatchMessageEvents(req *wppv1.WatchMessageEventsRequest, stream wppv1.MessageService_WatchMessageEventsServer) error {
-	ch, unsub := s.bus.Subscribe("message.", 256)
-	defer unsub()
-
-	for {
-		select {
-		case evt := <-ch:
-			if err := stream.Send(&wppv1.EventEnvelope{
-				EventId:          uuid.New().String(),
-				Session:          s.sessionName,
-				OccurredAtUnixMs: evt.Timestamp.UnixMilli(),
-				Kind:             evt.Kind,
-				PayloadVersion:   1,
-			}); err != nil {
-				return err
-			}
-		case <-stream.Context().Done():
-			return nil
+func (s *MessageService) SendReaction(_ context.Context, req *wppv1.SendReactionRequest) (*wppv1.SendReactionResponse, error) {
+	key, err := s.db.GetMessageKey(req.ChatJid, req.MsgId)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "get message: %v", err)
+	}
+	if key == nil {
+		return nil, grpcstatus.Errorf(codes.NotFound, "message %q not found in chat %q", req.MsgId, req.ChatJid)
+	}
+	if err := s.db.QueueReaction(req.ClientMsgId, s.newMessageID(), req.ChatJid, req.MsgId, req.Emoji); err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "queue outbox: %v", err)
+	}
+	s.wakeOutbox()
+	return &wppv1.SendReactionResponse{Accepted: true, Message: "queued"}, nil
+}
+
+// EditMessage queues an edit of one of our sent text messages. WhatsApp
+// only accepts edits within wa.EditWindow of sending.
+func (s *MessageService) EditMessage(_ context.Context, req *wppv1.EditMessageRequest) (*wppv1.EditMessageResponse, error) {
+	if req.Text == "" {
+		return nil, grpcstatus.Error(codes.InvalidArgument, "text is empty")
+	}
+	m, err := s.ownMessage(req.ChatJid, req.MsgId)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case m.MessageType != "text":
+		return nil, grpcstatus.Errorf(codes.FailedPrecondition, "message %q is not a text message", req.MsgId)
+	case !messageSent(m):
+		return nil, grpcstatus.Errorf(codes.FailedPrecondition, "message %q has not been sent", req.MsgId)
+	case time.Since(time.UnixMilli(m.Timestamp)) > wa.EditWindow:
+		return nil, grpcstatus.Errorf(codes.FailedPrecondition, "message %q is older than %v and can no longer be edited", req.MsgId, wa.EditWindow)
+	}
+	if err := s.db.QueueMessageChange(req.ClientMsgId, s.newMessageID(), &store.MessageChange{
+		ChatJID: req.ChatJid, MsgID: m.MsgID, Kind: "edit", Body: req.Text,
+	}); err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "queue outbox: %v", err)
+	}
+	s.wakeOutbox()
+	return &wppv1.EditMessageResponse{Accepted: true, Message: "queued"}, nil
+}
+
+// RevokeMessage queues a revoke of one of our messages, deleting it for
+// everyone. A message that has not been sent yet is cancelled instead.
+func (s *MessageService) RevokeMessage(_ context.Context, req *wppv1.RevokeMessageRequest) (*wppv1.RevokeMessageResponse, error) {
+	m, err := s.ownMessage(req.ChatJid, req.MsgId)
+	if err != nil {
+		return nil, err
+	}
+	if !messageSent(m) {
+		entry, err := s.db.CancelOutbox(m.MsgID)
+		if err := outboxError(m.MsgID, entry, err); err != nil {
+			return nil, err
+		}
+		s.publishUpserted(entry)
+		return &wppv1.RevokeMessageResponse{Accepted: true, Message: "cancelled"}, nil
+	}
+	if err := s.db.QueueMessageChange(req.ClientMsgId, s.newMessageID(), &store.MessageChange{
+		ChatJID: req.ChatJid, MsgID: m.MsgID, Kind: "revoke",
+	}); err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "queue outbox: %v", err)
+	}
+	s.wakeOutbox()
+	return &wppv1.RevokeMessageResponse{Accepted: true, Message: "queued"}, nil
+}
+
+// ownMessage returns a message we sent that has not been deleted, for
+// editing or revoking it.
+func (s *MessageService) ownMessage(chatJID, msgID string) (*store.Message, error) {
+	m, err := s.db.GetMessage(chatJID, msgID)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "get message: %v", err)
+	}
+	switch {
+	case m == nil:
+		return nil, grpcstatus.Errorf(codes.NotFound, "message %q not found in chat %q", msgID, chatJID)
+	case !m.FromMe:
+		return nil, grpcstatus.Errorf(codes.PermissionDenied, "message %q was not sent by us", msgID)
+	case m.Deleted:
+		return nil, grpcstatus.Errorf(codes.FailedPrecondition, "message %q is deleted", msgID)
+	}
+	return m, nil
+}
+
+// messageSent reports whether WhatsApp has accepted a message of ours;
+// until then it is still in the outbox.
+func messageSent(m *store.Message) bool {
+	switch m.Status {
+	case "queued", "sending", "failed":
+		return false
+	}
+	return true
+}
+
+// RetryMessage resends a queued or dead outbox entry right away.
+func (s *MessageService) RetryMessage(_ context.Context, req *wppv1.RetryMessageRequest) (*wppv1.RetryMessageResponse, error) {
+	entry, err := s.db.RetryOutbox(req.ClientMsgId)
+	if err := outboxError(req.ClientMsgId, entry, err); err != nil {
+		return nil, err
+	}
+	s.wakeOutbox()
+	if entry.HasMessage() {
+		s.publishUpserted(entry)
+	}
+	return &wppv1.RetryMessageResponse{}, nil
+}
+
+// CancelMessage drops a queued or dead outbox entry so it is never sent.
+func (s *MessageService) CancelMessage(_ context.Context, req *wppv1.CancelMessageRequest) (*wppv1.CancelMessageResponse, error) {
+	entry, err := s.db.CancelOutbox(req.ClientMsgId)
+	if err := outboxError(req.ClientMsgId, entry, err); err != nil {
+		return nil, err
+	}
+	if entry.HasMessage() {
+		// The message row is gone; watchers drop it when they refetch.
+		s.publishUpserted(entry)
+	}
+	return &wppv1.CancelMessageResponse{}, nil
+}
+
+// ListScheduled returns the texts still scheduled for later.
+func (s *MessageService) ListScheduled(_ context.Context, req *wppv1.ListScheduledRequest) (*wppv1.ListScheduledResponse, error) {
+	entries, err := s.db.ListScheduledOutbox(req.ChatJid)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "list scheduled: %v", err)
+	}
+	resp := &wppv1.ListScheduledResponse{}
+	for _, e := range entries {
+		resp.Messages = append(resp.Messages, &wppv1.ScheduledMessage{
+			ClientMsgId:  e.ClientMsgID,
+			ChatJid:      e.ChatJID,
+			Text:         e.Body,
+			SendAtUnixMs: e.SendAt,
+			ReplyToMsgId: e.TargetMsgID,
+		})
+	}
+	return resp, nil
+}
+
+// CancelScheduled drops a scheduled text that has not been sent yet.
+func (s *MessageService) CancelScheduled(_ context.Context, req *wppv1.CancelScheduledRequest) (*wppv1.CancelScheduledResponse, error) {
+	entry, err := s.db.GetOutbox(req.ClientMsgId)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "get outbox: %v", err)
+	}
+	if entry == nil || entry.SendAt == 0 {
+		return nil, grpcstatus.Errorf(codes.NotFound, "message %q is not scheduled", req.ClientMsgId)
+	}
+	entry, err = s.db.CancelOutbox(req.ClientMsgId)
+	if err := outboxError(req.ClientMsgId, entry, err); err != nil {
+		return nil, err
+	}
+	// A scheduled text that came due and failed has a message row to drop.
+	s.publishUpserted(entry)
+	return &wppv1.CancelScheduledResponse{}, nil
+}
+
+// outboxError maps the result of RetryOutbox or CancelOutbox to a status.
+func outboxError(clientMsgID string, entry *store.OutboxEntry, err error) error {
+	switch {
+	case errors.Is(err, store.ErrOutboxState):
+		return grpcstatus.Errorf(codes.FailedPrecondition, "message %q is %s", clientMsgID, entry.Status)
+	case err != nil:
+		return grpcstatus.Errorf(codes.Internal, "update outbox: %v", err)
+	case entry == nil:
+		return grpcstatus.Errorf(codes.NotFound, "message %q not found in outbox", clientMsgID)
+	}
+	return nil
+}
+
+// SendMedia queues a file as an attachment. The file is copied to the
+// media cache before the request is accepted, so the send survives
+// restarts and the original may be removed.
+func (s *MessageService) SendMedia(_ context.Context, req *wppv1.SendMediaRequest) (*wppv1.SendMediaResponse, error) {
+	if !filepath.IsAbs(req.Path) {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "path %q is not absolute", req.Path)
+	}
+	if err := s.checkReplyTo(req.ChatJid, req.ReplyToMsgId); err != nil {
+		return nil, err
+	}
+	f, err := os.Open(req.Path)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "open file: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "stat file: %v", err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "%q is not a regular file", req.Path)
+	}
+	if info.Size() == 0 {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "file %q is empty", req.Path)
+	}
+
+	// Only the head of the file is needed to sniff its type.
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "read file: %v", err)
+	}
+	mimetype, mediaType := media.Detect(req.Path, head[:n])
+	if req.MediaType != "" {
+		if err := media.CheckMediaType(req.MediaType, mimetype); err != nil {
+			return nil, grpcstatus.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		mediaType = req.MediaType
+	}
+	if err := media.CheckSize(mediaType, info.Size()); err != nil {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	m := &store.Media{
+		ChatJID: req.ChatJid, MsgID: req.ClientMsgId,
+		MediaType: mediaType, Mimetype: mimetype, FileLength: info.Size(),
+	}
+	switch mediaType {
+	case "voice":
+		m.MediaType, m.Voice = "audio", true
+	case "document":
+		m.FileName = cmp.Or(req.FileName, filepath.Base(req.Path))
+		m.Caption = req.Caption
+	case "image":
+		// Images are capped small enough to decode in memory.
+		data, err := io.ReadAll(io.MultiReader(bytes.NewReader(head[:n]), f))
+		if err != nil {
+			return nil, grpcstatus.Errorf(codes.InvalidArgument, "read file: %v", err)
 		}
+		if m.Thumbnail, err = media.Thumbnail(data); err != nil {
+			return nil, grpcstatus.Errorf(codes.InvalidArgument, "not a valid image: %v", err)
+		}
+		m.Caption = req.Caption
+	case "video":
+		m.Caption = req.Caption
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "rewind file: %v", err)
+	}
+	// The file may grow after the stat: copy only what was checked.
+	if m.LocalPath, err = s.media.Add(m, io.LimitReader(f, info.Size())); err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "cache media: %v", err)
+	}
+	if err := s.db.QueueMedia(req.ClientMsgId, s.newMessageID(), req.ChatJid, req.ReplyToMsgId, m); err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "queue outbox: %v", err)
+	}
+	s.wakeOutbox()
+
+	s.bus.Publish(bus.Event{
+		Kind:      "message.upserted",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"chat_jid":      req.ChatJid,
+			"msg_id":        req.ClientMsgId,
+			"client_msg_id": req.ClientMsgId,
+			"is_new":        "true",
+		},
+	})
+
+	return &wppv1.SendMediaResponse{Accepted: true, Message: "queued"}, nil
+}
+
+// checkReplyTo returns NotFound unless the message to quote, if any, is
+// stored in the chat.
+func (s *MessageService) checkReplyTo(chatJID, replyToMsgID string) error {
+	if replyToMsgID == "" {
+		return nil
+	}
+	key, err := s.db.GetMessageKey(chatJID, replyToMsgID)
+	if err != nil {
+		return grpcstatus.Errorf(codes.Internal, "get message: %v", err)
+	}
+	if key == nil {
+		return grpcstatus.Errorf(codes.NotFound, "message %q not found in chat %q", replyToMsgID, chatJID)
+	}
+	return nil
+}
+
+func (s *MessageService) publishUpserted(entry *store.OutboxEntry) {
+	s.bus.Publish(bus.Event{
+		Kind:      "message.upserted",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"chat_jid":      entry.ChatJID,
+			"msg_id":        entry.ClientMsgID,
+			"client_msg_id": entry.ClientMsgID,
+		},
+	})
+}
+
+func (s *MessageService) DownloadMedia(ctx context.Context, req *wppv1.DownloadMediaRequest) (*wppv1.DownloadMediaResponse, error) {
+	m, err := s.media.Fetch(ctx, req.ChatJid, req.MsgId)
+	if errors.Is(err, media.ErrNoMedia) {
+		return nil, grpcstatus.Errorf(codes.NotFound, "message %q in chat %q has no media", req.MsgId, req.ChatJid)
+	}
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "download media: %v", err)
 	}
+	return &wppv1.DownloadMediaResponse{
+		Path:       m.LocalPath,
+		Mimetype:   m.Mimetype,
+		FileName:   m.FileName,
+		FileLength: m.FileLength,
+	}, nil
+}
+
+func (s *MessageService) WatchMessageEvents(req *wppv1.WatchMessageEventsRequest, stream wppv1.MessageService_WatchMessageEventsServer) error {
+	return watchEvents(stream.Context(), s.journal, s.sessionName, req.Cursor, []string{"message."}, req.ChatJid, stream.Send)
 }
 
 func messageToProto(m *store.Message) *wppv1.Message {
@@ -128,5 +483,38 @@ func messageToProto(m *store.Message) *wppv1.Message {
 		FromMe:          m.FromMe,
 		MessageType:     m.MessageType,
 		Status:          m.Status,
+		EditedAtUnixMs:  m.EditedAt,
+		Deleted:         m.Deleted,
+		Reactions:       reactionsToProto(m.Reactions),
+		QuotedMsgId:     m.QuotedMsgID,
+		QuotedSender:    m.QuotedSender,
+		QuotedBody:      m.QuotedBody,
+		Media:           mediaToProto(m.Media),
+	}
+}
+
+func mediaToProto(m *store.Media) *wppv1.MediaInfo {
+	if m == nil {
+		return nil
+	}
+	return &wppv1.MediaInfo{
+		MediaType:  m.MediaType,
+		Mimetype:   m.Mimetype,
+		FileLength: m.FileLength,
+		FileName:   m.FileName,
+		LocalPath:  m.LocalPath,
+		Voice:      m.Voice,
+	}
+}
+
+func reactionsToProto(counts []store.ReactionCount) []*wppv1.ReactionCount {
+	var out []*wppv1.ReactionCount
+	for _, c := range counts {
+		out = append(out, &wppv1.ReactionCount{
+			Emoji:  c.Emoji,
+			Count:  int32(c.Count),
+			FromMe: c.FromMe,
+		})
 	}
+	return out
 }
@@ -102,6 +102,27 @@ func (s *SessionService) ListSessions(_ context.Context, _ *wppv1.ListSessionsRe
 	return nil, grpcstatus.Errorf(codes.Unimplemented, "ListSessions not yet implemented")
 }
 
+// GetBusStats reports per-subscriber delivery counters of the event bus.
+func (s *SessionService) GetBusStats(_ context.Context, _ *wppv1.GetBusStatsRequest) (*wppv1.GetBusStatsResponse, error) {
+	resp := &wppv1.GetBusStatsResponse{}
+	if s.bus == nil {
+		return resp, nil
+	}
+	for _, st := range s.bus.Stats() {
+		resp.Subscribers = append(resp.Subscribers, &wppv1.BusSubscriber{
+			Name:      st.Name,
+			Namespace: st.Namespace,
+			Lossless:  st.Lossless,
+			Delivered: st.Delivered,
+			Dropped:   st.Dropped,
+			Queued:    int32(st.Queued),
+			MaxQueued: int32(st.MaxQueued),
+		})
+	}
+	resp.TotalDropped = s.bus.Dropped.Load()
+	return resp, nil
+}
+
 func stateToProto(s status.State) wppv1.SessionStatus {
 	switch s {
 	case status.Booting:
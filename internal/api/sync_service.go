@@ -3,14 +3,13 @@ package api
 import (
 	"context"
 
-	"github.com/google/uuid"
 	wppv1 "github.com/matheus3301/wpp/gen/wpp/v1"
-	"github.com/matheus3301/wpp/internal/bus"
+	"github.com/matheus3301/wpp/internal/journal"
 	"github.com/matheus3301/wpp/internal/status"
+	"github.com/matheus3301/wpp/internal/store"
 	"github.com/matheus3301/wpp/internal/wa"
 	"google.golang.org/grpc/codes"
 	grpcstatus "google.golang.org/grpc/status"
-	"google.golang.org/protobuf/proto"
 )
 
 // SyncService implements the SyncService gRPC service.
@@ -18,16 +17,18 @@ type SyncService struct {
 	wppv1.UnimplementedSyncServiceServer
 
 	adapter     *wa.Adapter
-	bus         *bus.Bus
+	db          *store.DB
+	journal     *journal.Journal
 	machine     *status.Machine
 	sessionName string
 }
 
 // NewSyncService creates a new sync service.
-func NewSyncService(adapter *wa.Adapter, b *bus.Bus, machine *status.Machine, sessionName string) *SyncService {
+func NewSyncService(adapter *wa.Adapter, db *store.DB, j *journal.Journal, machine *status.Machine, sessionName string) *SyncService {
 	return &SyncService{
 		adapter:     adapter,
-		bus:         b,
+		db:          db,
+		journal:     j,
 		machine:     machine,
 		sessionName: sessionName,
 	}
@@ -36,9 +37,21 @@ func NewSyncService(adapter *wa.Adapter, b *bus.Bus, machine *status.Machine, se
 func (s *SyncService) GetSyncStatus(_ context.Context, _ *wppv1.GetSyncStatusRequest) (*wppv1.GetSyncStatusResponse, error) {
 	current := s.machine.Current()
 	syncing := current == status.Syncing || current == status.Ready
-	return &wppv1.GetSyncStatusResponse{
+	resp := &wppv1.GetSyncStatusResponse{
 		Syncing: syncing,
-	}, nil
+	}
+	if s.db != nil {
+		state, err := s.db.GetHistorySyncState()
+		if err != nil {
+			return nil, grpcstatus.Errorf(codes.Internal, "get sync state: %v", err)
+		}
+		resp.LastSyncAtUnixMs = state.LastSyncAt
+		resp.MessagesSynced = state.MessagesSynced
+		resp.ChatsSynced = state.ChatsSynced
+		resp.HistorySyncType = state.SyncType
+		resp.HistoryProgress = int32(state.Progress)
+	}
+	return resp, nil
 }
 
 func (s *SyncService) StartSync(_ context.Context, _ *wppv1.StartSyncRequest) (*wppv1.StartSyncResponse, error) {
@@ -63,48 +76,53 @@ func (s *SyncService) StopSync(_ context.Context, _ *wppv1.StopSyncRequest) (*wp
 	return &wppv1.StopSyncResponse{Success: true, Message: "sync stopped"}, nil
 }
 
-func (s *SyncService) WatchSyncEvents(_ *wppv1.WatchSyncEventsRequest, stream wppv1.SyncService_WatchSyncEventsServer) error {
-	ch, unsub := s.bus.Subscribe("sync.", 256)
-	defer unsub()
+// defaultBackfillCount and maxBackfillCount bound the messages asked for
+// by one BackfillChat.
+const (
+	defaultBackfillCount = 50
+	maxBackfillCount     = 500
+)
 
-	for {
-		select {
-		case evt := <-ch:
-			payload, _ := marshalSyncPayload(evt.Kind)
-			if err := stream.Send(&wppv1.EventEnvelope{
-				EventId:          uuid.New().String(),
-				Session:          s.sessionName,
-				OccurredAtUnixMs: evt.Timestamp.UnixMilli(),
-				Kind:             evt.Kind,
-				PayloadVersion:   1,
-				Payload:          payload,
-			}); err != nil {
-				return err
-			}
-		case <-stream.Context().Done():
-			return nil
-		}
+func (s *SyncService) BackfillChat(ctx context.Context, req *wppv1.BackfillChatRequest) (*wppv1.BackfillChatResponse, error) {
+	if req.ChatJid == "" {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "chat_jid is required")
 	}
-}
+	count := int(req.Count)
+	switch {
+	case count < 0:
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "count must not be negative")
+	case count == 0:
+		count = defaultBackfillCount
+	}
+	count = min(count, maxBackfillCount)
+	if s.adapter == nil {
+		return nil, grpcstatus.Errorf(codes.Unavailable, "adapter not initialized")
+	}
+
+	// The request is anchored at the oldest message we hold; the phone
+	// sends what precedes it.
+	oldest, err := s.db.OldestMessage(req.ChatJid)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "get oldest message: %v", err)
+	}
+	if oldest == nil {
+		return nil, grpcstatus.Errorf(codes.FailedPrecondition, "chat %q has no message to backfill from", req.ChatJid)
+	}
+	key := store.MessageKey{ChatJID: oldest.ChatJID, ID: oldest.MsgID, SenderJID: oldest.SenderJID, FromMe: oldest.FromMe}
 
-// marshalSyncPayload returns the correct proto payload for each sync event kind.
-func marshalSyncPayload(kind string) ([]byte, error) {
-	var msg proto.Message
-	switch kind {
-	case "sync.connected":
-		msg = &wppv1.SyncConnected{}
-	case "sync.disconnected":
-		msg = &wppv1.SyncDisconnected{}
-	case "sync.reconnecting":
-		msg = &wppv1.SyncReconnecting{}
-	case "sync.history_batch":
-		msg = &wppv1.SyncHistoryBatch{}
-	case "sync.connecting":
-		msg = &wppv1.SyncConnecting{}
-	case "sync.degraded":
-		msg = &wppv1.SyncDegraded{}
-	default:
-		msg = &wppv1.SyncConnected{}
+	// The request is recorded before it is sent so that even a quick
+	// answer finds it.
+	requestID := s.adapter.GenerateMessageID()
+	if err := s.db.SetBackfillRequest(req.ChatJid, requestID); err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "record backfill request: %v", err)
+	}
+	if err := s.adapter.RequestHistory(ctx, requestID, key, oldest.Timestamp, count); err != nil {
+		_, _ = s.db.ClaimBackfillRequest(req.ChatJid)
+		return nil, grpcstatus.Errorf(codes.Internal, "request history: %v", err)
 	}
-	return proto.Marshal(msg)
+	return &wppv1.BackfillChatResponse{RequestId: requestID, OldestMsgId: oldest.MsgID}, nil
+}
+
+func (s *SyncService) WatchSyncEvents(req *wppv1.WatchSyncEventsRequest, stream wppv1.SyncService_WatchSyncEventsServer) error {
+	return watchEvents(stream.Context(), s.journal, s.sessionName, req.Cursor, []string{"sync."}, "", stream.Send)
 }
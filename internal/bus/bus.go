@@ -1,6 +1,7 @@
 package bus
 
 import (
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -9,6 +10,9 @@ import (
 )
 
 // Bus is an in-process publish/subscribe event bus with namespace filtering.
+//
+// Publish never blocks. A best-effort subscription drops events while its
+// channel is full; a lossless one (see Lossless) queues them instead.
 type Bus struct {
 	mu      sync.RWMutex
 	subs    map[int]*subscription
@@ -18,8 +22,47 @@ type Bus struct {
 }
 
 type subscription struct {
+	name      string
 	namespace string
 	ch        chan Event
+	lossless  bool
+
+	delivered atomic.Int64
+	dropped   atomic.Int64
+
+	// Lossless subscriptions only: events waiting for room in ch, fed to
+	// it in order by pump.
+	mu        sync.Mutex
+	queue     []Event
+	maxQueued int
+	wake      chan struct{}
+	done      chan struct{}
+}
+
+// Option configures a subscription.
+type Option func(*subscription)
+
+// Lossless makes a subscription queue the events its channel has no room
+// for instead of dropping them. The queue is unbounded, so use it only for
+// subscribers that must see every event, such as ingestion.
+func Lossless() Option {
+	return func(s *subscription) { s.lossless = true }
+}
+
+// Named labels a subscription in Stats. The default name is its namespace.
+func Named(name string) Option {
+	return func(s *subscription) { s.name = name }
+}
+
+// SubscriberStats reports the delivery counters of one subscription.
+type SubscriberStats struct {
+	Name      string
+	Namespace string
+	Lossless  bool
+	Delivered int64
+	Dropped   int64
+	Queued    int // events waiting in a lossless queue
+	MaxQueued int // largest lossless queue length seen
 }
 
 // New creates a new event bus.
@@ -42,17 +85,24 @@ func (b *Bus) Publish(evt Event) {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 	for _, sub := range b.subs {
-		if strings.HasPrefix(evt.Kind, sub.namespace) {
-			select {
-			case sub.ch <- evt:
-			default:
-				b.Dropped.Add(1)
-				if b.logger != nil {
-					b.logger.Warn("bus event dropped",
-						zap.String("kind", evt.Kind),
-						zap.String("subscriber_ns", sub.namespace),
-					)
-				}
+		if !strings.HasPrefix(evt.Kind, sub.namespace) {
+			continue
+		}
+		if sub.lossless {
+			sub.enqueue(evt)
+			continue
+		}
+		select {
+		case sub.ch <- evt:
+			sub.delivered.Add(1)
+		default:
+			sub.dropped.Add(1)
+			b.Dropped.Add(1)
+			if b.logger != nil {
+				b.logger.Warn("bus event dropped",
+					zap.String("kind", evt.Kind),
+					zap.String("subscriber", sub.name),
+				)
 			}
 		}
 	}
@@ -60,17 +110,100 @@ func (b *Bus) Publish(evt Event) {
 
 // Subscribe returns a channel that receives events matching the given namespace prefix.
 // bufSize controls the channel buffer. Returns the channel and an unsubscribe function.
-func (b *Bus) Subscribe(namespace string, bufSize int) (<-chan Event, func()) {
-	ch := make(chan Event, bufSize)
+func (b *Bus) Subscribe(namespace string, bufSize int, opts ...Option) (<-chan Event, func()) {
+	sub := &subscription{
+		name:      namespace,
+		namespace: namespace,
+		ch:        make(chan Event, bufSize),
+	}
+	for _, opt := range opts {
+		opt(sub)
+	}
+	if sub.lossless {
+		sub.wake = make(chan struct{}, 1)
+		sub.done = make(chan struct{})
+		go sub.pump()
+	}
+
 	b.mu.Lock()
 	id := b.next
 	b.next++
-	b.subs[id] = &subscription{namespace: namespace, ch: ch}
+	b.subs[id] = sub
 	b.mu.Unlock()
 
-	return ch, func() {
+	var once sync.Once
+	return sub.ch, func() {
 		b.mu.Lock()
 		delete(b.subs, id)
 		b.mu.Unlock()
+		if sub.lossless {
+			once.Do(func() { close(sub.done) })
+		}
+	}
+}
+
+// Stats returns the counters of every current subscription, by name.
+func (b *Bus) Stats() []SubscriberStats {
+	b.mu.RLock()
+	stats := make([]SubscriberStats, 0, len(b.subs))
+	for _, sub := range b.subs {
+		sub.mu.Lock()
+		queued, maxQueued := len(sub.queue), sub.maxQueued
+		sub.mu.Unlock()
+		stats = append(stats, SubscriberStats{
+			Name:      sub.name,
+			Namespace: sub.namespace,
+			Lossless:  sub.lossless,
+			Delivered: sub.delivered.Load(),
+			Dropped:   sub.dropped.Load(),
+			Queued:    queued,
+			MaxQueued: maxQueued,
+		})
+	}
+	b.mu.RUnlock()
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+func (s *subscription) enqueue(evt Event) {
+	s.mu.Lock()
+	s.queue = append(s.queue, evt)
+	s.maxQueued = max(s.maxQueued, len(s.queue))
+	s.mu.Unlock()
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// pump moves queued events into the channel, in order, until the
+// subscription is cancelled.
+func (s *subscription) pump() {
+	for {
+		select {
+		case <-s.wake:
+		case <-s.done:
+			return
+		}
+		for {
+			s.mu.Lock()
+			if len(s.queue) == 0 {
+				s.queue = nil
+				s.mu.Unlock()
+				break
+			}
+			evt := s.queue[0]
+			s.queue[0] = Event{} // release the payload
+			s.queue = s.queue[1:]
+			s.mu.Unlock()
+
+			select {
+			case s.ch <- evt:
+				s.delivered.Add(1)
+			case <-s.done:
+				return
+			}
+		}
 	}
 }
@@ -82,3 +82,66 @@ func TestDropOnFullBuffer(t *testing.T) {
 		t.Errorf("dropped = %d, want 1", b.Dropped.Load())
 	}
 }
+
+func TestLosslessQueuesInOrder(t *testing.T) {
+	b := New()
+	ch, unsub := b.Subscribe("test.", 1, Lossless(), Named("ingest"))
+	defer unsub()
+
+	// Nothing reads while publishing, so all but one event must queue.
+	const n = 500
+	for i := range n {
+		b.Publish(Event{Kind: "test.event", Payload: i})
+	}
+	for i := range n {
+		select {
+		case evt := <-ch:
+			if evt.Payload != i {
+				t.Fatalf("event %d has payload %v", i, evt.Payload)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for event %d", i)
+		}
+	}
+
+	stats := b.Stats()
+	if len(stats) != 1 {
+		t.Fatalf("stats = %+v, want one subscriber", stats)
+	}
+	s := stats[0]
+	if s.Name != "ingest" || !s.Lossless || s.Delivered != n || s.Dropped != 0 || s.Queued != 0 || s.MaxQueued < n-2 {
+		t.Errorf("stats = %+v", s)
+	}
+	if b.Dropped.Load() != 0 {
+		t.Errorf("dropped = %d, want 0", b.Dropped.Load())
+	}
+}
+
+func TestStatsPerSubscriber(t *testing.T) {
+	b := New()
+	_, unsubA := b.Subscribe("test.", 1, Named("a"))
+	defer unsubA()
+	_, unsubB := b.Subscribe("test.", 3, Named("b"))
+	defer unsubB()
+
+	for range 3 {
+		b.Publish(Event{Kind: "test.event"})
+	}
+
+	stats := b.Stats()
+	if len(stats) != 2 || stats[0].Name != "a" || stats[1].Name != "b" {
+		t.Fatalf("stats = %+v, want a and b", stats)
+	}
+	if stats[0].Delivered != 1 || stats[0].Dropped != 2 {
+		t.Errorf("a = %+v, want 1 delivered, 2 dropped", stats[0])
+	}
+	if stats[1].Delivered != 3 || stats[1].Dropped != 0 {
+		t.Errorf("b = %+v, want 3 delivered, 0 dropped", stats[1])
+	}
+
+	// Unsubscribed subscribers leave the stats.
+	unsubA()
+	if stats := b.Stats(); len(stats) != 1 || stats[0].Name != "b" {
+		t.Errorf("stats after unsubscribe = %+v, want only b", stats)
+	}
+}
@@ -3,13 +3,32 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
 
 // Config represents the global ~/.wpp/config.toml.
 type Config struct {
-	DefaultSession string `toml:"default_session"`
+	DefaultSession string       `toml:"default_session"`
+	Events         EventsConfig `toml:"events,omitempty"`
+	Outbox         OutboxConfig `toml:"outbox,omitempty"`
+}
+
+// EventsConfig bounds the daemon's event journal. Zero values use the
+// daemon defaults.
+type EventsConfig struct {
+	Retention time.Duration `toml:"retention,omitempty"`  // maximum age of a journaled event, e.g. "72h"
+	MaxEvents int           `toml:"max_events,omitempty"` // maximum number of journaled events
+}
+
+// OutboxConfig is the retry policy of failed sends. Zero values use the
+// daemon defaults.
+type OutboxConfig struct {
+	MaxAttempts    int           `toml:"max_attempts,omitempty"`    // attempts before a send is dead
+	InitialBackoff time.Duration `toml:"initial_backoff,omitempty"` // delay before the first retry, e.g. "2s"
+	MaxBackoff     time.Duration `toml:"max_backoff,omitempty"`     // cap of the doubling delay
+	Workers        int           `toml:"workers,omitempty"`         // chats sent to concurrently
 }
 
 // Load reads config from the given path. Returns zero config and error if file missing.
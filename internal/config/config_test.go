@@ -3,7 +3,9 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestSaveAndLoad(t *testing.T) {
@@ -48,3 +50,48 @@ func TestSavePermissions(t *testing.T) {
 		t.Errorf("file permission = %o, want 0600", perm)
 	}
 }
+
+func TestLoadEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	data := "default_session = \"main\"\n\n[events]\nretention = \"72h\"\nmax_events = 5000\n"
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Events.Retention != 72*time.Hour || cfg.Events.MaxEvents != 5000 {
+		t.Errorf("Events = %+v, want 72h and 5000", cfg.Events)
+	}
+
+	// An unset events table is not written back.
+	if err := Save(path, &Config{DefaultSession: "main"}); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(saved), "events") {
+		t.Errorf("saved config has an events table:\n%s", saved)
+	}
+}
+
+func TestLoadOutbox(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	data := "[outbox]\nmax_attempts = 8\ninitial_backoff = \"1s\"\nmax_backoff = \"10m\"\n"
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := OutboxConfig{MaxAttempts: 8, InitialBackoff: time.Second, MaxBackoff: 10 * time.Minute}
+	if cfg.Outbox != want {
+		t.Errorf("Outbox = %+v, want %+v", cfg.Outbox, want)
+	}
+}
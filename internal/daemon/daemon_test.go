@@ -2,6 +2,7 @@ package daemon
 
 import (
 	"context"
+	"encoding/base64"
 	"net"
 	"os"
 	"path/filepath"
@@ -11,12 +12,16 @@ import (
 	wppv1 "github.com/matheus3301/wpp/gen/wpp/v1"
 	"github.com/matheus3301/wpp/internal/api"
 	"github.com/matheus3301/wpp/internal/bus"
+	"github.com/matheus3301/wpp/internal/config"
+	"github.com/matheus3301/wpp/internal/journal"
 	"github.com/matheus3301/wpp/internal/lock"
 	"github.com/matheus3301/wpp/internal/status"
 	"github.com/matheus3301/wpp/internal/store"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	grpcstatus "google.golang.org/grpc/status"
 )
 
 func TestDaemonLifecycle(t *testing.T) {
@@ -56,10 +61,13 @@ func TestDaemonLifecycle(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 	b := bus.New()
 	machine := status.NewMachine(b)
+	j := journal.New(db, b, config.EventsConfig{}, logger)
+	j.Start(context.Background())
+	defer j.Stop()
 	sessionSvc := api.NewSessionService(sessionName, machine, nil, b, db)
-	syncSvc := api.NewSyncService(nil, b, machine, sessionName)
-	chatSvc := api.NewChatService(db, b, sessionName)
-	messageSvc := api.NewMessageService(db, b, sessionName)
+	syncSvc := api.NewSyncService(nil, db, j, machine, sessionName)
+	chatSvc := api.NewChatService(db, nil, b, j, sessionName)
+	messageSvc := api.NewMessageService(db, nil, b, j, nil, nil, sessionName)
 
 	// Create gRPC server manually.
 	grpcSrv := grpc.NewServer()
@@ -102,6 +110,9 @@ func TestDaemonLifecycle(t *testing.T) {
 	}
 
 	// Test GetSyncStatus.
+	if err := db.RecordHistoryBatch(&store.HistoryBatch{SyncType: "recent", Progress: 40}, 5, 2); err != nil {
+		t.Fatal(err)
+	}
 	syncClient := wppv1.NewSyncServiceClient(conn)
 	syncResp, err := syncClient.GetSyncStatus(context.Background(), &wppv1.GetSyncStatusRequest{})
 	if err != nil {
@@ -110,6 +121,12 @@ func TestDaemonLifecycle(t *testing.T) {
 	if syncResp.Syncing {
 		t.Error("expected syncing = false")
 	}
+	if syncResp.MessagesSynced != 5 || syncResp.ChatsSynced != 2 || syncResp.LastSyncAtUnixMs == 0 {
+		t.Errorf("synced = %d messages, %d chats, last at %d; want 5, 2, set", syncResp.MessagesSynced, syncResp.ChatsSynced, syncResp.LastSyncAtUnixMs)
+	}
+	if syncResp.HistorySyncType != "recent" || syncResp.HistoryProgress != 40 {
+		t.Errorf("history = %s at %d%%, want recent at 40%%", syncResp.HistorySyncType, syncResp.HistoryProgress)
+	}
 
 	// Test ListChats (empty).
 	chatClient := wppv1.NewChatServiceClient(conn)
@@ -137,6 +154,15 @@ func TestDaemonLifecycle(t *testing.T) {
 		t.Errorf("expected 1 chat, got %d", len(chatResp.Chats))
 	}
 
+	// Malformed cursors are rejected, even with a valid prefix.
+	for _, raw := range []string{"1000:0junk:test@s", "1000:0"} {
+		cursor := base64.RawURLEncoding.EncodeToString([]byte(raw))
+		_, err := chatClient.ListChats(context.Background(), &wppv1.ListChatsRequest{Pagination: &wppv1.Pagination{Cursor: cursor}})
+		if grpcstatus.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListChats with cursor %q error = %v, want InvalidArgument", raw, err)
+		}
+	}
+
 	// Test ListMessages.
 	msgClient := wppv1.NewMessageServiceClient(conn)
 	msgResp, err := msgClient.ListMessages(context.Background(), &wppv1.ListMessagesRequest{ChatJid: "test@s"})
@@ -165,6 +191,40 @@ func TestDaemonLifecycle(t *testing.T) {
 		t.Error("expected accepted = true")
 	}
 
+	// Test WatchMessageEvents replays the journal from a cursor. SendText
+	// published message.upserted; wait for the journal to record it.
+	for deadline := time.Now().Add(2 * time.Second); ; {
+		if _, newest, err := db.EventRange(); err != nil {
+			t.Fatal(err)
+		} else if newest > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("SendText event was not journaled")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	watchCtx, watchCancel := context.WithTimeout(context.Background(), time.Second)
+	defer watchCancel()
+	stream, err := msgClient.WatchMessageEvents(watchCtx, &wppv1.WatchMessageEventsRequest{Cursor: "0"})
+	if err != nil {
+		t.Fatalf("WatchMessageEvents error = %v", err)
+	}
+	envelope, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("WatchMessageEvents recv error = %v", err)
+	}
+	if envelope.EventId != "1" || envelope.Kind != "message.upserted" {
+		t.Errorf("replayed event = %v, want event 1 message.upserted", envelope)
+	}
+	stream, err = msgClient.WatchMessageEvents(watchCtx, &wppv1.WatchMessageEventsRequest{Cursor: "bogus"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if grpcstatus.Code(err) != codes.InvalidArgument {
+		t.Errorf("bad cursor error = %v, want InvalidArgument", err)
+	}
+
 	logger.Info("integration test passed")
 }
 
@@ -324,9 +384,9 @@ func TestFxModuleWiring(t *testing.T) {
 		p,
 		zap.NewNop(),
 		api.NewSessionService("fxtest", status.NewMachine(nil), nil, nil, nil),
-		api.NewSyncService(nil, nil, status.NewMachine(nil), "fxtest"),
-		api.NewChatService(nil, nil, "fxtest"),
-		api.NewMessageService(nil, nil, "fxtest"),
+		api.NewSyncService(nil, nil, nil, status.NewMachine(nil), "fxtest"),
+		api.NewChatService(nil, nil, nil, nil, "fxtest"),
+		api.NewMessageService(nil, nil, nil, nil, nil, nil, "fxtest"),
 	)
 	if err != nil {
 		t.Fatalf("NewServer() with Params failed: %v", err)
@@ -6,8 +6,11 @@ import (
 
 	"github.com/matheus3301/wpp/internal/api"
 	"github.com/matheus3301/wpp/internal/bus"
+	"github.com/matheus3301/wpp/internal/config"
+	"github.com/matheus3301/wpp/internal/journal"
 	"github.com/matheus3301/wpp/internal/lock"
 	"github.com/matheus3301/wpp/internal/logging"
+	"github.com/matheus3301/wpp/internal/media"
 	"github.com/matheus3301/wpp/internal/outbox"
 	"github.com/matheus3301/wpp/internal/session"
 	"github.com/matheus3301/wpp/internal/status"
@@ -30,13 +33,16 @@ func Module(p Params) fx.Option {
 		fx.Supply(p),
 		fx.Provide(
 			provideLogger,
+			provideConfig,
 			provideBus,
 			provideStateMachine,
 			provideLock,
 			provideStore,
 			provideAdapter,
+			provideJournal,
 			provideSyncEngine,
 			provideSender,
+			provideMediaCache,
 			provideSessionService,
 			provideSyncService,
 			provideChatService,
@@ -51,6 +57,17 @@ func provideLogger(p Params) (*zap.Logger, error) {
 	return logging.New(session.LogPath(p.SessionName), p.SessionName)
 }
 
+// provideConfig loads the global config. A missing or unreadable file
+// yields the zero config, so every setting falls back to its default.
+func provideConfig(logger *zap.Logger) *config.Config {
+	cfg, err := config.Load(session.ConfigPath())
+	if err != nil {
+		logger.Debug("using default config", zap.Error(err))
+		return &config.Config{}
+	}
+	return cfg
+}
+
 func provideBus(logger *zap.Logger) *bus.Bus {
 	return bus.NewWithLogger(logger)
 }
@@ -96,31 +113,39 @@ func provideAdapter(p Params, b *bus.Bus, logger *zap.Logger) (*wa.Adapter, erro
 	return wa.NewAdapter(context.Background(), p.SessionName, b, logger)
 }
 
+func provideJournal(cfg *config.Config, db *store.DB, b *bus.Bus, logger *zap.Logger) *journal.Journal {
+	return journal.New(db, b, cfg.Events, logger)
+}
+
 func provideSyncEngine(db *store.DB, b *bus.Bus, logger *zap.Logger) *intsync.Engine {
 	return intsync.NewEngine(db, b, logger)
 }
 
-func provideSender(db *store.DB, adapter *wa.Adapter, b *bus.Bus, m *status.Machine, logger *zap.Logger) *outbox.Sender {
-	return outbox.NewSender(db, adapter, b, m, logger)
+func provideSender(cfg *config.Config, db *store.DB, adapter *wa.Adapter, b *bus.Bus, m *status.Machine, logger *zap.Logger) *outbox.Sender {
+	return outbox.NewSender(db, adapter, b, m, cfg.Outbox, logger)
+}
+
+func provideMediaCache(p Params, db *store.DB, adapter *wa.Adapter) *media.Cache {
+	return media.NewCache(session.MediaDir(p.SessionName), db, adapter)
 }
 
 func provideSessionService(p Params, m *status.Machine, adapter *wa.Adapter, b *bus.Bus, db *store.DB) *api.SessionService {
 	return api.NewSessionService(p.SessionName, m, adapter, b, db)
 }
 
-func provideSyncService(p Params, adapter *wa.Adapter, b *bus.Bus, m *status.Machine) *api.SyncService {
-	return api.NewSyncService(adapter, b, m, p.SessionName)
+func provideSyncService(p Params, adapter *wa.Adapter, db *store.DB, j *journal.Journal, m *status.Machine) *api.SyncService {
+	return api.NewSyncService(adapter, db, j, m, p.SessionName)
 }
 
-func provideChatService(p Params, db *store.DB, b *bus.Bus) *api.ChatService {
-	return api.NewChatService(db, b, p.SessionName)
+func provideChatService(p Params, db *store.DB, adapter *wa.Adapter, b *bus.Bus, j *journal.Journal) *api.ChatService {
+	return api.NewChatService(db, adapter, b, j, p.SessionName)
 }
 
-func provideMessageService(p Params, db *store.DB, b *bus.Bus) *api.MessageService {
-	return api.NewMessageService(db, b, p.SessionName)
+func provideMessageService(p Params, db *store.DB, adapter *wa.Adapter, b *bus.Bus, j *journal.Journal, cache *media.Cache, sender *outbox.Sender) *api.MessageService {
+	return api.NewMessageService(db, adapter, b, j, cache, sender, p.SessionName)
 }
 
-func registerLifecycle(lc fx.Lifecycle, srv *Server, lk *lock.Lock, db *store.DB, adapter *wa.Adapter, engine *intsync.Engine, sender *outbox.Sender, machine *status.Machine, b *bus.Bus, logger *zap.Logger) {
+func registerLifecycle(lc fx.Lifecycle, srv *Server, lk *lock.Lock, db *store.DB, adapter *wa.Adapter, j *journal.Journal, engine *intsync.Engine, sender *outbox.Sender, machine *status.Machine, b *bus.Bus, logger *zap.Logger) {
 	lc.Append(fx.Hook{
 		OnStart: func(_ context.Context) error {
 			// Recover any in-flight outbox messages from a previous crash.
@@ -130,6 +155,10 @@ func registerLifecycle(lc fx.Lifecycle, srv *Server, lk *lock.Lock, db *store.DB
 				logger.Info("recovered outbox entries", zap.Int64("count", recovered))
 			}
 
+			// Journal the events streamed to clients before anything
+			// publishes them.
+			j.Start(context.Background())
+
 			// Start sync engine (subscribes to wa.* bus events).
 			engine.Start(context.Background())
 
@@ -149,7 +178,7 @@ func registerLifecycle(lc fx.Lifecycle, srv *Server, lk *lock.Lock, db *store.DB
 
 			// Listen for sync.connected to trigger LID reconciliation.
 			go func() {
-				ch, unsub := b.Subscribe("sync.connected", 1)
+				ch, unsub := b.Subscribe("sync.connected", 1, bus.Named("lid-reconcile"))
 				defer unsub()
 
 				select {
@@ -205,6 +234,7 @@ func registerLifecycle(lc fx.Lifecycle, srv *Server, lk *lock.Lock, db *store.DB
 			sender.Stop()
 			engine.Stop()
 			adapter.Disconnect()
+			j.Stop()
 			srv.Stop(ctx)
 			if err := db.Close(); err != nil {
 				logger.Warn("error closing database", zap.Error(err))
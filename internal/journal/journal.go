@@ -0,0 +1,225 @@
+// Package journal persists the events streamed to clients so that Watch*
+// streams can resume from a cursor instead of losing whatever the bus
+// delivered while a client was away.
+package journal
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/matheus3301/wpp/internal/bus"
+	"github.com/matheus3301/wpp/internal/config"
+	"github.com/matheus3301/wpp/internal/store"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultRetention is how long events are kept when not configured.
+	DefaultRetention = 72 * time.Hour
+	// DefaultMaxEvents is how many events are kept when not configured.
+	DefaultMaxEvents = 100000
+
+	pruneInterval = 10 * time.Minute
+	replayBatch   = 500
+)
+
+// Namespaces are the bus namespaces recorded in the journal: those
+// streamed by the Watch* RPCs.
+var Namespaces = []string{"sync.", "chat.", "message."}
+
+var (
+	// ErrInvalidCursor is returned by Watch for a malformed cursor.
+	ErrInvalidCursor = errors.New("invalid event cursor")
+	// ErrCursorExpired is returned by Watch when events after the cursor
+	// were pruned, or the cursor is ahead of the journal. The client must
+	// reload its state and watch from the live tail.
+	ErrCursorExpired = errors.New("event cursor expired")
+)
+
+// Journal appends bus events to the events table and serves them back to
+// Watch* streams: first the events after the client's cursor, then new
+// events as they are appended.
+type Journal struct {
+	db        *store.DB
+	bus       *bus.Bus
+	logger    *zap.Logger
+	retention time.Duration
+	maxEvents int
+	cancel    context.CancelFunc
+
+	mu      sync.Mutex
+	changed chan struct{} // closed and replaced on every append
+}
+
+// New creates a journal with the retention in cfg.
+func New(db *store.DB, b *bus.Bus, cfg config.EventsConfig, logger *zap.Logger) *Journal {
+	j := &Journal{
+		db:        db,
+		bus:       b,
+		logger:    logger,
+		retention: cfg.Retention,
+		maxEvents: cfg.MaxEvents,
+		changed:   make(chan struct{}),
+	}
+	if j.retention <= 0 {
+		j.retention = DefaultRetention
+	}
+	if j.maxEvents <= 0 {
+		j.maxEvents = DefaultMaxEvents
+	}
+	return j
+}
+
+// Start subscribes to the journaled namespaces and prunes old events
+// periodically.
+func (j *Journal) Start(ctx context.Context) {
+	ctx, j.cancel = context.WithCancel(ctx)
+
+	// One subscription per namespace keeps a burst in one namespace from
+	// crowding out the others. Order is kept within each namespace, and the
+	// subscriptions are lossless so a slow disk never leaves holes in the log.
+	merged := make(chan bus.Event)
+	for _, ns := range Namespaces {
+		ch, unsub := j.bus.Subscribe(ns, 1024, bus.Lossless(), bus.Named("journal:"+ns))
+		go func() {
+			defer unsub()
+			for {
+				select {
+				case evt := <-ch:
+					select {
+					case merged <- evt:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		ticker := time.NewTicker(pruneInterval)
+		defer ticker.Stop()
+		j.prune()
+		for {
+			select {
+			case evt := <-merged:
+				if err := j.Append(evt); err != nil {
+					j.logger.Error("failed to journal event", zap.Error(err), zap.String("kind", evt.Kind))
+				}
+			case <-ticker.C:
+				j.prune()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops journaling.
+func (j *Journal) Stop() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+}
+
+// Append journals evt and wakes watchers.
+func (j *Journal) Append(evt bus.Event) error {
+	e := encode(evt)
+	if err := j.db.AppendEvent(e); err != nil {
+		return err
+	}
+	j.mu.Lock()
+	close(j.changed)
+	j.changed = make(chan struct{})
+	j.mu.Unlock()
+	return nil
+}
+
+func (j *Journal) prune() {
+	n, err := j.db.PruneEvents(time.Now().Add(-j.retention).UnixMilli(), j.maxEvents)
+	if err != nil {
+		j.logger.Warn("failed to prune event journal", zap.Error(err))
+		return
+	}
+	if n > 0 {
+		j.logger.Info("pruned event journal", zap.Int64("events", n))
+	}
+}
+
+// Watch calls send for every journaled event whose kind starts with one
+// of prefixes and, when chatJID is set, that is about that chat. It
+// starts after cursor, or at the live tail when cursor is empty, and
+// returns when ctx is done or send fails.
+func (j *Journal) Watch(ctx context.Context, cursor string, prefixes []string, chatJID string, send func(store.Event) error) error {
+	oldest, newest, err := j.db.EventRange()
+	if err != nil {
+		return err
+	}
+	last := newest
+	if cursor != "" {
+		last, err = strconv.ParseInt(cursor, 10, 64)
+		if err != nil || last < 0 {
+			return ErrInvalidCursor
+		}
+		if last < oldest-1 || last > newest {
+			return ErrCursorExpired
+		}
+	}
+
+	for {
+		// Take the wakeup channel before reading so an append between the
+		// read and the wait is not missed.
+		j.mu.Lock()
+		changed := j.changed
+		j.mu.Unlock()
+
+		events, err := j.db.ListEvents(last, prefixes, chatJID, replayBatch)
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			if err := send(e); err != nil {
+				return err
+			}
+			last = e.ID
+		}
+		if len(events) == replayBatch {
+			continue
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// WatchLive calls send for every event published on the bus in namespace
+// and, when chatJID is set, about that chat, from now until ctx is done or
+// send fails. It serves namespaces that are not journaled, like presence:
+// their events have no ID, so a stream of them cannot be resumed, and a
+// slow client misses events rather than holding up the bus.
+func (j *Journal) WatchLive(ctx context.Context, namespace, chatJID string, send func(store.Event) error) error {
+	ch, unsub := j.bus.Subscribe(namespace, 64, bus.Named("watch:"+namespace))
+	defer unsub()
+	for {
+		select {
+		case evt := <-ch:
+			e := encode(evt)
+			if chatJID != "" && e.ChatJID != chatJID {
+				continue
+			}
+			if err := send(*e); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
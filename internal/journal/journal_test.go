@@ -0,0 +1,180 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	wppv1 "github.com/matheus3301/wpp/gen/wpp/v1"
+	"github.com/matheus3301/wpp/internal/bus"
+	"github.com/matheus3301/wpp/internal/config"
+	"github.com/matheus3301/wpp/internal/store"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+func testDB(t *testing.T) *store.DB {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.db")
+	db, err := store.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Migrate(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// watch runs Watch in the background and returns the channel of events it
+// sends.
+func watch(t *testing.T, j *Journal, cursor string, prefixes []string, chatJID string) <-chan store.Event {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	ch := make(chan store.Event, 16)
+	go func() {
+		_ = j.Watch(ctx, cursor, prefixes, chatJID, func(e store.Event) error {
+			ch <- e
+			return nil
+		})
+	}()
+	return ch
+}
+
+func next(t *testing.T, ch <-chan store.Event) store.Event {
+	t.Helper()
+	select {
+	case e := <-ch:
+		return e
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for event")
+		return store.Event{}
+	}
+}
+
+func TestWatchReplaysThenTails(t *testing.T) {
+	db := testDB(t)
+	j := New(db, bus.New(), config.EventsConfig{}, zap.NewNop())
+
+	for _, evt := range []bus.Event{
+		{Kind: "message.upserted", Timestamp: time.UnixMilli(1000), Payload: map[string]string{"chat_jid": "a@s"}},
+		{Kind: "chat.updated", Timestamp: time.UnixMilli(2000), Payload: map[string]string{"chat_jid": "a@s"}},
+		{Kind: "message.status_changed", Timestamp: time.UnixMilli(3000), Payload: map[string]string{"chat_jid": "b@s", "msg_id": "m1", "status": "read"}},
+	} {
+		if err := j.Append(evt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Resuming after event 1 replays the later message events only.
+	ch := watch(t, j, "1", []string{"message."}, "")
+	e := next(t, ch)
+	if e.ID != 3 || e.Kind != "message.status_changed" || e.ChatJID != "b@s" || e.OccurredAt != 3000 {
+		t.Errorf("replayed %+v, want event 3", e)
+	}
+	var status wppv1.MessageStatusChanged
+	if err := proto.Unmarshal(e.Payload, &status); err != nil || status.Status != "read" {
+		t.Errorf("payload = %v (%v), want status read", &status, err)
+	}
+
+	// Then it tails new events.
+	if err := j.Append(bus.Event{Kind: "message.upserted", Timestamp: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if e := next(t, ch); e.ID != 4 {
+		t.Errorf("tailed event %d, want 4", e.ID)
+	}
+
+	// An empty cursor starts at the live tail; a chat filter applies.
+	live := watch(t, j, "", []string{"message.", "chat."}, "a@s")
+	time.Sleep(50 * time.Millisecond)
+	for _, chat := range []string{"b@s", "a@s"} {
+		if err := j.Append(bus.Event{Kind: "chat.updated", Timestamp: time.Now(), Payload: map[string]string{"chat_jid": chat}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if e := next(t, live); e.ID != 6 || e.ChatJID != "a@s" {
+		t.Errorf("live event = %+v, want event 6 for a@s", e)
+	}
+}
+
+func TestWatchCursorErrors(t *testing.T) {
+	db := testDB(t)
+	j := New(db, bus.New(), config.EventsConfig{MaxEvents: 2}, zap.NewNop())
+
+	for range 5 {
+		if err := j.Append(bus.Event{Kind: "message.upserted", Timestamp: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	j.prune()
+
+	send := func(store.Event) error { return nil }
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	tests := []struct {
+		cursor string
+		want   error
+	}{
+		{"abc", ErrInvalidCursor},
+		{"-1", ErrInvalidCursor},
+		{"2", ErrCursorExpired}, // event 3 was pruned
+		{"9", ErrCursorExpired}, // ahead of the journal
+		{"3", nil},              // events 4 and 5 are retained
+	}
+	for _, tt := range tests {
+		if err := j.Watch(ctx, tt.cursor, nil, "", send); !errors.Is(err, tt.want) {
+			t.Errorf("Watch(%q) = %v, want %v", tt.cursor, err, tt.want)
+		}
+	}
+}
+
+func TestStartJournalsBusEvents(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	j := New(db, b, config.EventsConfig{}, zap.NewNop())
+	j.Start(context.Background())
+	defer j.Stop()
+
+	ch := watch(t, j, "0", nil, "")
+	time.Sleep(50 * time.Millisecond)
+	b.Publish(bus.Event{Kind: "wa.message", Timestamp: time.Now()}) // not journaled
+	b.Publish(bus.Event{Kind: "sync.connected", Timestamp: time.Now()})
+
+	if e := next(t, ch); e.Kind != "sync.connected" {
+		t.Errorf("journaled %q, want sync.connected", e.Kind)
+	}
+}
+
+func TestWatchLive(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	j := New(db, b, config.EventsConfig{}, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan store.Event, 16)
+	go func() {
+		_ = j.WatchLive(ctx, "presence.", "a@s", func(e store.Event) error {
+			ch <- e
+			return nil
+		})
+	}()
+	time.Sleep(50 * time.Millisecond)
+	b.Publish(bus.Event{Kind: "presence.updated", Timestamp: time.Now(), Payload: map[string]string{"chat_jid": "b@s", "online": "true"}})
+	b.Publish(bus.Event{Kind: "presence.updated", Timestamp: time.Now(), Payload: map[string]string{"chat_jid": "a@s", "online": "true"}})
+
+	// Only the event about a@s is sent, and nothing is journaled.
+	e := next(t, ch)
+	var p wppv1.PresenceUpdated
+	if err := proto.Unmarshal(e.Payload, &p); err != nil || p.Jid != "a@s" || !p.Online {
+		t.Errorf("sent %+v (%v), want a@s online", &p, err)
+	}
+	if _, newest, err := db.EventRange(); err != nil || newest != 0 {
+		t.Errorf("EventRange newest = %d, %v, want an empty journal", newest, err)
+	}
+}
@@ -0,0 +1,117 @@
+package journal
+
+import (
+	"strconv"
+	"time"
+
+	wppv1 "github.com/matheus3301/wpp/gen/wpp/v1"
+	"github.com/matheus3301/wpp/internal/bus"
+	"github.com/matheus3301/wpp/internal/store"
+	"google.golang.org/protobuf/proto"
+)
+
+// encode converts a bus event into its journal row. This is the one place
+// where bus payloads become the typed proto payloads of EventEnvelope;
+// send flows carry their client_msg_id as correlation id, and the answer
+// to a backfill its request_id.
+func encode(evt bus.Event) *store.Event {
+	e := &store.Event{Kind: evt.Kind, OccurredAt: evt.Timestamp.UnixMilli()}
+	if evt.Timestamp.IsZero() {
+		e.OccurredAt = time.Now().UnixMilli()
+	}
+	m, _ := evt.Payload.(map[string]string)
+	e.ChatJID = m["chat_jid"]
+	e.CorrelationID = m["client_msg_id"]
+	if e.CorrelationID == "" {
+		e.CorrelationID = m["request_id"]
+	}
+
+	if msg := payload(evt.Kind, evt.Payload); msg != nil {
+		e.Payload, _ = proto.Marshal(msg)
+	}
+	return e
+}
+
+// payload returns the typed proto payload of a bus event, or nil for kinds
+// without one.
+func payload(kind string, p any) proto.Message {
+	m, _ := p.(map[string]string)
+	switch kind {
+	case "message.upserted":
+		return &wppv1.MessageUpserted{
+			ChatJid: m["chat_jid"],
+			MsgId:   m["msg_id"],
+			IsNew:   m["is_new"] == "true",
+		}
+	case "message.send_ack":
+		return &wppv1.MessageSendAck{
+			ClientMsgId: m["client_msg_id"],
+			ServerMsgId: m["server_msg_id"],
+			ChatJid:     m["chat_jid"],
+		}
+	case "message.send_failed":
+		attempts, _ := strconv.Atoi(m["attempts"])
+		next, _ := strconv.ParseInt(m["next_attempt_at"], 10, 64)
+		return &wppv1.MessageSendFailed{
+			ClientMsgId:       m["client_msg_id"],
+			Reason:            m["reason"],
+			ChatJid:           m["chat_jid"],
+			Attempts:          int32(attempts),
+			NextAttemptUnixMs: next,
+		}
+	case "message.status_changed":
+		return &wppv1.MessageStatusChanged{
+			ChatJid: m["chat_jid"],
+			MsgId:   m["msg_id"],
+			Status:  m["status"],
+		}
+	case "chat.updated":
+		return &wppv1.ChatUpdated{ChatJid: m["chat_jid"]}
+	case "chat.cleared":
+		return &wppv1.ChatCleared{ChatJid: m["chat_jid"], Deleted: m["deleted"] == "true"}
+	case "presence.chat_state":
+		return &wppv1.PresenceChatState{
+			ChatJid:   m["chat_jid"],
+			SenderJid: m["sender_jid"],
+			State:     m["state"],
+		}
+	case "presence.updated":
+		lastSeen, _ := strconv.ParseInt(m["last_seen_at"], 10, 64)
+		return &wppv1.PresenceUpdated{
+			Jid:            m["chat_jid"],
+			Online:         m["online"] == "true",
+			LastSeenUnixMs: lastSeen,
+		}
+	case "sync.connecting":
+		return &wppv1.SyncConnecting{}
+	case "sync.connected":
+		return &wppv1.SyncConnected{}
+	case "sync.history_batch":
+		messages, _ := strconv.Atoi(m["messages_count"])
+		chats, _ := strconv.Atoi(m["chats_count"])
+		chunk, _ := strconv.Atoi(m["chunk_order"])
+		progress, _ := strconv.Atoi(m["progress"])
+		totalMessages, _ := strconv.ParseInt(m["total_messages"], 10, 64)
+		totalChats, _ := strconv.ParseInt(m["total_chats"], 10, 64)
+		return &wppv1.SyncHistoryBatch{
+			MessagesCount: int32(messages),
+			ChatsCount:    int32(chats),
+			SyncType:      m["sync_type"],
+			ChunkOrder:    int32(chunk),
+			Progress:      int32(progress),
+			TotalMessages: totalMessages,
+			TotalChats:    totalChats,
+			ChatJid:       m["chat_jid"],
+			RequestId:     m["request_id"],
+		}
+	case "sync.reconnecting":
+		attempt, _ := strconv.Atoi(m["attempt"])
+		return &wppv1.SyncReconnecting{Attempt: int32(attempt)}
+	case "sync.disconnected":
+		return &wppv1.SyncDisconnected{Reason: m["reason"]}
+	case "sync.degraded":
+		return &wppv1.SyncDegraded{Reason: m["reason"]}
+	default:
+		return nil
+	}
+}
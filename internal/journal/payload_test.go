@@ -0,0 +1,100 @@
+package journal
+
+import (
+	"testing"
+	"time"
+
+	wppv1 "github.com/matheus3301/wpp/gen/wpp/v1"
+	"github.com/matheus3301/wpp/internal/bus"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestEncodePayloads(t *testing.T) {
+	tests := []struct {
+		evt         bus.Event
+		want        proto.Message
+		chatJID     string
+		correlation string
+	}{
+		{
+			evt:     bus.Event{Kind: "message.upserted", Payload: map[string]string{"chat_jid": "a@s", "msg_id": "m1", "is_new": "true"}},
+			want:    &wppv1.MessageUpserted{ChatJid: "a@s", MsgId: "m1", IsNew: true},
+			chatJID: "a@s",
+		},
+		{
+			evt:         bus.Event{Kind: "message.send_ack", Payload: map[string]string{"client_msg_id": "c1", "chat_jid": "a@s", "server_msg_id": "S1"}},
+			want:        &wppv1.MessageSendAck{ClientMsgId: "c1", ServerMsgId: "S1", ChatJid: "a@s"},
+			chatJID:     "a@s",
+			correlation: "c1",
+		},
+		{
+			evt:         bus.Event{Kind: "message.send_failed", Payload: map[string]string{"client_msg_id": "c2", "chat_jid": "a@s", "reason": "offline", "attempts": "2", "next_attempt_at": "1700000004000"}},
+			want:        &wppv1.MessageSendFailed{ClientMsgId: "c2", Reason: "offline", ChatJid: "a@s", Attempts: 2, NextAttemptUnixMs: 1700000004000},
+			chatJID:     "a@s",
+			correlation: "c2",
+		},
+		{
+			evt:     bus.Event{Kind: "chat.updated", Payload: map[string]string{"chat_jid": "g@g.us"}},
+			want:    &wppv1.ChatUpdated{ChatJid: "g@g.us"},
+			chatJID: "g@g.us",
+		},
+		{
+			evt:     bus.Event{Kind: "chat.cleared", Payload: map[string]string{"chat_jid": "a@s", "deleted": "true"}},
+			want:    &wppv1.ChatCleared{ChatJid: "a@s", Deleted: true},
+			chatJID: "a@s",
+		},
+		{
+			evt:     bus.Event{Kind: "presence.chat_state", Payload: map[string]string{"chat_jid": "g@g.us", "sender_jid": "a@s", "state": "composing"}},
+			want:    &wppv1.PresenceChatState{ChatJid: "g@g.us", SenderJid: "a@s", State: "composing"},
+			chatJID: "g@g.us",
+		},
+		{
+			evt:     bus.Event{Kind: "presence.updated", Payload: map[string]string{"chat_jid": "a@s", "online": "false", "last_seen_at": "1700000000000"}},
+			want:    &wppv1.PresenceUpdated{Jid: "a@s", LastSeenUnixMs: 1700000000000},
+			chatJID: "a@s",
+		},
+		{
+			evt: bus.Event{Kind: "sync.history_batch", Payload: map[string]string{
+				"messages_count": "40", "chats_count": "3", "sync_type": "recent", "chunk_order": "2",
+				"progress": "60", "total_messages": "90", "total_chats": "7",
+			}},
+			want: &wppv1.SyncHistoryBatch{
+				MessagesCount: 40, ChatsCount: 3, SyncType: "recent", ChunkOrder: 2,
+				Progress: 60, TotalMessages: 90, TotalChats: 7,
+			},
+		},
+		{
+			evt: bus.Event{Kind: "sync.history_batch", Payload: map[string]string{
+				"messages_count": "50", "chats_count": "1", "sync_type": "on_demand",
+				"chat_jid": "a@s", "request_id": "REQ1",
+			}},
+			want: &wppv1.SyncHistoryBatch{
+				MessagesCount: 50, ChatsCount: 1, SyncType: "on_demand", ChatJid: "a@s", RequestId: "REQ1",
+			},
+			chatJID:     "a@s",
+			correlation: "REQ1",
+		},
+		{
+			evt:  bus.Event{Kind: "sync.reconnecting", Payload: map[string]string{"attempt": "2"}},
+			want: &wppv1.SyncReconnecting{Attempt: 2},
+		},
+	}
+	for _, tt := range tests {
+		tt.evt.Timestamp = time.UnixMilli(1234)
+		e := encode(tt.evt)
+		if e.ChatJID != tt.chatJID || e.CorrelationID != tt.correlation || e.OccurredAt != 1234 {
+			t.Errorf("%s: chat %q correlation %q at %d", tt.evt.Kind, e.ChatJID, e.CorrelationID, e.OccurredAt)
+		}
+		got := tt.want.ProtoReflect().New().Interface()
+		if err := proto.Unmarshal(e.Payload, got); err != nil {
+			t.Fatalf("%s: %v", tt.evt.Kind, err)
+		}
+		if !proto.Equal(got, tt.want) {
+			t.Errorf("%s: payload = %v, want %v", tt.evt.Kind, got, tt.want)
+		}
+	}
+
+	if e := encode(bus.Event{Kind: "message.other"}); e.Payload != nil {
+		t.Errorf("unknown kind payload = %v, want none", e.Payload)
+	}
+}
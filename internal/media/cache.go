@@ -0,0 +1,168 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+
+	"github.com/matheus3301/wpp/internal/store"
+)
+
+// ErrNoMedia is returned when a message has no stored attachment.
+var ErrNoMedia = errors.New("message has no media")
+
+// Downloader fetches and decrypts the file of a media attachment.
+type Downloader interface {
+	Download(ctx context.Context, m *store.Media) ([]byte, error)
+}
+
+// Cache keeps downloaded media files in a directory, named by the SHA-256
+// of their content so a file shared across chats is stored once.
+type Cache struct {
+	dir        string
+	db         *store.DB
+	downloader Downloader
+}
+
+// NewCache creates a media cache rooted at dir.
+func NewCache(dir string, db *store.DB, downloader Downloader) *Cache {
+	return &Cache{dir: dir, db: db, downloader: downloader}
+}
+
+// Fetch returns the attachment of a message with LocalPath pointing at the
+// cached file, downloading it first if it is not cached yet.
+func (c *Cache) Fetch(ctx context.Context, chatJID, msgID string) (*store.Media, error) {
+	m, err := c.db.GetMedia(chatJID, msgID)
+	if err != nil {
+		return nil, fmt.Errorf("get media: %w", err)
+	}
+	if m == nil {
+		return nil, ErrNoMedia
+	}
+	if m.LocalPath != "" {
+		if _, err := os.Stat(m.LocalPath); err == nil {
+			return m, nil
+		}
+	}
+
+	data, err := c.downloader.Download(ctx, m)
+	if err != nil {
+		return nil, fmt.Errorf("download: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	if len(m.FileSHA256) > 0 && !bytes.Equal(sum[:], m.FileSHA256) {
+		return nil, fmt.Errorf("download: file hash mismatch")
+	}
+
+	path, err := c.write(hex.EncodeToString(sum[:]), extension(m), data)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.db.SetMediaLocalPath(chatJID, msgID, path); err != nil {
+		return nil, fmt.Errorf("set local path: %w", err)
+	}
+	m.LocalPath = path
+	return m, nil
+}
+
+// Add stores the file of an attachment being sent, read from r, and
+// returns its path. The file is streamed, never held in memory. Keeping it
+// in the cache lets the send be retried after the original is gone, and
+// serves it later like a downloaded file.
+func (c *Cache) Add(m *store.Media, r io.Reader) (string, error) {
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return "", fmt.Errorf("create cache dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(c.dir, "add.*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("create temp file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, h), r); err != nil {
+		_ = tmp.Close()
+		return "", fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("close temp file: %w", err)
+	}
+	return c.place(tmp.Name(), hex.EncodeToString(h.Sum(nil)), extension(m))
+}
+
+// write stores data at <dir>/<hash[:2]>/<hash><ext> unless it is already
+// there. The file is written to a temporary name first so a partial
+// download is never visible.
+func (c *Cache) write(hash, ext string, data []byte) (string, error) {
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return "", fmt.Errorf("create cache dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(c.dir, hash+".*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("create temp file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return "", fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("close temp file: %w", err)
+	}
+	return c.place(tmp.Name(), hash, ext)
+}
+
+// place moves a complete temporary file to <dir>/<hash[:2]>/<hash><ext>,
+// unless a file with that content is already there.
+func (c *Cache) place(tmp, hash, ext string) (string, error) {
+	dir := filepath.Join(c.dir, hash[:2])
+	path := filepath.Join(dir, hash+ext)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("create cache dir: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return "", fmt.Errorf("rename temp file: %w", err)
+	}
+	return path, nil
+}
+
+// commonExtensions covers the mimetypes WhatsApp uses most, where the
+// system mime table is missing or picks an unusual extension.
+var commonExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+	"video/mp4":       ".mp4",
+	"audio/ogg":       ".ogg",
+	"audio/mpeg":      ".mp3",
+	"audio/mp4":       ".m4a",
+	"application/pdf": ".pdf",
+}
+
+// extension picks a file extension for a cached attachment: the document's
+// own one, else one derived from the mimetype.
+func extension(m *store.Media) string {
+	if ext := filepath.Ext(m.FileName); ext != "" {
+		return ext
+	}
+	mt, _, err := mime.ParseMediaType(m.Mimetype)
+	if err != nil {
+		return ""
+	}
+	if ext, ok := commonExtensions[mt]; ok {
+		return ext
+	}
+	if exts, _ := mime.ExtensionsByType(mt); len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
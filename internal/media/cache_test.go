@@ -0,0 +1,136 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matheus3301/wpp/internal/store"
+)
+
+// fakeDownloader serves fixed content and counts calls.
+type fakeDownloader struct {
+	data  []byte
+	err   error
+	calls int
+}
+
+func (f *fakeDownloader) Download(_ context.Context, _ *store.Media) ([]byte, error) {
+	f.calls++
+	return f.data, f.err
+}
+
+func testDB(t *testing.T) *store.DB {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.db")
+	db, err := store.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Migrate(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestCacheFetch(t *testing.T) {
+	db := testDB(t)
+	data := []byte("%PDF-1.7 fake")
+	sum := sha256.Sum256(data)
+
+	for _, msgID := range []string{"m1", "m2"} {
+		if err := db.UpsertMedia(&store.Media{
+			ChatJID: "chat@s", MsgID: msgID, MediaType: "document",
+			Mimetype: "application/pdf", FileName: "report.pdf", FileSHA256: sum[:],
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dl := &fakeDownloader{data: data}
+	c := NewCache(t.TempDir(), db, dl)
+
+	m, err := c.Fetch(context.Background(), "chat@s", "m1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(m.LocalPath, fmt.Sprintf("%x.pdf", sum)) {
+		t.Errorf("path = %q, want content-addressed .pdf", m.LocalPath)
+	}
+	got, err := os.ReadFile(m.LocalPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(data) {
+		t.Errorf("cached content = %q", got)
+	}
+
+	// Cached: no second download.
+	if _, err := c.Fetch(context.Background(), "chat@s", "m1"); err != nil {
+		t.Fatal(err)
+	}
+	if dl.calls != 1 {
+		t.Errorf("downloads = %d, want 1 (served from cache)", dl.calls)
+	}
+
+	// Same content from another message shares the file.
+	m2, err := c.Fetch(context.Background(), "chat@s", "m2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m2.LocalPath != m.LocalPath {
+		t.Errorf("m2 path = %q, want shared %q", m2.LocalPath, m.LocalPath)
+	}
+}
+
+func TestCacheFetchErrors(t *testing.T) {
+	db := testDB(t)
+	c := NewCache(t.TempDir(), db, &fakeDownloader{data: []byte("tampered")})
+
+	if _, err := c.Fetch(context.Background(), "chat@s", "none"); err != ErrNoMedia {
+		t.Errorf("err = %v, want ErrNoMedia", err)
+	}
+
+	sum := sha256.Sum256([]byte("original"))
+	if err := db.UpsertMedia(&store.Media{ChatJID: "chat@s", MsgID: "m1", MediaType: "image", Mimetype: "image/jpeg", FileSHA256: sum[:]}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Fetch(context.Background(), "chat@s", "m1"); err == nil || !strings.Contains(err.Error(), "hash mismatch") {
+		t.Errorf("err = %v, want hash mismatch", err)
+	}
+	m, err := db.GetMedia("chat@s", "m1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.LocalPath != "" {
+		t.Errorf("local path = %q after failed download, want empty", m.LocalPath)
+	}
+}
+
+func TestCacheAdd(t *testing.T) {
+	db := testDB(t)
+	c := NewCache(t.TempDir(), db, &fakeDownloader{})
+	data := []byte("voice note")
+
+	m := &store.Media{MediaType: "audio", Mimetype: "audio/ogg; codecs=opus"}
+	path, err := c.Add(m, bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Ext(path) != ".ogg" {
+		t.Errorf("path = %q, want .ogg extension", path)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil || string(got) != string(data) {
+		t.Errorf("cached file = %q, %v, want %q", got, err, data)
+	}
+	if again, err := c.Add(m, bytes.NewReader(data)); err != nil || again != path {
+		t.Errorf("second Add = %q, %v, want shared %q", again, err, path)
+	}
+}
@@ -0,0 +1,102 @@
+package media
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// MediaTypes are the attachment kinds that can be sent. A voice note is
+// sent as audio played inline.
+var MediaTypes = []string{"image", "video", "audio", "voice", "document", "sticker"}
+
+// voiceMimetype is the only format WhatsApp plays as a voice note.
+const voiceMimetype = "audio/ogg; codecs=opus"
+
+// maxSizes are WhatsApp's size limits of a file sent as each media type,
+// in bytes.
+var maxSizes = map[string]int64{
+	"image":    16 << 20,
+	"video":    16 << 20,
+	"audio":    16 << 20,
+	"voice":    16 << 20,
+	"sticker":  500 << 10,
+	"document": 2 << 30,
+}
+
+// Detect returns the mimetype of a file to send, from its extension or
+// else its content, and the media type WhatsApp shows it as. Formats
+// WhatsApp does not play inline are sent as documents.
+func Detect(name string, data []byte) (mimetype, mediaType string) {
+	ext := strings.ToLower(filepath.Ext(name))
+	for mt, e := range commonExtensions {
+		if e == ext {
+			mimetype = mt
+			break
+		}
+	}
+	if mimetype == "" && ext != "" {
+		mimetype = mime.TypeByExtension(ext)
+	}
+	if mimetype == "" {
+		mimetype = http.DetectContentType(data)
+	}
+	if ext == ".opus" || (ext == ".ogg" && strings.HasPrefix(mimetype, "audio/")) {
+		mimetype = voiceMimetype
+	}
+
+	base, _, _ := mime.ParseMediaType(mimetype)
+	switch base {
+	case "image/jpeg", "image/png":
+		mediaType = "image"
+	case "image/webp":
+		mediaType = "sticker"
+	case "video/mp4", "video/3gpp":
+		mediaType = "video"
+	case "audio/ogg", "audio/mpeg", "audio/mp4", "audio/aac", "audio/amr":
+		mediaType = "audio"
+	default:
+		mediaType = "document"
+	}
+	return mimetype, mediaType
+}
+
+// CheckMediaType reports whether a file of mimetype can be sent as
+// mediaType. Anything can be sent as a document; images must be JPEG or
+// PNG, the formats Thumbnail decodes.
+func CheckMediaType(mediaType, mimetype string) error {
+	base, _, _ := mime.ParseMediaType(mimetype)
+	ok := false
+	switch mediaType {
+	case "document":
+		ok = true
+	case "image":
+		ok = base == "image/jpeg" || base == "image/png"
+	case "sticker", "video", "audio":
+		ok = strings.HasPrefix(base, mediaType+"/") ||
+			(mediaType == "sticker" && base == "image/webp")
+	case "voice":
+		ok = base == "audio/ogg"
+	default:
+		return fmt.Errorf("unknown media type %q, want one of %s", mediaType, strings.Join(MediaTypes, ", "))
+	}
+	if !ok {
+		return fmt.Errorf("cannot send %s as %s", mimetype, mediaType)
+	}
+	return nil
+}
+
+// CheckSize reports whether a file of size bytes is within WhatsApp's
+// limit for mediaType.
+func CheckSize(mediaType string, size int64) error {
+	limit, ok := maxSizes[mediaType]
+	if !ok {
+		return fmt.Errorf("unknown media type %q, want one of %s", mediaType, strings.Join(MediaTypes, ", "))
+	}
+	if size > limit {
+		return fmt.Errorf("file of %d bytes is over the %d byte limit of %s", size, limit, mediaType)
+	}
+	return nil
+}
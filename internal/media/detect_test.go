@@ -0,0 +1,70 @@
+package media
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		wantMimetype  string
+		wantMediaType string
+	}{
+		{"photo.JPG", "", "image/jpeg", "image"},
+		{"shot.png", "", "image/png", "image"},
+		{"cat.webp", "", "image/webp", "sticker"},
+		{"clip.mp4", "", "video/mp4", "video"},
+		{"song.mp3", "", "audio/mpeg", "audio"},
+		{"memo.ogg", "", "audio/ogg; codecs=opus", "audio"},
+		{"memo.opus", "OggS", "audio/ogg; codecs=opus", "audio"},
+		{"report.pdf", "", "application/pdf", "document"},
+		{"anim.gif", "", "image/gif", "document"},
+		{"noext", "%PDF-1.7", "application/pdf", "document"},
+		{"noext", "\x89PNG\r\n\x1a\n", "image/png", "image"},
+	}
+	for _, tt := range tests {
+		mimetype, mediaType := Detect(tt.name, []byte(tt.data))
+		if mimetype != tt.wantMimetype || mediaType != tt.wantMediaType {
+			t.Errorf("Detect(%q) = %q, %q, want %q, %q", tt.name, mimetype, mediaType, tt.wantMimetype, tt.wantMediaType)
+		}
+	}
+}
+
+func TestCheckMediaType(t *testing.T) {
+	tests := []struct {
+		mediaType, mimetype string
+		ok                  bool
+	}{
+		{"document", "image/png", true},
+		{"image", "image/png", true},
+		{"image", "image/webp", false},
+		{"sticker", "image/webp", true},
+		{"voice", "audio/ogg; codecs=opus", true},
+		{"voice", "audio/mpeg", false},
+		{"video", "image/png", false},
+		{"gif", "image/gif", false},
+	}
+	for _, tt := range tests {
+		if err := CheckMediaType(tt.mediaType, tt.mimetype); (err == nil) != tt.ok {
+			t.Errorf("CheckMediaType(%q, %q) = %v, want ok %v", tt.mediaType, tt.mimetype, err, tt.ok)
+		}
+	}
+}
+
+func TestCheckSize(t *testing.T) {
+	tests := []struct {
+		mediaType string
+		size      int64
+		ok        bool
+	}{
+		{"image", 16 << 20, true},
+		{"image", 16<<20 + 1, false},
+		{"sticker", 600 << 10, false},
+		{"document", 100 << 20, true},
+		{"gif", 1, false},
+	}
+	for _, tt := range tests {
+		if err := CheckSize(tt.mediaType, tt.size); (err == nil) != tt.ok {
+			t.Errorf("CheckSize(%q, %d) = %v, want ok %v", tt.mediaType, tt.size, err, tt.ok)
+		}
+	}
+}
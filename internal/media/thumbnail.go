@@ -0,0 +1,70 @@
+package media
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png" // register the PNG decoder
+)
+
+// thumbnailSize bounds the longer side of a thumbnail, in pixels.
+const thumbnailSize = 100
+
+// maxImagePixels bounds the images Thumbnail decodes: a small file can
+// declare dimensions that take gigabytes to decode.
+const maxImagePixels = 50_000_000
+
+// Thumbnail returns a small JPEG preview of an image, shown by WhatsApp
+// while the image downloads. Images over maxImagePixels are refused.
+func Thumbnail(data []byte) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > maxImagePixels {
+		return nil, fmt.Errorf("image of %dx%d pixels is too large", cfg.Width, cfg.Height)
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w == 0 || h == 0 {
+		return nil, fmt.Errorf("decode image: empty image")
+	}
+	if w > thumbnailSize || h > thumbnailSize {
+		if w >= h {
+			w, h = thumbnailSize, max(1, h*thumbnailSize/w)
+		} else {
+			w, h = max(1, w*thumbnailSize/h), thumbnailSize
+		}
+	}
+
+	// Each thumbnail pixel averages a grid of samples from its area of the
+	// source; a full box filter would touch every pixel of a large photo.
+	const samples = 4
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			var r, g, bl, n uint32
+			for sy := range samples {
+				for sx := range samples {
+					px := b.Min.X + (x*samples+sx)*b.Dx()/(w*samples)
+					py := b.Min.Y + (y*samples+sy)*b.Dy()/(h*samples)
+					cr, cg, cb, _ := src.At(px, py).RGBA()
+					r, g, bl, n = r+cr, g+cg, bl+cb, n+1
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: 0xffff})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 60}); err != nil {
+		return nil, fmt.Errorf("encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,66 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestThumbnail(t *testing.T) {
+	// A wide image, red on the left and blue on the right.
+	src := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	for y := range 200 {
+		for x := range 400 {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= 200 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			src.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatal(err)
+	}
+
+	thumb, err := Thumbnail(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := jpeg.Decode(bytes.NewReader(thumb))
+	if err != nil {
+		t.Fatalf("thumbnail is not a JPEG: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 100 || b.Dy() != 50 {
+		t.Errorf("thumbnail size = %dx%d, want 100x50", b.Dx(), b.Dy())
+	}
+	if r, _, b, _ := img.At(10, 25).RGBA(); r < 0xc000 || b > 0x4000 {
+		t.Errorf("left of thumbnail = %x/%x, want red", r, b)
+	}
+	if r, _, b, _ := img.At(90, 25).RGBA(); b < 0xc000 || r > 0x4000 {
+		t.Errorf("right of thumbnail = %x/%x, want blue", r, b)
+	}
+
+	if _, err := Thumbnail([]byte("not an image")); err == nil {
+		t.Error("Thumbnail of garbage succeeded, want error")
+	}
+
+	// A tiny file declaring a huge image is refused before decoding.
+	buf.Reset()
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+	huge := buf.Bytes()
+	binary.BigEndian.PutUint32(huge[16:], 20000) // IHDR width
+	binary.BigEndian.PutUint32(huge[20:], 20000) // IHDR height
+	binary.BigEndian.PutUint32(huge[29:], crc32.ChecksumIEEE(huge[12:29]))
+	if _, err := Thumbnail(huge); err == nil || !strings.Contains(err.Error(), "too large") {
+		t.Errorf("Thumbnail of 20000x20000 image = %v, want too large", err)
+	}
+}
@@ -1,143 +1,586 @@
 package outbox
 
 import (
+	"cmp"
 	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/matheus3301/wpp/internal/bus"
+	"github.com/matheus3301/wpp/internal/config"
 	"github.com/matheus3301/wpp/internal/status"
 	"github.com/matheus3301/wpp/internal/store"
+	"github.com/matheus3301/wpp/internal/wa"
 	"go.uber.org/zap"
 )
 
+const (
+	// DefaultMaxAttempts is how many times a message is tried when not configured.
+	DefaultMaxAttempts = 5
+	// DefaultInitialBackoff is the delay before the first retry when not configured.
+	DefaultInitialBackoff = 2 * time.Second
+	// DefaultMaxBackoff caps the retry delay when not configured.
+	DefaultMaxBackoff = 5 * time.Minute
+	// DefaultWorkers is how many chats are sent to at once when not configured.
+	DefaultWorkers = 4
+
+	// drainTimeout bounds how long Stop waits for in-flight sends.
+	drainTimeout = 10 * time.Second
+)
+
 // TextSender is the interface for sending text messages via WhatsApp.
+// msgID is the message ID assigned when the message was queued, so that a
+// resend after a crash is the same message to WhatsApp; empty lets WhatsApp
+// assign one. A non-nil replyTo quotes that message.
 type TextSender interface {
-	SendText(ctx context.Context, jid string, text string) (serverMsgID string, err error)
+	SendText(ctx context.Context, jid, msgID, text string, replyTo *store.QuotedMessage) (serverMsgID string, err error)
+}
+
+// ReactionSender is the interface for sending reactions via WhatsApp.
+// msgID is as for TextSender. An empty emoji removes our reaction to the
+// target message.
+type ReactionSender interface {
+	SendReaction(ctx context.Context, target store.MessageKey, msgID, emoji string) (serverMsgID string, err error)
+}
+
+// MediaSender is the interface for sending attachments via WhatsApp. It
+// uploads the cached file at m.LocalPath and fills in m's key material
+// from the upload. msgID and replyTo are as for TextSender.
+type MediaSender interface {
+	SendMedia(ctx context.Context, jid, msgID string, m *store.Media, replyTo *store.QuotedMessage) (serverMsgID string, err error)
+}
+
+// ChangeSender is the interface for editing and revoking our own messages
+// via WhatsApp. msgID is as for TextSender and names the edit or revoke
+// itself, not the target.
+type ChangeSender interface {
+	SendEdit(ctx context.Context, target store.MessageKey, msgID, text string) (serverMsgID string, err error)
+	SendRevoke(ctx context.Context, target store.MessageKey, msgID string) (serverMsgID string, err error)
+}
+
+// MessageSender sends every kind of outbox entry.
+type MessageSender interface {
+	TextSender
+	MediaSender
+	ReactionSender
+	ChangeSender
 }
 
 // Sender drains the outbox and sends messages via the WhatsApp adapter.
+//
+// A dispatcher wakes when entries are queued, when a retry becomes due and
+// when the session becomes ready, and hands each chat's due entries to a
+// pool of workers as one batch. A chat has at most one batch in flight, so
+// its messages go out in order while a slow chat holds up only its worker.
+//
+// A failed send is queued again after an exponential backoff with jitter;
+// after the last attempt the entry is dead until retried by the user.
 type Sender struct {
-	db      *store.DB
-	sender  TextSender
-	bus     *bus.Bus
-	machine *status.Machine
-	logger  *zap.Logger
-	cancel  context.CancelFunc
+	db             *store.DB
+	sender         MessageSender
+	bus            *bus.Bus
+	machine        *status.Machine
+	logger         *zap.Logger
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	workers        int
+
+	jobs   chan []store.OutboxEntry // one chat's due entries, in order
+	queued chan struct{}            // signalled by Wake
+	idle   chan struct{}            // signalled when a worker finishes a batch
+
+	mu   sync.Mutex
+	busy map[string]bool // chats with a batch in flight
+
+	cancel     context.CancelFunc // stops dispatching
+	sendCancel context.CancelFunc // aborts in-flight sends
+	done       chan struct{}      // closed once the dispatcher and workers exit
 }
 
-// NewSender creates a new outbox sender.
-func NewSender(db *store.DB, sender TextSender, b *bus.Bus, machine *status.Machine, logger *zap.Logger) *Sender {
-	return &Sender{
-		db:      db,
-		sender:  sender,
-		bus:     b,
-		machine: machine,
-		logger:  logger,
+// NewSender creates a new outbox sender with the retry policy in cfg.
+func NewSender(db *store.DB, sender MessageSender, b *bus.Bus, machine *status.Machine, cfg config.OutboxConfig, logger *zap.Logger) *Sender {
+	s := &Sender{
+		db:             db,
+		sender:         sender,
+		bus:            b,
+		machine:        machine,
+		logger:         logger,
+		maxAttempts:    cfg.MaxAttempts,
+		initialBackoff: cfg.InitialBackoff,
+		maxBackoff:     cfg.MaxBackoff,
+		workers:        cfg.Workers,
+		queued:         make(chan struct{}, 1),
+		idle:           make(chan struct{}, 1),
+		busy:           make(map[string]bool),
 	}
+	if s.workers <= 0 {
+		s.workers = DefaultWorkers
+	}
+	if s.maxAttempts <= 0 {
+		s.maxAttempts = DefaultMaxAttempts
+	}
+	if s.initialBackoff <= 0 {
+		s.initialBackoff = DefaultInitialBackoff
+	}
+	if s.maxBackoff <= 0 {
+		s.maxBackoff = DefaultMaxBackoff
+	}
+	if s.maxBackoff < s.initialBackoff {
+		s.maxBackoff = s.initialBackoff
+	}
+	return s
 }
 
-// Start begins polling the outbox for pending messages.
+// Start starts the dispatcher and the workers.
 func (s *Sender) Start(ctx context.Context) {
-	ctx, s.cancel = context.WithCancel(ctx)
-	go s.loop(ctx)
+	var dispatchCtx, sendCtx context.Context
+	dispatchCtx, s.cancel = context.WithCancel(ctx)
+	// Sends outlive Stop until drained, so they do not inherit its cancel.
+	sendCtx, s.sendCancel = context.WithCancel(context.WithoutCancel(ctx))
+	s.jobs = make(chan []store.OutboxEntry)
+	s.done = make(chan struct{})
+
+	var wg sync.WaitGroup
+	for range s.workers {
+		wg.Go(func() { s.work(dispatchCtx, sendCtx) })
+	}
+	go func() {
+		s.dispatch(dispatchCtx)
+		close(s.jobs)
+		wg.Wait()
+		close(s.done)
+	}()
 }
 
-// Stop stops the sender loop.
+// Stop stops dispatching and waits for in-flight sends to finish, up to a
+// timeout after which they are aborted. Entries not yet picked up stay
+// queued for the next start.
 func (s *Sender) Stop() {
-	if s.cancel != nil {
-		s.cancel()
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	select {
+	case <-s.done:
+	case <-time.After(drainTimeout):
+		s.logger.Warn("outbox drain timed out, aborting in-flight sends")
+		s.sendCancel()
+		<-s.done
 	}
+	s.sendCancel()
 }
 
-func (s *Sender) loop(ctx context.Context) {
-	ticker := time.NewTicker(500 * time.Millisecond)
-	defer ticker.Stop()
+// Wake tells the dispatcher that entries were queued or made due, so it
+// need not poll. Wakes are coalesced: one may stand for many entries.
+func (s *Sender) Wake() {
+	select {
+	case s.queued <- struct{}{}:
+	default:
+	}
+}
+
+// dispatch hands due entries to the workers whenever there may be new
+// work, until ctx is done.
+func (s *Sender) dispatch(ctx context.Context) {
+	statusCh, unsub := s.bus.Subscribe("session.status_changed", 4, bus.Lossless(), bus.Named("outbox"))
+	defer unsub()
 
 	for {
+		var timer *time.Timer
+		var retry <-chan time.Time
+		if next := s.dispatchPending(ctx); next > 0 {
+			timer = time.NewTimer(time.Until(time.UnixMilli(next)))
+			retry = timer.C
+		}
+
 		select {
-		case <-ticker.C:
-			s.processPending(ctx)
+		case <-s.queued:
+		case <-s.idle:
+		case <-statusCh:
+		case <-retry:
 		case <-ctx.Done():
 			return
 		}
+		if timer != nil {
+			timer.Stop()
+		}
 	}
 }
 
-func (s *Sender) processPending(ctx context.Context) {
+// dispatchPending sends a batch to the workers for every chat with due
+// entries and no batch in flight. It returns when the next retry is due,
+// in Unix ms, or 0 if no retry is waiting.
+func (s *Sender) dispatchPending(ctx context.Context) int64 {
 	// Skip sending when not connected (2.3).
-	if s.machine != nil {
-		current := s.machine.Current()
-		if current != status.Ready {
-			return
-		}
+	if !s.ready() {
+		return 0
 	}
 
+	now := time.Now().UnixMilli()
 	pending, err := s.db.PendingOutbox()
 	if err != nil {
 		s.logger.Error("failed to read outbox", zap.Error(err))
-		return
+		return time.Now().Add(time.Second).UnixMilli()
 	}
 
+	var chats []string
+	batches := make(map[string][]store.OutboxEntry)
+	s.mu.Lock()
 	for _, entry := range pending {
-		if err := s.db.MarkOutboxSending(entry.ClientMsgID); err != nil {
-			s.logger.Error("failed to mark sending", zap.Error(err), zap.String("client_msg_id", entry.ClientMsgID))
+		if s.busy[entry.ChatJID] {
 			continue
 		}
+		if batches[entry.ChatJID] == nil {
+			chats = append(chats, entry.ChatJID)
+		}
+		batches[entry.ChatJID] = append(batches[entry.ChatJID], entry)
+	}
+	for _, chat := range chats {
+		s.busy[chat] = true
+	}
+	s.mu.Unlock()
+
+	for i, chat := range chats {
+		select {
+		case s.jobs <- batches[chat]:
+		case <-ctx.Done():
+			s.mu.Lock()
+			for _, chat := range chats[i:] {
+				delete(s.busy, chat)
+			}
+			s.mu.Unlock()
+			return 0
+		}
+	}
 
-		// Update the existing message row to 'sending' status.
-		_ = s.db.UpsertMessage(&store.Message{
-			ChatJID:     entry.ChatJID,
-			MsgID:       entry.ClientMsgID,
-			Body:        entry.Body,
-			MessageType: "text",
-			FromMe:      true,
-			Status:      "sending",
-			Timestamp:   time.Now().UnixMilli(),
-		})
-		s.bus.Publish(bus.Event{
-			Kind:      "message.upserted",
-			Timestamp: time.Now(),
-			Payload:   map[string]string{"chat_jid": entry.ChatJID, "msg_id": entry.ClientMsgID},
-		})
-
-		serverMsgID, err := s.sender.SendText(ctx, entry.ChatJID, entry.Body)
-		if err != nil {
-			s.logger.Error("failed to send message", zap.Error(err), zap.String("client_msg_id", entry.ClientMsgID))
-			_ = s.db.MarkOutboxFailed(entry.ClientMsgID, err.Error())
-			_ = s.db.UpsertMessage(&store.Message{
-				ChatJID: entry.ChatJID, MsgID: entry.ClientMsgID,
-				Body: entry.Body, MessageType: "text", FromMe: true,
-				Status: "failed", Timestamp: time.Now().UnixMilli(),
-			})
-			s.bus.Publish(bus.Event{
-				Kind:      "message.send_failed",
-				Timestamp: time.Now(),
-				Payload: map[string]string{
-					"client_msg_id": entry.ClientMsgID,
-					"error":         err.Error(),
-				},
-			})
-			continue
+	next, err := s.db.NextOutboxAttempt(now)
+	if err != nil {
+		s.logger.Error("failed to read outbox", zap.Error(err))
+		return time.Now().Add(time.Second).UnixMilli()
+	}
+	return next
+}
+
+// work sends batches until the jobs channel is closed. A batch stops at the
+// first failure, since the rest of the chat must wait for the retry, and
+// when the sender is stopping, leaving the rest queued.
+func (s *Sender) work(stopCtx, sendCtx context.Context) {
+	for batch := range s.jobs {
+		for _, entry := range batch {
+			if stopCtx.Err() != nil || !s.ready() {
+				break
+			}
+			if !s.send(sendCtx, entry) {
+				break
+			}
 		}
 
-		if err := s.db.MarkOutboxSent(entry.ClientMsgID, serverMsgID); err != nil {
-			s.logger.Error("failed to mark sent", zap.Error(err), zap.String("client_msg_id", entry.ClientMsgID))
+		s.mu.Lock()
+		delete(s.busy, batch[0].ChatJID)
+		s.mu.Unlock()
+		select {
+		case s.idle <- struct{}{}:
+		default:
 		}
+	}
+}
+
+func (s *Sender) ready() bool {
+	return s.machine == nil || s.machine.Current() == status.Ready
+}
+
+// send sends one outbox entry and reports whether it was sent.
+func (s *Sender) send(ctx context.Context, entry store.OutboxEntry) bool {
+	if err := s.db.MarkOutboxSending(entry.ClientMsgID); errors.Is(err, store.ErrOutboxState) {
+		// Cancelled, or seen sent, since it was read.
+		return true
+	} else if err != nil {
+		s.logger.Error("failed to mark sending", zap.Error(err), zap.String("client_msg_id", entry.ClientMsgID))
+		return false
+	}
+	switch entry.Kind {
+	case "reaction":
+		return s.sendReaction(ctx, entry)
+	case "edit", "revoke":
+		return s.sendChange(ctx, entry)
+	}
+	return s.sendMessage(ctx, entry)
+}
+
+// sendMessage sends a queued text or attachment, keeping its optimistic
+// message row in step.
+func (s *Sender) sendMessage(ctx context.Context, entry store.OutboxEntry) bool {
+	var m *store.Media
+	messageType := "text"
+	if entry.Kind == "media" {
+		var err error
+		if m, err = s.db.GetMedia(entry.ChatJID, entry.ClientMsgID); err != nil || m == nil {
+			return s.failMessage(entry, messageType, cmp.Or(err, errors.New("attachment is missing")))
+		}
+		messageType = m.MediaType
+	}
+
+	// Update the existing message row to 'sending' status.
+	_ = s.db.UpsertMessage(&store.Message{
+		ChatJID:     entry.ChatJID,
+		MsgID:       entry.ClientMsgID,
+		Body:        entry.Body,
+		MessageType: messageType,
+		FromMe:      true,
+		Status:      "sending",
+		Timestamp:   time.Now().UnixMilli(),
+		QuotedMsgID: entry.TargetMsgID,
+	})
+	s.bus.Publish(bus.Event{
+		Kind:      "message.upserted",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"chat_jid":      entry.ChatJID,
+			"msg_id":        entry.ClientMsgID,
+			"client_msg_id": entry.ClientMsgID,
+		},
+	})
+
+	var replyTo *store.QuotedMessage
+	if entry.TargetMsgID != "" {
+		// A reply whose quoted message is gone is sent as plain text.
+		var err error
+		if replyTo, err = s.db.GetQuotedMessage(entry.ChatJID, entry.TargetMsgID); err != nil {
+			s.logger.Warn("failed to load quoted message", zap.Error(err), zap.String("client_msg_id", entry.ClientMsgID))
+		}
+	}
+
+	var serverMsgID string
+	var err error
+	if m != nil {
+		serverMsgID, err = s.sender.SendMedia(ctx, entry.ChatJID, entry.ServerMsgID, m, replyTo)
+	} else {
+		serverMsgID, err = s.sender.SendText(ctx, entry.ChatJID, entry.ServerMsgID, entry.Body, replyTo)
+	}
+	if err != nil {
+		return s.failMessage(entry, messageType, err)
+	}
+	if m != nil {
+		// Keep the upload's key material so the file can be fetched again
+		// like a received attachment.
+		if err := s.db.UpsertMedia(m); err != nil {
+			s.logger.Warn("failed to store uploaded media", zap.Error(err), zap.String("client_msg_id", entry.ClientMsgID))
+		}
+	}
+
+	// The message row is re-keyed to serverMsgID; send_ack tells watchers.
+	if err := s.db.MarkOutboxSent(entry.ClientMsgID, serverMsgID); err != nil {
+		s.logger.Error("failed to mark sent", zap.Error(err), zap.String("client_msg_id", entry.ClientMsgID))
+	}
+
+	s.logger.Info("message sent", zap.String("client_msg_id", entry.ClientMsgID), zap.String("server_msg_id", serverMsgID))
+	s.bus.Publish(bus.Event{
+		Kind:      "message.send_ack",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"client_msg_id": entry.ClientMsgID,
+			"chat_jid":      entry.ChatJID,
+			"server_msg_id": serverMsgID,
+		},
+	})
+	return true
+}
+
+// failMessage records a failed send of a text or attachment. Returns false.
+func (s *Sender) failMessage(entry store.OutboxEntry, messageType string, err error) bool {
+	s.logger.Error("failed to send message", zap.Error(err), zap.String("client_msg_id", entry.ClientMsgID))
+	// The message shows as queued while a retry is pending.
+	msgStatus := "queued"
+	if !s.fail(entry, err) {
+		msgStatus = "failed"
+	}
+	_ = s.db.UpsertMessage(&store.Message{
+		ChatJID: entry.ChatJID, MsgID: entry.ClientMsgID,
+		Body: entry.Body, MessageType: messageType, FromMe: true,
+		Status: msgStatus, Timestamp: time.Now().UnixMilli(),
+	})
+	s.bus.Publish(bus.Event{
+		Kind:      "message.upserted",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"chat_jid":      entry.ChatJID,
+			"msg_id":        entry.ClientMsgID,
+			"client_msg_id": entry.ClientMsgID,
+		},
+	})
+	return false
+}
+
+// sendReaction sends a queued reaction and records it locally once
+// WhatsApp accepts it.
+func (s *Sender) sendReaction(ctx context.Context, entry store.OutboxEntry) bool {
+	target, err := s.db.GetMessageKey(entry.ChatJID, entry.TargetMsgID)
+	if err == nil && target == nil {
+		err = fmt.Errorf("target message %s not found", entry.TargetMsgID)
+	}
+	var serverMsgID string
+	if err == nil {
+		serverMsgID, err = s.sender.SendReaction(ctx, *target, entry.ServerMsgID, entry.Body)
+	}
+	if err != nil {
+		s.logger.Error("failed to send reaction", zap.Error(err), zap.String("client_msg_id", entry.ClientMsgID))
+		s.fail(entry, err)
+		return false
+	}
+
+	if err := s.db.MarkOutboxSent(entry.ClientMsgID, serverMsgID); err != nil {
+		s.logger.Error("failed to mark sent", zap.Error(err), zap.String("client_msg_id", entry.ClientMsgID))
+	}
+	if _, err := s.db.ApplyReaction(&store.Reaction{
+		ChatJID: entry.ChatJID, MsgID: entry.TargetMsgID,
+		Emoji: entry.Body, FromMe: true, Timestamp: time.Now().UnixMilli(),
+	}); err != nil {
+		s.logger.Error("failed to record reaction", zap.Error(err), zap.String("client_msg_id", entry.ClientMsgID))
+	}
+
+	s.logger.Info("reaction sent", zap.String("client_msg_id", entry.ClientMsgID), zap.String("server_msg_id", serverMsgID))
+	s.bus.Publish(bus.Event{
+		Kind:      "message.upserted",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"chat_jid":      entry.ChatJID,
+			"msg_id":        entry.TargetMsgID,
+			"client_msg_id": entry.ClientMsgID,
+		},
+	})
+	s.bus.Publish(bus.Event{
+		Kind:      "message.send_ack",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"client_msg_id": entry.ClientMsgID,
+			"chat_jid":      entry.ChatJID,
+			"server_msg_id": serverMsgID,
+		},
+	})
+	return true
+}
+
+// sendChange sends a queued edit or revoke of one of our messages and
+// applies it to the message once WhatsApp accepts it.
+func (s *Sender) sendChange(ctx context.Context, entry store.OutboxEntry) bool {
+	target, err := s.db.GetMessageKey(entry.ChatJID, entry.TargetMsgID)
+	if err == nil && target == nil {
+		err = fmt.Errorf("target message %s not found", entry.TargetMsgID)
+	}
+	if err == nil && entry.Kind == "edit" {
+		err = s.checkEditWindow(entry)
+	}
+	var serverMsgID string
+	if err == nil {
+		if entry.Kind == "edit" {
+			serverMsgID, err = s.sender.SendEdit(ctx, *target, entry.ServerMsgID, entry.Body)
+		} else {
+			serverMsgID, err = s.sender.SendRevoke(ctx, *target, entry.ServerMsgID)
+		}
+	}
+	if err != nil {
+		s.logger.Error("failed to send "+entry.Kind, zap.Error(err), zap.String("client_msg_id", entry.ClientMsgID))
+		s.fail(entry, err)
+		return false
+	}
+
+	if err := s.db.MarkOutboxSent(entry.ClientMsgID, serverMsgID); err != nil {
+		s.logger.Error("failed to mark sent", zap.Error(err), zap.String("client_msg_id", entry.ClientMsgID))
+	}
+	if _, err := s.db.ApplyMessageChange(&store.MessageChange{
+		ChatJID: entry.ChatJID, MsgID: entry.TargetMsgID,
+		Kind: entry.Kind, Body: entry.Body, FromMe: true, Timestamp: time.Now().UnixMilli(),
+	}); err != nil {
+		s.logger.Error("failed to record "+entry.Kind, zap.Error(err), zap.String("client_msg_id", entry.ClientMsgID))
+	}
+
+	s.logger.Info(entry.Kind+" sent", zap.String("client_msg_id", entry.ClientMsgID), zap.String("server_msg_id", serverMsgID))
+	s.bus.Publish(bus.Event{
+		Kind:      "message.upserted",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"chat_jid":      entry.ChatJID,
+			"msg_id":        entry.TargetMsgID,
+			"client_msg_id": entry.ClientMsgID,
+		},
+	})
+	s.bus.Publish(bus.Event{
+		Kind:      "message.send_ack",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"client_msg_id": entry.ClientMsgID,
+			"chat_jid":      entry.ChatJID,
+			"server_msg_id": serverMsgID,
+		},
+	})
+	return true
+}
+
+// checkEditWindow returns a finalError once the message an edit targets is
+// older than wa.EditWindow: WhatsApp would drop the edit, however often
+// it is retried.
+func (s *Sender) checkEditWindow(entry store.OutboxEntry) error {
+	m, err := s.db.GetMessage(entry.ChatJID, entry.TargetMsgID)
+	if err != nil || m == nil {
+		return err
+	}
+	if time.Since(time.UnixMilli(m.Timestamp)) > wa.EditWindow {
+		return finalError{fmt.Errorf("message %s is older than %v and can no longer be edited", entry.TargetMsgID, wa.EditWindow)}
+	}
+	return nil
+}
+
+// finalError is a send failure that retrying cannot fix: the entry is
+// marked dead at once.
+type finalError struct{ error }
+
+func (e finalError) Unwrap() error { return e.error }
+
+// fail records a failed attempt at entry: it is queued again after a
+// backoff, or dead once it has used all its attempts or the failure is
+// final. It publishes message.send_failed either way and reports whether
+// a retry is pending.
+func (s *Sender) fail(entry store.OutboxEntry, sendErr error) bool {
+	attempts := entry.Attempts + 1 // counted by MarkOutboxSending
+	var next int64
+	if attempts < s.maxAttempts && !errors.As(sendErr, new(finalError)) {
+		next = time.Now().Add(s.backoff(attempts)).UnixMilli()
+		if err := s.db.MarkOutboxRetry(entry.ClientMsgID, sendErr.Error(), next); err != nil {
+			s.logger.Error("failed to schedule retry", zap.Error(err), zap.String("client_msg_id", entry.ClientMsgID))
+		}
+	} else {
+		s.logger.Warn("giving up on message", zap.Int("attempts", attempts), zap.String("client_msg_id", entry.ClientMsgID))
+		if err := s.db.MarkOutboxDead(entry.ClientMsgID, sendErr.Error()); err != nil {
+			s.logger.Error("failed to mark dead", zap.Error(err), zap.String("client_msg_id", entry.ClientMsgID))
+		}
+	}
+
+	payload := map[string]string{
+		"client_msg_id": entry.ClientMsgID,
+		"chat_jid":      entry.ChatJID,
+		"reason":        sendErr.Error(),
+		"attempts":      strconv.Itoa(attempts),
+	}
+	if next > 0 {
+		payload["next_attempt_at"] = strconv.FormatInt(next, 10)
+	}
+	s.bus.Publish(bus.Event{Kind: "message.send_failed", Timestamp: time.Now(), Payload: payload})
+	return next > 0
+}
 
-		_ = s.db.UpsertMessage(&store.Message{
-			ChatJID: entry.ChatJID, MsgID: entry.ClientMsgID,
-			Body: entry.Body, MessageType: "text", FromMe: true,
-			Status: "sent", Timestamp: time.Now().UnixMilli(),
-		})
-
-		s.logger.Info("message sent", zap.String("client_msg_id", entry.ClientMsgID), zap.String("server_msg_id", serverMsgID))
-		s.bus.Publish(bus.Event{
-			Kind:      "message.send_ack",
-			Timestamp: time.Now(),
-			Payload: map[string]string{
-				"client_msg_id": entry.ClientMsgID,
-				"server_msg_id": serverMsgID,
-			},
-		})
+// backoff returns the delay after the given failed attempt: the initial
+// backoff doubled per earlier attempt, capped at the maximum, with the upper
+// half randomized so that messages failing together do not retry together.
+func (s *Sender) backoff(attempt int) time.Duration {
+	d := s.initialBackoff
+	for i := 1; i < attempt && d < s.maxBackoff; i++ {
+		d *= 2
 	}
+	d = min(d, s.maxBackoff)
+	return d/2 + rand.N(d/2+1)
 }
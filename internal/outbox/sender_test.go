@@ -1,41 +1,147 @@
 package outbox
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/matheus3301/wpp/internal/bus"
+	"github.com/matheus3301/wpp/internal/config"
 	"github.com/matheus3301/wpp/internal/store"
+	"github.com/matheus3301/wpp/internal/wa"
 	"go.uber.org/zap"
 )
 
 // mockSender records calls and returns configurable results.
 type mockSender struct {
-	calls []sendCall
-	err   error
-	delay time.Duration // artificial delay to observe intermediate states
+	mu       sync.Mutex
+	calls    []sendCall
+	err      error
+	failures int           // when set, only the first failures sends return err
+	delay    time.Duration // artificial delay to observe intermediate states
 }
 
 type sendCall struct {
-	JID  string
-	Text string
+	JID     string
+	MsgID   string
+	Text    string
+	ReplyTo string
 }
 
-func (m *mockSender) SendText(_ context.Context, jid string, text string) (string, error) {
-	m.calls = append(m.calls, sendCall{JID: jid, Text: text})
+func (m *mockSender) SendReaction(_ context.Context, target store.MessageKey, msgID, emoji string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, sendCall{JID: target.ChatJID, MsgID: msgID, Text: emoji})
+	if m.err != nil {
+		return "", m.err
+	}
+	return "reaction-" + target.ID, nil
+}
+
+func (m *mockSender) SendText(_ context.Context, jid, msgID, text string, replyTo *store.QuotedMessage) (string, error) {
+	call := sendCall{JID: jid, MsgID: msgID, Text: text}
+	if replyTo != nil {
+		call.ReplyTo = replyTo.Key.ID
+	}
+	m.mu.Lock()
+	m.calls = append(m.calls, call)
+	fail := m.err != nil && (m.failures == 0 || len(m.calls) <= m.failures)
+	m.mu.Unlock()
 	if m.delay > 0 {
 		time.Sleep(m.delay)
 	}
+	if fail {
+		return "", m.err
+	}
+	if msgID != "" {
+		return msgID, nil
+	}
+	return "server-" + text, nil
+}
+
+// SendMedia records the attachment's type as Text and fakes the upload.
+func (m *mockSender) SendMedia(_ context.Context, jid, msgID string, md *store.Media, replyTo *store.QuotedMessage) (string, error) {
+	call := sendCall{JID: jid, MsgID: msgID, Text: md.MediaType}
+	if replyTo != nil {
+		call.ReplyTo = replyTo.Key.ID
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, call)
+	if m.err != nil {
+		return "", m.err
+	}
+	md.DirectPath = "/v/" + md.MsgID
+	md.MediaKey = []byte("key")
+	return cmp.Or(msgID, "server-"+md.MsgID), nil
+}
+
+func (m *mockSender) SendEdit(_ context.Context, target store.MessageKey, msgID, text string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, sendCall{JID: target.ChatJID, MsgID: msgID, Text: "edit " + target.ID + ": " + text})
+	if m.err != nil {
+		return "", m.err
+	}
+	return "edit-" + target.ID, nil
+}
+
+func (m *mockSender) SendRevoke(_ context.Context, target store.MessageKey, msgID string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, sendCall{JID: target.ChatJID, MsgID: msgID, Text: "revoke " + target.ID})
 	if m.err != nil {
 		return "", m.err
 	}
-	return "server-" + jid, nil
+	return "revoke-" + target.ID, nil
+}
+
+// sent returns the calls made so far.
+func (m *mockSender) sent() []sendCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.calls)
+}
+
+// chatSender sends texts after a per-chat delay and reports each send.
+type chatSender struct {
+	delay  map[string]time.Duration
+	onSend func(jid, text string)
+}
+
+func (c *chatSender) SendReaction(context.Context, store.MessageKey, string, string) (string, error) {
+	return "", nil
+}
+
+func (c *chatSender) SendMedia(context.Context, string, string, *store.Media, *store.QuotedMessage) (string, error) {
+	return "", nil
+}
+
+func (c *chatSender) SendEdit(context.Context, store.MessageKey, string, string) (string, error) {
+	return "", nil
+}
+
+func (c *chatSender) SendRevoke(context.Context, store.MessageKey, string) (string, error) {
+	return "", nil
+}
+
+func (c *chatSender) SendText(ctx context.Context, jid, _, text string, _ *store.QuotedMessage) (string, error) {
+	select {
+	case <-time.After(c.delay[jid]):
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	c.onSend(jid, text)
+	return "server-" + text, nil
 }
 
-func testDB(t *testing.T) *store.DB {
+func testDB(t testing.TB) *store.DB {
 	t.Helper()
 	path := filepath.Join(t.TempDir(), "test.db")
 	db, err := store.Open(path)
@@ -54,7 +160,7 @@ func TestSenderProcessesPendingMessages(t *testing.T) {
 	b := bus.New()
 	mock := &mockSender{}
 	logger, _ := zap.NewDevelopment()
-	s := NewSender(db, mock, b, nil, logger)
+	s := NewSender(db, mock, b, nil, config.OutboxConfig{}, logger)
 
 	// Subscribe to ack events.
 	ch, unsub := b.Subscribe("message.send_ack", 10)
@@ -75,11 +181,12 @@ func TestSenderProcessesPendingMessages(t *testing.T) {
 	time.Sleep(time.Second)
 
 	// Verify the mock was called.
-	if len(mock.calls) != 1 {
-		t.Fatalf("got %d send calls, want 1", len(mock.calls))
+	calls := mock.sent()
+	if len(calls) != 1 {
+		t.Fatalf("got %d send calls, want 1", len(calls))
 	}
-	if mock.calls[0].JID != "chat@s" || mock.calls[0].Text != "hello" {
-		t.Errorf("call = %+v, want {chat@s, hello}", mock.calls[0])
+	if calls[0].JID != "chat@s" || calls[0].Text != "hello" {
+		t.Errorf("call = %+v, want {chat@s, hello}", calls[0])
 	}
 
 	// Verify outbox is drained (no more pending).
@@ -107,7 +214,7 @@ func TestSenderHandlesFailure(t *testing.T) {
 	b := bus.New()
 	mock := &mockSender{err: fmt.Errorf("network error")}
 	logger, _ := zap.NewDevelopment()
-	s := NewSender(db, mock, b, nil, logger)
+	s := NewSender(db, mock, b, nil, config.OutboxConfig{}, logger)
 
 	// Subscribe to failure events.
 	ch, unsub := b.Subscribe("message.send_failed", 10)
@@ -135,13 +242,20 @@ func TestSenderHandlesFailure(t *testing.T) {
 		t.Fatal("timeout waiting for send_failed event")
 	}
 
-	// Verify outbox entry is no longer pending (marked failed).
+	// Verify outbox entry is no longer pending (retry is backed off).
 	pending, err := db.PendingOutbox()
 	if err != nil {
 		t.Fatal(err)
 	}
 	if len(pending) != 0 {
-		t.Errorf("got %d pending, want 0 (should be marked failed)", len(pending))
+		t.Errorf("got %d pending, want 0 (should wait for its retry)", len(pending))
+	}
+	entry, err := db.GetOutbox("c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Status != "queued" || entry.Attempts != 1 || entry.NextAttempt <= time.Now().UnixMilli() {
+		t.Errorf("entry = %+v, want queued after 1 attempt with a future retry", entry)
 	}
 }
 
@@ -155,7 +269,7 @@ func TestSenderOptimisticInsert(t *testing.T) {
 	b := bus.New()
 	mock := &mockSender{delay: 500 * time.Millisecond}
 	logger, _ := zap.NewDevelopment()
-	s := NewSender(db, mock, b, nil, logger)
+	s := NewSender(db, mock, b, nil, config.OutboxConfig{}, logger)
 
 	if err := db.UpsertChat(&store.Chat{JID: "chat@s"}); err != nil {
 		t.Fatal(err)
@@ -179,7 +293,7 @@ func TestSenderOptimisticInsert(t *testing.T) {
 	}
 
 	// Message should exist with status "sending" while mock is still sleeping.
-	msgs, err := db.ListMessages("chat@s", 0, 10)
+	msgs, err := db.ListMessages("chat@s", store.Cursor{}, 10)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -200,7 +314,7 @@ func TestSenderOptimisticInsert(t *testing.T) {
 	time.Sleep(time.Second)
 
 	// Message should now have status "sent".
-	msgs, err = db.ListMessages("chat@s", 0, 10)
+	msgs, err = db.ListMessages("chat@s", store.Cursor{}, 10)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -212,14 +326,14 @@ func TestSenderOptimisticInsert(t *testing.T) {
 	}
 }
 
-// TestSenderOptimisticInsertOnFailure verifies that a failed send updates
-// the optimistic message to "failed" status.
+// TestSenderOptimisticInsertOnFailure verifies that a send that runs out of
+// attempts updates the optimistic message to "failed" status.
 func TestSenderOptimisticInsertOnFailure(t *testing.T) {
 	db := testDB(t)
 	b := bus.New()
 	mock := &mockSender{err: fmt.Errorf("timeout"), delay: 200 * time.Millisecond}
 	logger, _ := zap.NewDevelopment()
-	s := NewSender(db, mock, b, nil, logger)
+	s := NewSender(db, mock, b, nil, config.OutboxConfig{MaxAttempts: 1}, logger)
 
 	if err := db.UpsertChat(&store.Chat{JID: "chat@s"}); err != nil {
 		t.Fatal(err)
@@ -233,7 +347,7 @@ func TestSenderOptimisticInsertOnFailure(t *testing.T) {
 
 	time.Sleep(time.Second)
 
-	msgs, err := db.ListMessages("chat@s", 0, 10)
+	msgs, err := db.ListMessages("chat@s", store.Cursor{}, 10)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -244,3 +358,699 @@ func TestSenderOptimisticInsertOnFailure(t *testing.T) {
 		t.Errorf("status = %q, want 'failed'", msgs[0].Status)
 	}
 }
+
+// TestSenderUsesAssignedID verifies that a message is sent under the
+// WhatsApp ID assigned when it was queued and re-keyed to it once sent.
+func TestSenderUsesAssignedID(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	mock := &mockSender{}
+	logger, _ := zap.NewDevelopment()
+	s := NewSender(db, mock, b, nil, config.OutboxConfig{}, logger)
+
+	ch, unsub := b.Subscribe("message.send_ack", 10)
+	defer unsub()
+
+	if err := db.UpsertChat(&store.Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueOutboxWithMessage("c1", "3EB0C1", "chat@s", "hello", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Start(context.Background())
+	defer s.Stop()
+
+	select {
+	case evt := <-ch:
+		p := evt.Payload.(map[string]string)
+		if p["client_msg_id"] != "c1" || p["server_msg_id"] != "3EB0C1" {
+			t.Errorf("ack payload = %v, want c1 -> 3EB0C1", p)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for send_ack event")
+	}
+
+	if calls := mock.sent(); len(calls) != 1 || calls[0].MsgID != "3EB0C1" {
+		t.Errorf("calls = %+v, want one send as 3EB0C1", calls)
+	}
+	msgs, err := db.ListMessages("chat@s", store.Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 1 || msgs[0].MsgID != "3EB0C1" || msgs[0].Status != "sent" {
+		t.Errorf("messages = %+v, want one sent as 3EB0C1", msgs)
+	}
+}
+
+// TestSenderSendsScheduledWhenDue verifies that a scheduled message is held
+// until its time and only then shows up in its chat.
+func TestSenderSendsScheduledWhenDue(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	mock := &mockSender{}
+	logger, _ := zap.NewDevelopment()
+	s := NewSender(db, mock, b, nil, config.OutboxConfig{}, logger)
+
+	ch, unsub := b.Subscribe("message.send_ack", 10)
+	defer unsub()
+
+	if err := db.UpsertChat(&store.Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	sendAt := time.Now().Add(500 * time.Millisecond)
+	if err := db.ScheduleOutbox("c1", "", "chat@s", "later", "", sendAt.UnixMilli()); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Start(context.Background())
+	defer s.Stop()
+
+	time.Sleep(200 * time.Millisecond)
+	if calls := mock.sent(); len(calls) != 0 {
+		t.Fatalf("sent %+v before it was due", calls)
+	}
+
+	select {
+	case <-ch:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for send_ack event")
+	}
+	if time.Now().Before(sendAt) {
+		t.Error("sent before it was due")
+	}
+	msgs, err := db.ListMessages("chat@s", store.Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 1 || msgs[0].Body != "later" || msgs[0].Timestamp < sendAt.UnixMilli() {
+		t.Errorf("messages = %+v, want one stamped when sent", msgs)
+	}
+}
+
+func TestSenderSendsMedia(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	mock := &mockSender{}
+	logger, _ := zap.NewDevelopment()
+	s := NewSender(db, mock, b, nil, config.OutboxConfig{}, logger)
+
+	ch, unsub := b.Subscribe("message.send_ack", 10)
+	defer unsub()
+
+	if err := db.UpsertChat(&store.Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	m := &store.Media{
+		ChatJID: "chat@s", MsgID: "c1", MediaType: "document", Mimetype: "application/pdf",
+		FileLength: 3, FileName: "report.pdf", Caption: "q3", LocalPath: "/cache/ab/abc.pdf",
+	}
+	if err := db.QueueMedia("c1", "3EB0D1", "chat@s", "", m); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Start(context.Background())
+	defer s.Stop()
+
+	select {
+	case <-ch:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for send_ack event")
+	}
+	if calls := mock.sent(); len(calls) != 1 || calls[0].MsgID != "3EB0D1" || calls[0].Text != "document" {
+		t.Errorf("calls = %+v, want one document sent as 3EB0D1", calls)
+	}
+
+	// The message and its media move to the WhatsApp ID, keeping the
+	// upload's key material next to the cached file.
+	msg, err := db.GetMessage("chat@s", "3EB0D1")
+	if err != nil || msg == nil {
+		t.Fatalf("GetMessage(3EB0D1) = %v, %v", msg, err)
+	}
+	if msg.Status != "sent" || msg.MessageType != "document" || msg.Body != "q3" {
+		t.Errorf("message = %+v, want sent document with caption q3", msg)
+	}
+	got, err := db.GetMedia("chat@s", "3EB0D1")
+	if err != nil || got == nil {
+		t.Fatalf("GetMedia(3EB0D1) = %v, %v", got, err)
+	}
+	if got.DirectPath != "/v/c1" || got.LocalPath != m.LocalPath || got.FileName != "report.pdf" {
+		t.Errorf("media = %+v, want uploaded report.pdf at its cached path", got)
+	}
+}
+
+func TestSenderSendsReaction(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	mock := &mockSender{}
+	logger, _ := zap.NewDevelopment()
+	s := NewSender(db, mock, b, nil, config.OutboxConfig{}, logger)
+
+	if err := db.UpsertChat(&store.Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpsertMessage(&store.Message{ChatJID: "chat@s", MsgID: "m1", SenderJID: "bob@s", Body: "hi", MessageType: "text", Timestamp: 1000}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueReaction("r1", "", "chat@s", "m1", "👍"); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Start(context.Background())
+	defer s.Stop()
+
+	time.Sleep(time.Second)
+
+	calls := mock.sent()
+	if len(calls) != 1 || calls[0].Text != "👍" {
+		t.Fatalf("calls = %+v, want one 👍 reaction", calls)
+	}
+
+	// The reaction is recorded locally once sent; no message row is created for it.
+	msgs, err := db.ListMessages("chat@s", store.Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 1 {
+		t.Fatalf("got %d messages, want 1", len(msgs))
+	}
+	r := msgs[0].Reactions
+	if len(r) != 1 || r[0].Emoji != "👍" || r[0].Count != 1 || !r[0].FromMe {
+		t.Errorf("reactions = %+v, want one 👍 from me", r)
+	}
+}
+
+func TestSenderSendsEditAndRevoke(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	mock := &mockSender{}
+	logger, _ := zap.NewDevelopment()
+	s := NewSender(db, mock, b, nil, config.OutboxConfig{}, logger)
+
+	ch, unsub := b.Subscribe("message.send_ack", 10)
+	defer unsub()
+
+	if err := db.UpsertChat(&store.Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"m1", "m2"} {
+		if err := db.UpsertMessage(&store.Message{ChatJID: "chat@s", MsgID: id, Body: "teh " + id, MessageType: "text", FromMe: true, Status: "sent", Timestamp: time.Now().UnixMilli()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.QueueMessageChange("e1", "3EB0E1", &store.MessageChange{ChatJID: "chat@s", MsgID: "m1", Kind: "edit", Body: "the m1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueMessageChange("v1", "3EB0V1", &store.MessageChange{ChatJID: "chat@s", MsgID: "m2", Kind: "revoke"}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Start(context.Background())
+	defer s.Stop()
+
+	for range 2 {
+		select {
+		case <-ch:
+		case <-time.After(2 * time.Second):
+			t.Fatal("timeout waiting for send_ack event")
+		}
+	}
+	calls := mock.sent()
+	if len(calls) != 2 || calls[0] != (sendCall{JID: "chat@s", MsgID: "3EB0E1", Text: "edit m1: the m1"}) ||
+		calls[1] != (sendCall{JID: "chat@s", MsgID: "3EB0V1", Text: "revoke m2"}) {
+		t.Fatalf("calls = %+v, want the edit of m1 then the revoke of m2", calls)
+	}
+
+	// Both change the target message once sent; neither gets a row of its own.
+	msgs, err := db.ListMessages("chat@s", store.Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 2 {
+		t.Fatalf("got %d messages, want 2", len(msgs))
+	}
+	for _, m := range msgs {
+		switch m.MsgID {
+		case "m1":
+			if m.Body != "the m1" || m.EditedAt == 0 {
+				t.Errorf("m1 = %+v, want edited to %q", m, "the m1")
+			}
+		case "m2":
+			if !m.Deleted || m.Body != "" {
+				t.Errorf("m2 = %+v, want deleted", m)
+			}
+		}
+	}
+}
+
+func TestSenderDropsExpiredEdit(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	mock := &mockSender{}
+	logger, _ := zap.NewDevelopment()
+	s := NewSender(db, mock, b, nil, config.OutboxConfig{}, logger)
+
+	ch, unsub := b.Subscribe("message.send_failed", 10)
+	defer unsub()
+
+	if err := db.UpsertChat(&store.Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	sentAt := time.Now().Add(-wa.EditWindow - time.Minute).UnixMilli()
+	if err := db.UpsertMessage(&store.Message{ChatJID: "chat@s", MsgID: "m1", Body: "teh", MessageType: "text", FromMe: true, Status: "sent", Timestamp: sentAt}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueMessageChange("e1", "3EB0E1", &store.MessageChange{ChatJID: "chat@s", MsgID: "m1", Kind: "edit", Body: "the"}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Start(context.Background())
+	defer s.Stop()
+
+	// The edit is dead after one attempt, without a retry.
+	select {
+	case evt := <-ch:
+		if p := evt.Payload.(map[string]string); p["attempts"] != "1" || p["next_attempt_at"] != "" {
+			t.Errorf("failure = %v, want attempt 1 without a retry", p)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for send_failed event")
+	}
+	if calls := mock.sent(); len(calls) != 0 {
+		t.Errorf("calls = %+v, want none", calls)
+	}
+	entry, err := db.GetOutbox("e1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Status != "dead" || !strings.Contains(entry.ErrorMessage, "can no longer be edited") {
+		t.Errorf("entry = %+v, want dead as too old to edit", entry)
+	}
+}
+
+func TestSenderSendsReply(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	mock := &mockSender{}
+	logger, _ := zap.NewDevelopment()
+	s := NewSender(db, mock, b, nil, config.OutboxConfig{}, logger)
+
+	if err := db.UpsertChat(&store.Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpsertMessage(&store.Message{ChatJID: "chat@s", MsgID: "m1", SenderJID: "bob@s", Body: "lunch?", MessageType: "text", Timestamp: 1000}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueOutboxWithMessage("c1", "", "chat@s", "sure", "m1"); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Start(context.Background())
+	defer s.Stop()
+
+	time.Sleep(time.Second)
+
+	calls := mock.sent()
+	if len(calls) != 1 || calls[0].ReplyTo != "m1" {
+		t.Fatalf("calls = %+v, want one reply to m1", calls)
+	}
+	checkReplyContext(t, db, "sure")
+}
+
+func TestSenderFailedReplyKeepsContext(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	mock := &mockSender{err: fmt.Errorf("network error")}
+	logger, _ := zap.NewDevelopment()
+	s := NewSender(db, mock, b, nil, config.OutboxConfig{MaxAttempts: 1}, logger)
+
+	if err := db.UpsertChat(&store.Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpsertMessage(&store.Message{ChatJID: "chat@s", MsgID: "m1", SenderJID: "bob@s", Body: "lunch?", MessageType: "text", Timestamp: 1000}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueOutboxWithMessage("c1", "", "chat@s", "sure", "m1"); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Start(context.Background())
+	defer s.Stop()
+
+	time.Sleep(time.Second)
+
+	if entry, err := db.GetOutbox("c1"); err != nil || entry.Status != "dead" {
+		t.Fatalf("outbox entry = %+v, %v; want dead", entry, err)
+	}
+	checkReplyContext(t, db, "sure")
+}
+
+// checkReplyContext fails unless the message with body still quotes bob's
+// "lunch?" (m1).
+func checkReplyContext(t *testing.T, db *store.DB, body string) {
+	t.Helper()
+	msgs, err := db.ListMessages("chat@s", store.Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range msgs {
+		if m.Body != body {
+			continue
+		}
+		if m.QuotedMsgID != "m1" || m.QuotedSender != "bob@s" || m.QuotedBody != "lunch?" {
+			t.Errorf("reply = %+v, want it to quote bob's lunch? (m1)", m)
+		}
+		return
+	}
+	t.Fatalf("no message %q in %+v", body, msgs)
+}
+
+func TestSenderRetriesWithBackoff(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	mock := &mockSender{err: fmt.Errorf("network error"), failures: 2}
+	logger, _ := zap.NewDevelopment()
+	s := NewSender(db, mock, b, nil, config.OutboxConfig{InitialBackoff: time.Millisecond}, logger)
+
+	if err := db.UpsertChat(&store.Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueOutboxWithMessage("c1", "", "chat@s", "hello", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Start(context.Background())
+	defer s.Stop()
+
+	time.Sleep(2 * time.Second)
+
+	calls := mock.sent()
+	if len(calls) != 3 {
+		t.Fatalf("got %d send calls, want 3 (two failures, then success)", len(calls))
+	}
+	entry, err := db.GetOutbox("c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Status != "sent" || entry.Attempts != 3 {
+		t.Errorf("entry = %+v, want sent after 3 attempts", entry)
+	}
+}
+
+func TestSenderDeadLetters(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	mock := &mockSender{err: fmt.Errorf("network error")}
+	logger, _ := zap.NewDevelopment()
+	s := NewSender(db, mock, b, nil, config.OutboxConfig{MaxAttempts: 2, InitialBackoff: time.Millisecond}, logger)
+
+	ch, unsub := b.Subscribe("message.send_failed", 10)
+	defer unsub()
+
+	if err := db.UpsertChat(&store.Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueOutboxWithMessage("c1", "", "chat@s", "hello", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Start(context.Background())
+	defer s.Stop()
+
+	time.Sleep(2 * time.Second)
+
+	calls := mock.sent()
+	if len(calls) != 2 {
+		t.Fatalf("got %d send calls, want 2", len(calls))
+	}
+	entry, err := db.GetOutbox("c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Status != "dead" || entry.ErrorMessage != "network error" {
+		t.Errorf("entry = %+v, want dead with the last error", entry)
+	}
+	msg, err := db.GetMessage("chat@s", "c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Status != "failed" {
+		t.Errorf("message status = %q, want failed", msg.Status)
+	}
+
+	// The first failure schedules a retry; the last one does not.
+	var got []map[string]string
+	for len(got) < 2 {
+		select {
+		case evt := <-ch:
+			got = append(got, evt.Payload.(map[string]string))
+		case <-time.After(time.Second):
+			t.Fatalf("got %d send_failed events, want 2", len(got))
+		}
+	}
+	if got[0]["attempts"] != "1" || got[0]["next_attempt_at"] == "" {
+		t.Errorf("first failure = %v, want attempt 1 with a retry time", got[0])
+	}
+	if got[1]["attempts"] != "2" || got[1]["next_attempt_at"] != "" {
+		t.Errorf("last failure = %v, want attempt 2 without a retry", got[1])
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	s := NewSender(nil, nil, nil, nil, config.OutboxConfig{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}, zap.NewNop())
+
+	tests := []struct {
+		attempt int
+		base    time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second},
+		{50, 10 * time.Second},
+	}
+	for _, tt := range tests {
+		for range 100 {
+			if d := s.backoff(tt.attempt); d < tt.base/2 || d > tt.base {
+				t.Fatalf("backoff(%d) = %v, want within [%v, %v]", tt.attempt, d, tt.base/2, tt.base)
+			}
+		}
+	}
+}
+
+func TestSenderKeepsOrderWithinChat(t *testing.T) {
+	db := testDB(t)
+	var mu sync.Mutex
+	got := map[string][]string{}
+	cs := &chatSender{
+		delay: map[string]time.Duration{"a@s": 20 * time.Millisecond, "b@s": 5 * time.Millisecond},
+		onSend: func(jid, text string) {
+			mu.Lock()
+			got[jid] = append(got[jid], text)
+			mu.Unlock()
+		},
+	}
+	s := NewSender(db, cs, bus.New(), nil, config.OutboxConfig{}, zap.NewNop())
+
+	want := map[string][]string{}
+	for _, chat := range []string{"a@s", "b@s"} {
+		if err := db.UpsertChat(&store.Chat{JID: chat}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.Start(context.Background())
+	defer s.Stop()
+
+	// Queue while the sender runs, so batches interleave with new entries.
+	for i := range 10 {
+		for _, chat := range []string{"a@s", "b@s"} {
+			text := fmt.Sprintf("%s-%d", chat, i)
+			if err := db.QueueOutboxWithMessage(text, "", chat, text, ""); err != nil {
+				t.Fatal(err)
+			}
+			s.Wake()
+			want[chat] = append(want[chat], text)
+		}
+	}
+
+	time.Sleep(time.Second)
+
+	mu.Lock()
+	defer mu.Unlock()
+	for chat := range want {
+		if !slices.Equal(got[chat], want[chat]) {
+			t.Errorf("sent to %s = %v, want %v", chat, got[chat], want[chat])
+		}
+	}
+}
+
+func TestSenderSlowChatDoesNotBlockOthers(t *testing.T) {
+	db := testDB(t)
+	fast := make(chan time.Time, 1)
+	cs := &chatSender{
+		delay: map[string]time.Duration{"slow@s": 2 * time.Second},
+		onSend: func(jid, _ string) {
+			if jid == "fast@s" {
+				fast <- time.Now()
+			}
+		},
+	}
+	s := NewSender(db, cs, bus.New(), nil, config.OutboxConfig{}, zap.NewNop())
+
+	for _, chat := range []string{"slow@s", "fast@s"} {
+		if err := db.UpsertChat(&store.Chat{JID: chat}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.Start(context.Background())
+	defer s.Stop()
+
+	if err := db.QueueOutboxWithMessage("s1", "", "slow@s", "slow", ""); err != nil {
+		t.Fatal(err)
+	}
+	s.Wake()
+	queued := time.Now()
+	if err := db.QueueOutboxWithMessage("f1", "", "fast@s", "fast", ""); err != nil {
+		t.Fatal(err)
+	}
+	s.Wake()
+
+	select {
+	case sent := <-fast:
+		if d := sent.Sub(queued); d > time.Second {
+			t.Errorf("fast chat sent after %v, want well before the slow send completes", d)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timeout waiting for the fast chat")
+	}
+}
+
+func TestSenderHoldsChatBehindRetry(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	mock := &mockSender{err: fmt.Errorf("network error"), failures: 1}
+	logger, _ := zap.NewDevelopment()
+	s := NewSender(db, mock, b, nil, config.OutboxConfig{InitialBackoff: 200 * time.Millisecond}, logger)
+
+	if err := db.UpsertChat(&store.Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueOutboxWithMessage("c1", "", "chat@s", "first", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueOutboxWithMessage("c2", "", "chat@s", "second", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Start(context.Background())
+	defer s.Stop()
+
+	time.Sleep(time.Second)
+
+	var texts []string
+	for _, c := range mock.sent() {
+		texts = append(texts, c.Text)
+	}
+	if want := []string{"first", "first", "second"}; !slices.Equal(texts, want) {
+		t.Errorf("sends = %v, want %v (second waits for the retry of first)", texts, want)
+	}
+}
+
+func TestSenderStopDrainsInFlightSends(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	mock := &mockSender{delay: 300 * time.Millisecond}
+	logger, _ := zap.NewDevelopment()
+	s := NewSender(db, mock, b, nil, config.OutboxConfig{}, logger)
+
+	ch, unsub := b.Subscribe("message.upserted", 10)
+	defer unsub()
+
+	if err := db.UpsertChat(&store.Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueOutboxWithMessage("c1", "", "chat@s", "hello", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueOutboxWithMessage("c2", "", "chat@s", "later", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Start(context.Background())
+	select {
+	case <-ch: // c1 is being sent
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for the send to start")
+	}
+	s.Stop()
+
+	// The in-flight send completes; the next one is left for the next start.
+	for id, want := range map[string]string{"c1": "sent", "c2": "queued"} {
+		e, err := db.GetOutbox(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Status != want {
+			t.Errorf("%s status = %q, want %q", id, e.Status, want)
+		}
+	}
+}
+
+// BenchmarkSendLatency measures the time from queueing a message to its
+// send.
+func BenchmarkSendLatency(b *testing.B) {
+	db := testDB(b)
+	if err := db.UpsertChat(&store.Chat{JID: "chat@s"}); err != nil {
+		b.Fatal(err)
+	}
+	sent := make(chan struct{}, 1)
+	cs := &chatSender{onSend: func(string, string) { sent <- struct{}{} }}
+	s := NewSender(db, cs, bus.New(), nil, config.OutboxConfig{}, zap.NewNop())
+	s.Start(context.Background())
+	defer s.Stop()
+
+	for i := 0; b.Loop(); i++ {
+		id := fmt.Sprintf("c%d", i)
+		if err := db.QueueOutboxWithMessage(id, "", "chat@s", id, ""); err != nil {
+			b.Fatal(err)
+		}
+		s.Wake()
+		<-sent
+	}
+}
+
+// BenchmarkSendLatencyBesideSlowChat measures the same while every send to
+// another chat takes 100ms.
+func BenchmarkSendLatencyBesideSlowChat(b *testing.B) {
+	db := testDB(b)
+	for _, chat := range []string{"slow@s", "fast@s"} {
+		if err := db.UpsertChat(&store.Chat{JID: chat}); err != nil {
+			b.Fatal(err)
+		}
+	}
+	sent := make(chan struct{}, 1)
+	cs := &chatSender{
+		delay: map[string]time.Duration{"slow@s": 100 * time.Millisecond},
+		onSend: func(jid, _ string) {
+			if jid == "fast@s" {
+				sent <- struct{}{}
+			}
+		},
+	}
+	s := NewSender(db, cs, bus.New(), nil, config.OutboxConfig{}, zap.NewNop())
+	s.Start(context.Background())
+	defer s.Stop()
+
+	for i := 0; b.Loop(); i++ {
+		slow, fast := fmt.Sprintf("s%d", i), fmt.Sprintf("f%d", i)
+		if err := db.QueueOutboxWithMessage(slow, "", "slow@s", slow, ""); err != nil {
+			b.Fatal(err)
+		}
+		if err := db.QueueOutboxWithMessage(fast, "", "fast@s", fast, ""); err != nil {
+			b.Fatal(err)
+		}
+		s.Wake()
+		<-sent
+	}
+}
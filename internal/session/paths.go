@@ -46,6 +46,11 @@ func LogPath(name string) string {
 	return filepath.Join(LogDir(name), "wppd.log")
 }
 
+// MediaDir returns the downloaded media cache directory for a session.
+func MediaDir(name string) string {
+	return filepath.Join(Dir(name), "media")
+}
+
 // ConfigPath returns the global config file path.
 func ConfigPath() string {
 	return filepath.Join(BaseDir(), "config.toml")
@@ -56,6 +61,7 @@ func EnsureDir(name string) error {
 	dirs := []string{
 		Dir(name),
 		LogDir(name),
+		MediaDir(name),
 	}
 	for _, d := range dirs {
 		if err := os.MkdirAll(d, 0700); err != nil {
@@ -31,7 +31,7 @@ var validTransitions = map[State][]State{
 	Syncing:      {Ready, Reconnecting, Degraded, Error},
 	Ready:        {Reconnecting, Degraded, AuthRequired, Error},
 	Reconnecting: {Connecting, Degraded, Error},
-	Degraded:     {Connecting, Reconnecting, Ready, Error},
+	Degraded:     {Connecting, Reconnecting, Syncing, Ready, Error},
 	Error:        {Booting},
 }
 
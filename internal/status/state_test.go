@@ -26,6 +26,10 @@ func TestValidTransitions(t *testing.T) {
 		{Syncing, Ready},
 		{Ready, Reconnecting},
 		{Reconnecting, Connecting},
+		{Syncing, Degraded},
+		{Ready, Degraded},
+		{Degraded, Syncing},
+		{Degraded, Ready},
 	}
 	for _, tt := range tests {
 		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
@@ -2,6 +2,8 @@ package store
 
 import (
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -22,22 +24,36 @@ func (db *DB) UpsertChat(c *Chat) error {
 	return err
 }
 
-// ListChats returns chats sorted by last message timestamp descending.
-// Names are resolved via LEFT JOIN to contacts table with fallback:
-// chat.name -> contact.push_name -> contact.name -> chat.jid
-func (db *DB) ListChats(limit, offset int) ([]Chat, error) {
+// chatDisplayName resolves a chat's name over chats c, groups g and
+// contacts ct with fallback:
+// group.name -> chat.name -> contact.push_name -> contact.name -> chat.jid
+const chatDisplayName = `COALESCE(NULLIF(g.name,''), NULLIF(c.name,''), NULLIF(ct.push_name,''), NULLIF(ct.name,''), c.jid)`
+
+// ListChats returns up to limit chats matching filter after the cursor,
+// sorted by last message timestamp descending. Names are resolved via
+// LEFT JOIN to the groups and contacts tables (see chatDisplayName).
+func (db *DB) ListChats(limit int, after Cursor, filter ChatFilter) ([]Chat, error) {
 	if limit <= 0 {
 		limit = 50
 	}
+	conds, args := filter.where()
+	where := ""
+	if len(conds) > 0 {
+		where = " AND " + strings.Join(conds, " AND ")
+	}
+	args = append(args, after.IsZero(), after.Timestamp, after.Timestamp, after.JID, limit)
+
 	rows, err := db.Query(`
-		SELECT c.jid,
-			COALESCE(NULLIF(c.name,''), NULLIF(ct.push_name,''), NULLIF(ct.name,''), c.jid) AS display_name,
-			c.is_group, c.unread_count, c.last_message_at, c.last_message_preview
+		SELECT c.jid, `+chatDisplayName+` AS display_name,
+			c.is_group, c.unread_count, c.last_message_at, c.last_message_preview,
+			c.archived, c.pinned_at, c.muted_until
 		FROM chats c
 		LEFT JOIN contacts ct ON c.jid = ct.jid
-		WHERE c.jid NOT LIKE '%@lid'
-		ORDER BY c.last_message_at DESC
-		LIMIT ? OFFSET ?`, limit, offset)
+		LEFT JOIN groups g ON c.jid = g.jid
+		WHERE c.jid NOT LIKE '%@lid'`+where+`
+			AND (? OR c.last_message_at < ? OR (c.last_message_at = ? AND c.jid < ?))
+		ORDER BY c.last_message_at DESC, c.jid DESC
+		LIMIT ?`, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +62,8 @@ func (db *DB) ListChats(limit, offset int) ([]Chat, error) {
 	var chats []Chat
 	for rows.Next() {
 		var c Chat
-		if err := rows.Scan(&c.JID, &c.Name, &c.IsGroup, &c.UnreadCount, &c.LastMessageAt, &c.LastMessagePreview); err != nil {
+		if err := rows.Scan(&c.JID, &c.Name, &c.IsGroup, &c.UnreadCount, &c.LastMessageAt, &c.LastMessagePreview,
+			&c.Archived, &c.PinnedAt, &c.MutedUntil); err != nil {
 			return nil, err
 		}
 		chats = append(chats, c)
@@ -58,13 +75,15 @@ func (db *DB) ListChats(limit, offset int) ([]Chat, error) {
 func (db *DB) GetChat(jid string) (*Chat, error) {
 	var c Chat
 	err := db.QueryRow(`
-		SELECT c.jid,
-			COALESCE(NULLIF(c.name,''), NULLIF(ct.push_name,''), NULLIF(ct.name,''), c.jid) AS display_name,
-			c.is_group, c.unread_count, c.last_message_at, c.last_message_preview
+		SELECT c.jid, `+chatDisplayName+` AS display_name,
+			c.is_group, c.unread_count, c.last_message_at, c.last_message_preview,
+			c.archived, c.pinned_at, c.muted_until
 		FROM chats c
 		LEFT JOIN contacts ct ON c.jid = ct.jid
+		LEFT JOIN groups g ON c.jid = g.jid
 		WHERE c.jid = ?`, jid).
-		Scan(&c.JID, &c.Name, &c.IsGroup, &c.UnreadCount, &c.LastMessageAt, &c.LastMessagePreview)
+		Scan(&c.JID, &c.Name, &c.IsGroup, &c.UnreadCount, &c.LastMessageAt, &c.LastMessagePreview,
+			&c.Archived, &c.PinnedAt, &c.MutedUntil)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -73,3 +92,148 @@ func (db *DB) GetChat(jid string) (*Chat, error) {
 	}
 	return &c, nil
 }
+
+// TouchChat records a new message in its chat, creating the chat if
+// needed. Unlike UpsertChat it keeps the stored name, never moves
+// last_message_at backwards, and adds c.UnreadCount to the stored unread
+// count instead of replacing it.
+func (db *DB) TouchChat(c *Chat) error {
+	now := time.Now().UnixMilli()
+	_, err := db.Exec(`
+		INSERT INTO chats (jid, name, is_group, unread_count, last_message_at, last_message_preview, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(jid) DO UPDATE SET
+			name = COALESCE(NULLIF(excluded.name, ''), chats.name),
+			is_group = excluded.is_group,
+			unread_count = chats.unread_count + excluded.unread_count,
+			last_message_at = MAX(chats.last_message_at, excluded.last_message_at),
+			last_message_preview = CASE WHEN excluded.last_message_at >= chats.last_message_at THEN excluded.last_message_preview ELSE chats.last_message_preview END,
+			updated_at = excluded.updated_at`,
+		c.JID, c.Name, c.IsGroup, c.UnreadCount, c.LastMessageAt, c.LastMessagePreview, now)
+	return err
+}
+
+// SetChatUnread sets the unread count of a stored chat. A negative count
+// means the chat was marked unread without a count, as WhatsApp does; the
+// stored count is then raised to at least one. Returns false when the chat
+// is not stored.
+func (db *DB) SetChatUnread(jid string, count int) (bool, error) {
+	res, err := db.Exec(`
+		UPDATE chats SET
+			unread_count = CASE WHEN ? < 0 THEN MAX(unread_count, 1) ELSE ? END,
+			updated_at = ?
+		WHERE jid = ?`, count, count, time.Now().UnixMilli(), jid)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// SetChatSettings applies the app state settings of a stored chat.
+// Returns false when the chat is not stored.
+func (db *DB) SetChatSettings(s *ChatSettings) (bool, error) {
+	res, err := db.Exec(`
+		UPDATE chats SET
+			archived = COALESCE(?, archived),
+			pinned_at = COALESCE(?, pinned_at),
+			muted_until = COALESCE(?, muted_until),
+			updated_at = ?
+		WHERE jid = ?`, s.Archived, s.PinnedAt, s.MutedUntil, time.Now().UnixMilli(), s.ChatJID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// ClearChat removes the messages of a chat that was cleared or deleted on
+// another device, along with their reactions, media and revisions. Our
+// messages the outbox has not sent yet are kept, and the chat preview
+// falls back to the newest message left. Returns whether the chat row was
+// deleted.
+func (db *DB) ClearChat(c *ChatClear) (bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	cleared := `SELECT msg_id FROM messages
+		WHERE chat_jid = ?1 AND (?2 = 0 OR timestamp <= ?2) AND status NOT IN ('queued', 'sending', 'failed')`
+	stmts := []string{
+		`DELETE FROM reactions WHERE chat_jid = ?1 AND msg_id IN (` + cleared + `)`,
+		`DELETE FROM media WHERE chat_jid = ?1 AND msg_id IN (` + cleared + `)`,
+		`DELETE FROM message_revisions WHERE chat_jid = ?1 AND msg_id IN (` + cleared + `)`,
+		`DELETE FROM messages WHERE chat_jid = ?1 AND msg_id IN (` + cleared + `)`,
+	}
+	for _, q := range stmts {
+		if _, err := tx.Exec(q, c.ChatJID, c.Before); err != nil {
+			return false, fmt.Errorf("clear chat: %w", err)
+		}
+	}
+	if _, err := tx.Exec(`
+		UPDATE chats SET
+			unread_count = 0,
+			last_message_preview = COALESCE((
+				SELECT substr(body, 1, 100) FROM messages WHERE chat_jid = ?1
+				ORDER BY timestamp DESC, id DESC LIMIT 1), ''),
+			updated_at = ?2
+		WHERE jid = ?1`, c.ChatJID, time.Now().UnixMilli()); err != nil {
+		return false, fmt.Errorf("reset chat: %w", err)
+	}
+
+	deleted := false
+	if c.Delete {
+		if _, err := tx.Exec(`DELETE FROM outbox WHERE chat_jid = ? AND status IN ('sent', 'cancelled', 'dead')`, c.ChatJID); err != nil {
+			return false, fmt.Errorf("clear outbox: %w", err)
+		}
+		res, err := tx.Exec(`
+			DELETE FROM chats WHERE jid = ?1
+				AND NOT EXISTS (SELECT 1 FROM messages WHERE chat_jid = ?1)
+				AND NOT EXISTS (SELECT 1 FROM outbox WHERE chat_jid = ?1)`, c.ChatJID)
+		if err != nil {
+			return false, fmt.Errorf("delete chat: %w", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return false, err
+		}
+		deleted = n > 0
+	}
+	return deleted, tx.Commit()
+}
+
+// UnreadMessages returns the keys of the chat's unread messages: its
+// newest unread_count incoming messages, oldest first.
+func (db *DB) UnreadMessages(chatJID string) ([]MessageKey, error) {
+	rows, err := db.Query(`
+		SELECT msg_id, sender_jid FROM (
+			SELECT msg_id, sender_jid, timestamp, id
+			FROM messages
+			WHERE chat_jid = ? AND from_me = 0 AND deleted = 0
+			ORDER BY timestamp DESC, id DESC
+			LIMIT (SELECT unread_count FROM chats WHERE jid = ?)
+		)
+		ORDER BY timestamp ASC, id ASC`, chatJID, chatJID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var keys []MessageKey
+	for rows.Next() {
+		k := MessageKey{ChatJID: chatJID}
+		if err := rows.Scan(&k.ID, &k.SenderJID); err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
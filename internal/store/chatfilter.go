@@ -0,0 +1,161 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ChatFilter restricts ListChats. The zero ChatFilter matches every chat.
+// Flag filters are nil when not constrained.
+type ChatFilter struct {
+	Unread   *bool
+	Group    *bool
+	Archived *bool
+	Pinned   *bool
+	Muted    *bool
+	Names    []string // substrings of the display name
+	Text     []string // substrings of the display name or last message
+	Since    int64    // minimum last_message_at, in Unix ms
+}
+
+// ParseChatFilter parses the chat filter language: whitespace-separated
+// terms that must all match.
+//
+//	is:unread is:group is:dm is:archived is:pinned is:muted
+//	name:foo              display name contains foo
+//	since:2026-01-01      last message on or after that day (local time)
+//	foo                   display name or last message contains foo
+//
+// A leading '-' negates an is: term. Values may be double-quoted to
+// include spaces, as in name:"book club". Words that look like filters but
+// are not (10:30, re:meeting, URLs) are matched as text.
+func ParseChatFilter(s string) (ChatFilter, error) {
+	var f ChatFilter
+	terms, err := splitTerms(s)
+	if err != nil {
+		return f, err
+	}
+	for _, term := range terms {
+		key, value, _ := strings.Cut(term, ":")
+		negated := strings.HasPrefix(key, "-")
+		key = strings.TrimPrefix(key, "-")
+		if value == "" || (key != "is" && key != "name" && key != "since") {
+			f.Text = append(f.Text, unquote(term))
+			continue
+		}
+		if negated && key != "is" {
+			return f, fmt.Errorf("only is: terms can be negated: %q", term)
+		}
+		switch key {
+		case "is":
+			want := !negated
+			switch value {
+			case "unread":
+				f.Unread = &want
+			case "group":
+				f.Group = &want
+			case "dm":
+				dm := !want
+				f.Group = &dm
+			case "archived":
+				f.Archived = &want
+			case "pinned":
+				f.Pinned = &want
+			case "muted":
+				f.Muted = &want
+			default:
+				return f, fmt.Errorf("unknown filter %q", term)
+			}
+		case "name":
+			f.Names = append(f.Names, unquote(value))
+		case "since":
+			day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+			if err != nil {
+				return f, fmt.Errorf("since: want YYYY-MM-DD, got %q", value)
+			}
+			f.Since = day.UnixMilli()
+		}
+	}
+	return f, nil
+}
+
+// where returns the SQL conditions and arguments of f over the chats c,
+// groups g and contacts ct of ListChats.
+func (f ChatFilter) where() ([]string, []any) {
+	var conds []string
+	var args []any
+	flag := func(col string, want *bool) {
+		if want == nil {
+			return
+		}
+		conds = append(conds, col+" = ?")
+		args = append(args, *want)
+	}
+	if f.Unread != nil {
+		if *f.Unread {
+			conds = append(conds, "c.unread_count != 0")
+		} else {
+			conds = append(conds, "c.unread_count = 0")
+		}
+	}
+	flag("c.is_group", f.Group)
+	flag("c.archived", f.Archived)
+	flag("c.pinned_at > 0", f.Pinned)
+	if f.Muted != nil {
+		conds = append(conds, "(c.muted_until < 0 OR c.muted_until > ?) = ?")
+		args = append(args, time.Now().UnixMilli(), *f.Muted)
+	}
+	for _, name := range f.Names {
+		conds = append(conds, chatDisplayName+` LIKE ? ESCAPE '\'`)
+		args = append(args, likePattern(name))
+	}
+	for _, text := range f.Text {
+		conds = append(conds, `(`+chatDisplayName+` LIKE ? ESCAPE '\' OR c.last_message_preview LIKE ? ESCAPE '\')`)
+		args = append(args, likePattern(text), likePattern(text))
+	}
+	if f.Since > 0 {
+		conds = append(conds, "c.last_message_at >= ?")
+		args = append(args, f.Since)
+	}
+	return conds, args
+}
+
+// splitTerms splits s on whitespace, keeping double-quoted runs together.
+func splitTerms(s string) ([]string, error) {
+	var terms []string
+	var cur strings.Builder
+	quoted := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			cur.WriteRune(r)
+		case !quoted && (r == ' ' || r == '\t'):
+			if cur.Len() > 0 {
+				terms = append(terms, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in %q", s)
+	}
+	if cur.Len() > 0 {
+		terms = append(terms, cur.String())
+	}
+	return terms, nil
+}
+
+func unquote(s string) string {
+	return strings.ReplaceAll(s, `"`, "")
+}
+
+// likePattern returns a case-insensitive LIKE pattern matching s anywhere,
+// escaping LIKE wildcards with '\'.
+func likePattern(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + r.Replace(s) + "%"
+}
@@ -58,6 +58,23 @@ func (db *DB) GetContact(jid string) (*Contact, error) {
 	return &c, nil
 }
 
+// DisplayName returns the name shown for jid: the contact's push name or
+// saved name, falling back to the JID itself.
+func (db *DB) DisplayName(jid string) (string, error) {
+	c, err := db.GetContact(jid)
+	if c == nil || err != nil {
+		return jid, err
+	}
+	switch {
+	case c.PushName != "":
+		return c.PushName, nil
+	case c.Name != "":
+		return c.Name, nil
+	default:
+		return jid, nil
+	}
+}
+
 // ChatCount returns the total number of chats.
 func (db *DB) ChatCount() (int64, error) {
 	var count int64
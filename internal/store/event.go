@@ -0,0 +1,100 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AppendEvent adds e to the event journal and sets e.ID.
+func (db *DB) AppendEvent(e *Event) error {
+	res, err := db.Exec(`
+		INSERT INTO events (kind, chat_jid, correlation_id, payload, occurred_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		e.Kind, e.ChatJID, e.CorrelationID, e.Payload, e.OccurredAt, time.Now().UnixMilli())
+	if err != nil {
+		return fmt.Errorf("append event: %w", err)
+	}
+	e.ID, err = res.LastInsertId()
+	return err
+}
+
+// ListEvents returns up to limit journaled events with an id greater than
+// after, oldest first. Only kinds starting with one of prefixes are
+// returned, and when chatJID is set only events about that chat.
+func (db *DB) ListEvents(after int64, prefixes []string, chatJID string, limit int) ([]Event, error) {
+	if limit <= 0 {
+		limit = 500
+	}
+	q := `SELECT id, kind, chat_jid, correlation_id, payload, occurred_at FROM events WHERE id > ?`
+	args := []any{after}
+	if len(prefixes) > 0 {
+		conds := make([]string, len(prefixes))
+		for i, p := range prefixes {
+			conds[i] = "substr(kind, 1, ?) = ?"
+			args = append(args, len(p), p)
+		}
+		q += " AND (" + strings.Join(conds, " OR ") + ")"
+	}
+	if chatJID != "" {
+		q += " AND chat_jid = ?"
+		args = append(args, chatJID)
+	}
+	q += " ORDER BY id LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := db.Query(q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var events []Event
+	for rows.Next() {
+		var e Event
+		if err := rows.Scan(&e.ID, &e.Kind, &e.ChatJID, &e.CorrelationID, &e.Payload, &e.OccurredAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// EventRange returns the id of the oldest retained event and of the newest
+// event ever appended. Any cursor from oldest-1 to newest can be resumed
+// without gaps. With no retained events oldest is newest+1.
+func (db *DB) EventRange() (oldest, newest int64, err error) {
+	// sqlite_sequence keeps the AUTOINCREMENT high-water mark even after
+	// every row has been pruned.
+	if err := db.QueryRow(`
+		SELECT COALESCE((SELECT seq FROM sqlite_sequence WHERE name = 'events'), 0)`).Scan(&newest); err != nil {
+		return 0, 0, fmt.Errorf("event sequence: %w", err)
+	}
+	if err := db.QueryRow(`SELECT COALESCE(MIN(id), ?) FROM events`, newest+1).Scan(&oldest); err != nil {
+		return 0, 0, fmt.Errorf("oldest event: %w", err)
+	}
+	return oldest, newest, nil
+}
+
+// PruneEvents deletes journaled events created before olderThan (Unix ms)
+// and all but the newest keep events. A zero bound is not applied.
+func (db *DB) PruneEvents(olderThan int64, keep int) (int64, error) {
+	var total int64
+	if olderThan > 0 {
+		res, err := db.Exec(`DELETE FROM events WHERE created_at < ?`, olderThan)
+		if err != nil {
+			return 0, fmt.Errorf("prune events by age: %w", err)
+		}
+		n, _ := res.RowsAffected()
+		total += n
+	}
+	if keep > 0 {
+		res, err := db.Exec(`DELETE FROM events WHERE id <= (SELECT MAX(id) FROM events) - ?`, keep)
+		if err != nil {
+			return 0, fmt.Errorf("prune events by count: %w", err)
+		}
+		n, _ := res.RowsAffected()
+		total += n
+	}
+	return total, nil
+}
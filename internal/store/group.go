@@ -0,0 +1,131 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// UpsertGroup stores group metadata. When g.Participants is not nil it
+// replaces the stored participant list.
+func (db *DB) UpsertGroup(g *Group) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`
+		INSERT INTO groups (jid, name, topic, owner_jid, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(jid) DO UPDATE SET
+			name = excluded.name,
+			topic = excluded.topic,
+			owner_jid = excluded.owner_jid,
+			created_at = excluded.created_at,
+			updated_at = excluded.updated_at`,
+		g.JID, g.Name, g.Topic, g.OwnerJID, g.CreatedAt, time.Now().UnixMilli()); err != nil {
+		return fmt.Errorf("upsert group: %w", err)
+	}
+
+	if g.Participants != nil {
+		if _, err := tx.Exec(`DELETE FROM group_participants WHERE group_jid = ?`, g.JID); err != nil {
+			return fmt.Errorf("clear participants: %w", err)
+		}
+		for _, p := range g.Participants {
+			if _, err := tx.Exec(`
+				INSERT OR REPLACE INTO group_participants (group_jid, jid, is_admin, is_super_admin)
+				VALUES (?, ?, ?, ?)`, g.JID, p.JID, p.IsAdmin, p.IsSuperAdmin); err != nil {
+				return fmt.Errorf("insert participant %q: %w", p.JID, err)
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// ApplyGroupChange applies a group notification to the stored group,
+// creating a bare row for groups seen for the first time.
+func (db *DB) ApplyGroupChange(c *GroupChange) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now().UnixMilli()
+	if _, err := tx.Exec(`INSERT OR IGNORE INTO groups (jid, updated_at) VALUES (?, ?)`, c.GroupJID, now); err != nil {
+		return fmt.Errorf("insert group: %w", err)
+	}
+	if c.Name != nil {
+		if _, err := tx.Exec(`UPDATE groups SET name = ?, updated_at = ? WHERE jid = ?`, *c.Name, now, c.GroupJID); err != nil {
+			return fmt.Errorf("update group name: %w", err)
+		}
+	}
+	if c.Topic != nil {
+		if _, err := tx.Exec(`UPDATE groups SET topic = ?, updated_at = ? WHERE jid = ?`, *c.Topic, now, c.GroupJID); err != nil {
+			return fmt.Errorf("update group topic: %w", err)
+		}
+	}
+	for _, jid := range c.Join {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO group_participants (group_jid, jid) VALUES (?, ?)`, c.GroupJID, jid); err != nil {
+			return fmt.Errorf("add participant %q: %w", jid, err)
+		}
+	}
+	for _, jid := range c.Leave {
+		if _, err := tx.Exec(`DELETE FROM group_participants WHERE group_jid = ? AND jid = ?`, c.GroupJID, jid); err != nil {
+			return fmt.Errorf("remove participant %q: %w", jid, err)
+		}
+	}
+	for _, jid := range c.Promote {
+		if _, err := tx.Exec(`
+			INSERT INTO group_participants (group_jid, jid, is_admin) VALUES (?, ?, 1)
+			ON CONFLICT(group_jid, jid) DO UPDATE SET is_admin = 1`, c.GroupJID, jid); err != nil {
+			return fmt.Errorf("promote participant %q: %w", jid, err)
+		}
+	}
+	for _, jid := range c.Demote {
+		if _, err := tx.Exec(`
+			UPDATE group_participants SET is_admin = 0, is_super_admin = 0
+			WHERE group_jid = ? AND jid = ?`, c.GroupJID, jid); err != nil {
+			return fmt.Errorf("demote participant %q: %w", jid, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetGroup returns a group with its participants, admins first, or nil if
+// the group is not stored. Participant names are resolved via contacts.
+func (db *DB) GetGroup(jid string) (*Group, error) {
+	g := Group{JID: jid}
+	err := db.QueryRow(`SELECT name, topic, owner_jid, created_at FROM groups WHERE jid = ?`, jid).
+		Scan(&g.Name, &g.Topic, &g.OwnerJID, &g.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(`
+		SELECT p.jid,
+			COALESCE(NULLIF(ct.push_name,''), NULLIF(ct.name,''), p.jid) AS display_name,
+			p.is_admin, p.is_super_admin
+		FROM group_participants p
+		LEFT JOIN contacts ct ON p.jid = ct.jid
+		WHERE p.group_jid = ?
+		ORDER BY p.is_super_admin DESC, p.is_admin DESC, display_name`, jid)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	g.Participants = []GroupParticipant{}
+	for rows.Next() {
+		var p GroupParticipant
+		if err := rows.Scan(&p.JID, &p.Name, &p.IsAdmin, &p.IsSuperAdmin); err != nil {
+			return nil, err
+		}
+		g.Participants = append(g.Participants, p)
+	}
+	return &g, rows.Err()
+}
@@ -70,6 +70,50 @@ func (db *DB) ReconcileLIDs() (int64, error) {
 		return 0, fmt.Errorf("reassign messages: %w", err)
 	}
 
+	// Reassign revision history along with its messages.
+	if _, err := tx.Exec(`
+		UPDATE message_revisions SET
+			chat_jid = (SELECT lm.pn || '@s.whatsapp.net' FROM lid_map lm WHERE message_revisions.chat_jid = lm.lid || '@lid')
+		WHERE chat_jid IN (SELECT lm.lid || '@lid' FROM lid_map lm)
+	`); err != nil {
+		return 0, fmt.Errorf("reassign revisions: %w", err)
+	}
+
+	// Reassign media metadata along with its messages.
+	if _, err := tx.Exec(`
+		UPDATE media SET
+			chat_jid = (SELECT lm.pn || '@s.whatsapp.net' FROM lid_map lm WHERE media.chat_jid = lm.lid || '@lid')
+		WHERE chat_jid IN (SELECT lm.lid || '@lid' FROM lid_map lm)
+	`); err != nil {
+		return 0, fmt.Errorf("reassign media: %w", err)
+	}
+
+	// Reassign reactions; a reassigned row replaces any PN row for the same sender.
+	if _, err := tx.Exec(`
+		UPDATE OR REPLACE reactions SET
+			chat_jid = COALESCE(
+				(SELECT lm.pn || '@s.whatsapp.net' FROM lid_map lm WHERE reactions.chat_jid = lm.lid || '@lid'),
+				reactions.chat_jid
+			),
+			sender_jid = COALESCE(
+				(SELECT lm2.pn || '@s.whatsapp.net' FROM lid_map lm2 WHERE reactions.sender_jid = lm2.lid || '@lid'),
+				reactions.sender_jid
+			)
+		WHERE chat_jid IN (SELECT lm.lid || '@lid' FROM lid_map lm)
+			OR sender_jid IN (SELECT lm.lid || '@lid' FROM lid_map lm)
+	`); err != nil {
+		return 0, fmt.Errorf("reassign reactions: %w", err)
+	}
+
+	// Reassign group participants known only by their LID.
+	if _, err := tx.Exec(`
+		UPDATE OR REPLACE group_participants SET
+			jid = (SELECT lm.pn || '@s.whatsapp.net' FROM lid_map lm WHERE group_participants.jid = lm.lid || '@lid')
+		WHERE jid IN (SELECT lm.lid || '@lid' FROM lid_map lm)
+	`); err != nil {
+		return 0, fmt.Errorf("reassign group participants: %w", err)
+	}
+
 	// Reassign contacts from LID to PN.
 	if _, err := tx.Exec(`
 		INSERT INTO contacts (jid, name, push_name, updated_at)
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// UpsertMedia stores the attachment metadata of a message. Re-ingesting a
+// message refreshes the metadata but keeps the cached file.
+func (db *DB) UpsertMedia(m *Media) error {
+	_, err := db.Exec(`
+		INSERT INTO media (chat_jid, msg_id, media_type, mimetype, file_length, file_name, caption,
+			direct_path, media_key, file_sha256, file_enc_sha256, thumbnail, voice, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(chat_jid, msg_id) DO UPDATE SET
+			media_type = excluded.media_type,
+			mimetype = excluded.mimetype,
+			file_length = excluded.file_length,
+			file_name = excluded.file_name,
+			caption = excluded.caption,
+			direct_path = excluded.direct_path,
+			media_key = excluded.media_key,
+			file_sha256 = excluded.file_sha256,
+			file_enc_sha256 = excluded.file_enc_sha256,
+			thumbnail = excluded.thumbnail,
+			voice = excluded.voice`,
+		m.ChatJID, m.MsgID, m.MediaType, m.Mimetype, m.FileLength, m.FileName, m.Caption,
+		m.DirectPath, m.MediaKey, m.FileSHA256, m.FileEncSHA256, m.Thumbnail, m.Voice, time.Now().UnixMilli())
+	return err
+}
+
+// GetMedia returns the attachment metadata of a message, or nil if it has none.
+func (db *DB) GetMedia(chatJID, msgID string) (*Media, error) {
+	var m Media
+	err := db.QueryRow(`
+		SELECT chat_jid, msg_id, media_type, mimetype, file_length, file_name, caption,
+			direct_path, media_key, file_sha256, file_enc_sha256, thumbnail, voice, local_path
+		FROM media WHERE chat_jid = ? AND msg_id = ?`, chatJID, msgID).
+		Scan(&m.ChatJID, &m.MsgID, &m.MediaType, &m.Mimetype, &m.FileLength, &m.FileName, &m.Caption,
+			&m.DirectPath, &m.MediaKey, &m.FileSHA256, &m.FileEncSHA256, &m.Thumbnail, &m.Voice, &m.LocalPath)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// SetMediaLocalPath records where the downloaded file of a message is cached.
+func (db *DB) SetMediaLocalPath(chatJID, msgID, path string) error {
+	_, err := db.Exec(`UPDATE media SET local_path = ? WHERE chat_jid = ? AND msg_id = ?`, path, chatJID, msgID)
+	return err
+}
+
+// loadMedia fills the Media of each media message. Key material and
+// thumbnails are left out; use GetMedia for those.
+func (db *DB) loadMedia(msgs []*Message) error {
+	for _, m := range msgs {
+		switch m.MessageType {
+		case "image", "video", "audio", "document", "sticker":
+		default:
+			continue
+		}
+		md := Media{ChatJID: m.ChatJID, MsgID: m.MsgID}
+		err := db.QueryRow(`
+			SELECT media_type, mimetype, file_length, file_name, caption, voice, local_path
+			FROM media WHERE chat_jid = ? AND msg_id = ?`, m.ChatJID, m.MsgID).
+			Scan(&md.MediaType, &md.Mimetype, &md.FileLength, &md.FileName, &md.Caption, &md.Voice, &md.LocalPath)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("load media: %w", err)
+		}
+		m.Media = &md
+	}
+	return nil
+}
@@ -1,51 +1,275 @@
 package store
 
-import "time"
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
 
-// UpsertMessage inserts or updates a message (idempotent on chat_jid + msg_id).
+// matchMsgID matches a message row by msg_id or, for messages sent from this
+// device that still keep their client_msg_id (those not yet sent, and those
+// sent before messages were re-keyed on send), by the server ID in the outbox.
+// Takes the same ID twice as arguments.
+const matchMsgID = `(msg_id = ? OR msg_id IN (SELECT client_msg_id FROM outbox WHERE server_msg_id = ?))`
+
+// changeBy restricts a message change to the messages its sender may change:
+// ours when the change is ours, otherwise the sender's. History messages of
+// direct chats carry no sender; theirs is the chat. Takes FromMe and
+// SenderJID as arguments.
+const changeBy = `(CASE WHEN ? THEN from_me = 1 ELSE from_me = 0 AND COALESCE(NULLIF(sender_jid, ''), chat_jid) = ? END)`
+
+// groupAdmin matches when the given JID is an admin of the message's group.
+const groupAdmin = `EXISTS (SELECT 1 FROM group_participants gp
+	WHERE gp.group_jid = messages.chat_jid AND gp.jid = ? AND (gp.is_admin = 1 OR gp.is_super_admin = 1))`
+
+// quotedJoin joins each message m to the message it quotes as q, resolving
+// server IDs of messages sent from this device that still keep their
+// client_msg_id to their local msg_id.
+const quotedJoin = `
+	LEFT JOIN messages q ON m.quoted_msg_id != '' AND q.chat_jid = m.chat_jid AND q.msg_id =
+		COALESCE((SELECT o.client_msg_id FROM outbox o
+			JOIN messages c ON c.chat_jid = o.chat_jid AND c.msg_id = o.client_msg_id
+			WHERE o.server_msg_id = m.quoted_msg_id AND o.kind IN ('text', 'media')), m.quoted_msg_id)`
+
+// quotedColumns selects the reply context of m for scanning into
+// Message.QuotedMsgID, QuotedSender and QuotedBody.
+const quotedColumns = `COALESCE(q.msg_id, m.quoted_msg_id), m.quoted_sender, COALESCE(q.body, m.quoted_body)`
+
+// messageUpsert inserts or updates a message, idempotent on chat_jid +
+// msg_id. Revoked or edited messages keep their body, and the reply
+// context is kept when the update does not carry one, as the outbox's
+// status updates do not. A message that reached WhatsApp keeps the
+// furthest status it got to, so re-ingesting it does not undo receipts;
+// before that the outbox may move it back to queued or failed.
+var messageUpsert = `
+	INSERT INTO messages (chat_jid, msg_id, sender_jid, sender_name, body, message_type, from_me, status, timestamp, quoted_msg_id, quoted_sender, quoted_body, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(chat_jid, msg_id) DO UPDATE SET
+		sender_name = excluded.sender_name,
+		body = CASE WHEN messages.deleted = 1 OR messages.edited_at > 0 THEN messages.body ELSE excluded.body END,
+		status = CASE WHEN ` + statusRank("messages.status") + ` >= ` + statusRank("'sent'") + `
+			AND ` + statusRank("messages.status") + ` > ` + statusRank("excluded.status") + `
+			THEN messages.status ELSE excluded.status END,
+		quoted_msg_id = COALESCE(NULLIF(excluded.quoted_msg_id, ''), messages.quoted_msg_id),
+		quoted_sender = COALESCE(NULLIF(excluded.quoted_sender, ''), messages.quoted_sender),
+		quoted_body = COALESCE(NULLIF(excluded.quoted_body, ''), messages.quoted_body)`
+
+func messageUpsertArgs(m *Message) []any {
+	return []any{m.ChatJID, m.MsgID, m.SenderJID, m.SenderName, m.Body, m.MessageType, m.FromMe, m.Status, m.Timestamp,
+		m.QuotedMsgID, m.QuotedSender, m.QuotedBody, time.Now().UnixMilli()}
+}
+
+// UpsertMessage inserts or updates a message (idempotent on chat_jid + msg_id)
+// as described at messageUpsert. A replaced body is kept in
+// message_revisions by the messages_revision_au trigger.
 func (db *DB) UpsertMessage(m *Message) error {
-	now := time.Now().UnixMilli()
-	_, err := db.Exec(`
-		INSERT INTO messages (chat_jid, msg_id, sender_jid, sender_name, body, message_type, from_me, status, timestamp, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-		ON CONFLICT(chat_jid, msg_id) DO UPDATE SET
-			sender_name = excluded.sender_name,
-			body = excluded.body,
-			status = excluded.status`,
-		m.ChatJID, m.MsgID, m.SenderJID, m.SenderName, m.Body, m.MessageType, m.FromMe, m.Status, m.Timestamp, now)
+	_, err := db.Exec(messageUpsert, messageUpsertArgs(m)...)
 	return err
 }
 
-// ListMessages returns messages for a chat using keyset pagination by timestamp.
-// Sender names are resolved via LEFT JOIN to contacts table.
-func (db *DB) ListMessages(chatJID string, beforeTs int64, limit int) ([]Message, error) {
-	if limit <= 0 {
-		limit = 50
+// UpsertMessageTx is UpsertMessage within tx, for batches.
+func UpsertMessageTx(tx *sql.Tx, m *Message) error {
+	_, err := tx.Exec(messageUpsert, messageUpsertArgs(m)...)
+	return err
+}
+
+// ApplyMessageChange applies an edit or revoke to the message it targets.
+// Edits replace the body and set edited_at; revokes clear the body, mark
+// the row deleted and drop its media metadata. Only the sender may edit a
+// message; a group admin may also revoke it. Returns the local msg_id that
+// changed, or "" if the target is not stored, is not the sender's (or is
+// already deleted, for edits).
+func (db *DB) ApplyMessageChange(c *MessageChange) (string, error) {
+	var row *sql.Row
+	switch c.Kind {
+	case "edit":
+		row = db.QueryRow(`
+			UPDATE messages SET body = ?, edited_at = ?
+			WHERE chat_jid = ? AND deleted = 0 AND `+matchMsgID+` AND `+changeBy+`
+			RETURNING msg_id`,
+			c.Body, c.Timestamp, c.ChatJID, c.MsgID, c.MsgID, c.FromMe, c.SenderJID)
+	case "revoke":
+		row = db.QueryRow(`
+			UPDATE messages SET body = '', deleted = 1
+			WHERE chat_jid = ? AND `+matchMsgID+` AND (`+changeBy+` OR `+groupAdmin+`)
+			RETURNING msg_id`,
+			c.ChatJID, c.MsgID, c.MsgID, c.FromMe, c.SenderJID, c.SenderJID)
+	default:
+		return "", fmt.Errorf("unknown message change kind %q", c.Kind)
 	}
-	if beforeTs <= 0 {
-		beforeTs = time.Now().UnixMilli() + 1
+
+	var msgID string
+	err := row.Scan(&msgID)
+	if err == sql.ErrNoRows {
+		return "", nil
 	}
+	if err != nil {
+		return "", err
+	}
+	// A revoked attachment can no longer be downloaded or searched.
+	if c.Kind == "revoke" {
+		if _, err := db.Exec(`DELETE FROM media WHERE chat_jid = ? AND msg_id = ?`, c.ChatJID, msgID); err != nil {
+			return "", fmt.Errorf("delete media: %w", err)
+		}
+	}
+	return msgID, nil
+}
+
+// MessageExists reports whether a message is stored under chatJID and msgID.
+func (db *DB) MessageExists(chatJID, msgID string) (bool, error) {
+	var exists bool
+	err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM messages WHERE chat_jid = ? AND msg_id = ?)`, chatJID, msgID).Scan(&exists)
+	return exists, err
+}
+
+// GetMessageKey returns the WhatsApp key of a stored message, or nil if
+// the message is not stored.
+func (db *DB) GetMessageKey(chatJID, msgID string) (*MessageKey, error) {
+	q, err := db.GetQuotedMessage(chatJID, msgID)
+	if q == nil || err != nil {
+		return nil, err
+	}
+	return &q.Key, nil
+}
+
+// GetQuotedMessage returns the key and body of a stored message for
+// quoting it in a reply, or nil if the message is not stored.
+func (db *DB) GetQuotedMessage(chatJID, msgID string) (*QuotedMessage, error) {
+	q := QuotedMessage{Key: MessageKey{ChatJID: chatJID}}
+	err := db.QueryRow(`
+		SELECT COALESCE(NULLIF(o.server_msg_id, ''), m.msg_id), m.sender_jid, m.from_me, m.body
+		FROM messages m
+		LEFT JOIN outbox o ON o.client_msg_id = m.msg_id
+		WHERE m.chat_jid = ? AND m.msg_id = ?`, chatJID, msgID).Scan(&q.Key.ID, &q.Key.SenderJID, &q.Key.FromMe, &q.Body)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if q.Key.FromMe {
+		q.Key.SenderJID = ""
+	}
+	return &q, nil
+}
+
+// loadAttachments fills what messages carry from other tables: reaction
+// counts and media metadata.
+func (db *DB) loadAttachments(msgs []*Message) error {
+	if err := db.loadReactions(msgs); err != nil {
+		return err
+	}
+	return db.loadMedia(msgs)
+}
+
+// ListRevisions returns the prior bodies of a message, oldest first.
+func (db *DB) ListRevisions(chatJID, msgID string) ([]MessageRevision, error) {
 	rows, err := db.Query(`
+		SELECT chat_jid, msg_id, body, revised_at
+		FROM message_revisions
+		WHERE chat_jid = ? AND msg_id = ?
+		ORDER BY id ASC`, chatJID, msgID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var revs []MessageRevision
+	for rows.Next() {
+		var r MessageRevision
+		if err := rows.Scan(&r.ChatJID, &r.MsgID, &r.Body, &r.RevisedAt); err != nil {
+			return nil, err
+		}
+		revs = append(revs, r)
+	}
+	return revs, rows.Err()
+}
+
+// messageSelect selects messages m with their sender name resolved via
+// LEFT JOIN to the contacts table and their reply context, in the column
+// order scanMessages expects.
+const messageSelect = `
 		SELECT m.id, m.chat_jid, m.msg_id, m.sender_jid,
 			COALESCE(NULLIF(m.sender_name,''), NULLIF(ct.push_name,''), NULLIF(ct.name,''), m.sender_jid) AS display_name,
-			m.body, m.message_type, m.from_me, m.status, m.timestamp
+			m.body, m.message_type, m.from_me, m.status, m.timestamp, m.edited_at, m.deleted,
+			` + quotedColumns + `
 		FROM messages m
-		LEFT JOIN contacts ct ON m.sender_jid = ct.jid
-		WHERE m.chat_jid = ? AND m.timestamp < ?
-		ORDER BY m.timestamp DESC
-		LIMIT ?`, chatJID, beforeTs, limit)
+		LEFT JOIN contacts ct ON m.sender_jid = ct.jid` + quotedJoin
+
+// ListMessages returns up to limit messages of a chat older than the
+// cursor, newest first. Sender names are resolved via LEFT JOIN to contacts table.
+func (db *DB) ListMessages(chatJID string, before Cursor, limit int) ([]Message, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	rows, err := db.Query(messageSelect+`
+		WHERE m.chat_jid = ?
+			AND (? OR m.timestamp < ? OR (m.timestamp = ? AND m.id < ?))
+		ORDER BY m.timestamp DESC, m.id DESC
+		LIMIT ?`, chatJID, before.IsZero(), before.Timestamp, before.Timestamp, before.ID, limit)
 	if err != nil {
 		return nil, err
 	}
+	return db.scanMessages(rows)
+}
+
+// GetMessage returns a single message by its msg_id, or nil if it is not
+// stored.
+func (db *DB) GetMessage(chatJID, msgID string) (*Message, error) {
+	rows, err := db.Query(messageSelect+`
+		WHERE m.chat_jid = ? AND m.msg_id = ?`, chatJID, msgID)
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := db.scanMessages(rows)
+	if err != nil || len(msgs) == 0 {
+		return nil, err
+	}
+	return &msgs[0], nil
+}
+
+// OldestMessage returns the oldest message of a chat that WhatsApp knows,
+// skipping our system notices and sends not yet made, or nil if there is
+// none. Older history is requested relative to it.
+func (db *DB) OldestMessage(chatJID string) (*Message, error) {
+	rows, err := db.Query(messageSelect+`
+		WHERE m.chat_jid = ? AND m.msg_id NOT LIKE 'system-%'
+			AND m.status NOT IN ('queued', 'sending', 'failed')
+		ORDER BY m.timestamp ASC, m.id ASC
+		LIMIT 1`, chatJID)
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := db.scanMessages(rows)
+	if err != nil || len(msgs) == 0 {
+		return nil, err
+	}
+	return &msgs[0], nil
+}
+
+// scanMessages reads the rows of a messageSelect query and loads their
+// attachments. It closes rows.
+func (db *DB) scanMessages(rows *sql.Rows) ([]Message, error) {
 	defer func() { _ = rows.Close() }()
 
 	var msgs []Message
 	for rows.Next() {
 		var m Message
-		if err := rows.Scan(&m.ID, &m.ChatJID, &m.MsgID, &m.SenderJID, &m.SenderName, &m.Body, &m.MessageType, &m.FromMe, &m.Status, &m.Timestamp); err != nil {
+		if err := rows.Scan(&m.ID, &m.ChatJID, &m.MsgID, &m.SenderJID, &m.SenderName, &m.Body, &m.MessageType, &m.FromMe, &m.Status, &m.Timestamp, &m.EditedAt, &m.Deleted, &m.QuotedMsgID, &m.QuotedSender, &m.QuotedBody); err != nil {
 			return nil, err
 		}
 		msgs = append(msgs, m)
 	}
-	return msgs, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*Message, len(msgs))
+	for i := range msgs {
+		ptrs[i] = &msgs[i]
+	}
+	if err := db.loadAttachments(ptrs); err != nil {
+		return nil, err
+	}
+	return msgs, nil
 }
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS messages_revision_au;
+DROP INDEX IF EXISTS idx_message_revisions_msg;
+DROP TABLE IF EXISTS message_revisions;
+ALTER TABLE messages DROP COLUMN deleted;
+ALTER TABLE messages DROP COLUMN edited_at;
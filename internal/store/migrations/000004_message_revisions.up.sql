@@ -0,0 +1,18 @@
+ALTER TABLE messages ADD COLUMN edited_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE messages ADD COLUMN deleted INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS message_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    chat_jid TEXT NOT NULL,
+    msg_id TEXT NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    revised_at INTEGER NOT NULL DEFAULT (strftime('%s','now') * 1000)
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_revisions_msg ON message_revisions(chat_jid, msg_id);
+
+-- Keep the prior body whenever a message body is replaced (edit or revoke).
+CREATE TRIGGER IF NOT EXISTS messages_revision_au AFTER UPDATE OF body ON messages
+WHEN old.body != new.body AND old.body != '' BEGIN
+    INSERT INTO message_revisions (chat_jid, msg_id, body) VALUES (old.chat_jid, old.msg_id, old.body);
+END;
@@ -0,0 +1,3 @@
+ALTER TABLE outbox DROP COLUMN target_msg_id;
+ALTER TABLE outbox DROP COLUMN kind;
+DROP TABLE IF EXISTS reactions;
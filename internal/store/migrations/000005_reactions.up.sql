@@ -0,0 +1,16 @@
+-- One reaction per sender per message. Our own reactions use sender_jid ''
+-- so every linked device (and this daemon) updates the same row.
+CREATE TABLE IF NOT EXISTS reactions (
+    chat_jid TEXT NOT NULL,
+    msg_id TEXT NOT NULL,
+    sender_jid TEXT NOT NULL DEFAULT '',
+    emoji TEXT NOT NULL,
+    from_me INTEGER NOT NULL DEFAULT 0,
+    timestamp INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (chat_jid, msg_id, sender_jid)
+);
+
+-- The outbox carries more than text: kind selects how an entry is sent and
+-- target_msg_id names the message a reaction applies to.
+ALTER TABLE outbox ADD COLUMN kind TEXT NOT NULL DEFAULT 'text';
+ALTER TABLE outbox ADD COLUMN target_msg_id TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE messages DROP COLUMN quoted_sender;
+ALTER TABLE messages DROP COLUMN quoted_msg_id;
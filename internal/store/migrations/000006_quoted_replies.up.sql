@@ -0,0 +1,3 @@
+-- Reply context: the WhatsApp ID and sender of the message being quoted.
+ALTER TABLE messages ADD COLUMN quoted_msg_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE messages ADD COLUMN quoted_sender TEXT NOT NULL DEFAULT '';
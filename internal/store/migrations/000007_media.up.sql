@@ -0,0 +1,60 @@
+CREATE TABLE IF NOT EXISTS media (
+    chat_jid TEXT NOT NULL,
+    msg_id TEXT NOT NULL,
+    media_type TEXT NOT NULL,
+    mimetype TEXT NOT NULL DEFAULT '',
+    file_length INTEGER NOT NULL DEFAULT 0,
+    file_name TEXT NOT NULL DEFAULT '',
+    caption TEXT NOT NULL DEFAULT '',
+    direct_path TEXT NOT NULL DEFAULT '',
+    media_key BLOB,
+    file_sha256 BLOB,
+    file_enc_sha256 BLOB,
+    thumbnail BLOB,
+    local_path TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT (strftime('%s','now') * 1000),
+    PRIMARY KEY (chat_jid, msg_id)
+);
+
+-- Rebuild the search index with its own content so document file names,
+-- which live in media, are searchable alongside message bodies (captions
+-- are stored as the body).
+DROP TRIGGER IF EXISTS messages_ai;
+DROP TRIGGER IF EXISTS messages_ad;
+DROP TRIGGER IF EXISTS messages_au;
+DROP TABLE IF EXISTS messages_fts;
+
+CREATE VIRTUAL TABLE messages_fts USING fts5(body, file_name);
+
+INSERT INTO messages_fts(rowid, body, file_name)
+SELECT m.id, m.body, COALESCE(md.file_name, '')
+FROM messages m
+LEFT JOIN media md ON md.chat_jid = m.chat_jid AND md.msg_id = m.msg_id;
+
+CREATE TRIGGER IF NOT EXISTS messages_ai AFTER INSERT ON messages BEGIN
+    INSERT INTO messages_fts(rowid, body, file_name) VALUES (new.id, new.body,
+        COALESCE((SELECT file_name FROM media WHERE chat_jid = new.chat_jid AND msg_id = new.msg_id), ''));
+END;
+
+CREATE TRIGGER IF NOT EXISTS messages_ad AFTER DELETE ON messages BEGIN
+    DELETE FROM messages_fts WHERE rowid = old.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS messages_au AFTER UPDATE OF body ON messages BEGIN
+    UPDATE messages_fts SET body = new.body WHERE rowid = new.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS media_ai AFTER INSERT ON media BEGIN
+    UPDATE messages_fts SET file_name = new.file_name
+    WHERE rowid = (SELECT id FROM messages WHERE chat_jid = new.chat_jid AND msg_id = new.msg_id);
+END;
+
+CREATE TRIGGER IF NOT EXISTS media_au AFTER UPDATE OF file_name ON media BEGIN
+    UPDATE messages_fts SET file_name = new.file_name
+    WHERE rowid = (SELECT id FROM messages WHERE chat_jid = new.chat_jid AND msg_id = new.msg_id);
+END;
+
+CREATE TRIGGER IF NOT EXISTS media_ad AFTER DELETE ON media BEGIN
+    UPDATE messages_fts SET file_name = ''
+    WHERE rowid = (SELECT id FROM messages WHERE chat_jid = old.chat_jid AND msg_id = old.msg_id);
+END;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS group_participants;
+DROP TABLE IF EXISTS groups;
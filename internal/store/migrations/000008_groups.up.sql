@@ -0,0 +1,18 @@
+-- Group metadata. Participants are kept in full on connect and updated
+-- incrementally from group notifications.
+CREATE TABLE IF NOT EXISTS groups (
+    jid TEXT PRIMARY KEY,
+    name TEXT NOT NULL DEFAULT '',
+    topic TEXT NOT NULL DEFAULT '',
+    owner_jid TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT 0,
+    updated_at INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS group_participants (
+    group_jid TEXT NOT NULL,
+    jid TEXT NOT NULL,
+    is_admin INTEGER NOT NULL DEFAULT 0,
+    is_super_admin INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (group_jid, jid)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE chats DROP COLUMN pinned_at;
+ALTER TABLE chats DROP COLUMN archived;
@@ -0,0 +1,4 @@
+-- Chat list flags kept in sync with WhatsApp app state: pinned_at is when
+-- the chat was pinned, 0 when it is not.
+ALTER TABLE chats ADD COLUMN archived INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE chats ADD COLUMN pinned_at INTEGER NOT NULL DEFAULT 0;
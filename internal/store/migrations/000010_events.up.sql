@@ -0,0 +1,14 @@
+-- Append-only journal of the events streamed to clients. Watch* RPCs
+-- replay from an event id cursor before tailing live events. Old rows
+-- are pruned by the daemon according to the configured retention.
+CREATE TABLE IF NOT EXISTS events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL,
+    chat_jid TEXT NOT NULL DEFAULT '',
+    correlation_id TEXT NOT NULL DEFAULT '',
+    payload BLOB,
+    occurred_at INTEGER NOT NULL,
+    created_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_created_at ON events(created_at);
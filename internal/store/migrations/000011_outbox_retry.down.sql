@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_outbox_due;
+UPDATE outbox SET status = 'failed' WHERE status IN ('dead', 'cancelled');
+ALTER TABLE outbox DROP COLUMN next_attempt_at;
+ALTER TABLE outbox DROP COLUMN attempts;
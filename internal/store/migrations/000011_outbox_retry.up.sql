@@ -0,0 +1,8 @@
+-- Failed sends are retried with backoff until they run out of attempts.
+ALTER TABLE outbox ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE outbox ADD COLUMN next_attempt_at INTEGER NOT NULL DEFAULT 0;
+
+-- Sends that failed before retries existed will never be tried again.
+UPDATE outbox SET status = 'dead' WHERE status = 'failed';
+
+CREATE INDEX IF NOT EXISTS idx_outbox_due ON outbox(status, next_attempt_at);
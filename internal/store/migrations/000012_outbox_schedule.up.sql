@@ -0,0 +1,4 @@
+-- Scheduled sends stay queued until send_at (Unix ms); 0 sends right away.
+ALTER TABLE outbox ADD COLUMN send_at INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_scheduled ON outbox(status, send_at);
@@ -0,0 +1,2 @@
+-- Voice notes are audio attachments played inline rather than as files.
+ALTER TABLE media ADD COLUMN voice INTEGER NOT NULL DEFAULT 0;
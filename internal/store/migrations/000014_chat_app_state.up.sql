@@ -0,0 +1,3 @@
+-- When a chat's mute from WhatsApp app state ends: 0 when not muted, -1
+-- when muted indefinitely.
+ALTER TABLE chats ADD COLUMN muted_until INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- The text a reply carries of the message it quotes, shown when that
+-- message is not stored.
+ALTER TABLE messages ADD COLUMN quoted_body TEXT NOT NULL DEFAULT '';
@@ -1,10 +1,23 @@
 package store
 
 import (
+	"cmp"
+	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 )
 
+// ErrOutboxState is returned by RetryOutbox and CancelOutbox for an entry
+// that is being sent, was sent, or was cancelled, and by MarkOutboxSending
+// for one that is not queued.
+var ErrOutboxState = errors.New("outbox entry is not queued or dead")
+
+const outboxSelect = `
+	SELECT id, client_msg_id, chat_jid, body, status, error_message, server_msg_id, kind, target_msg_id,
+		attempts, next_attempt_at, send_at
+	FROM outbox`
+
 // QueueOutbox adds a message to the send outbox only (without creating a message row).
 func (db *DB) QueueOutbox(clientMsgID, chatJID, body string) error {
 	now := time.Now().UnixMilli()
@@ -17,7 +30,26 @@ func (db *DB) QueueOutbox(clientMsgID, chatJID, body string) error {
 
 // QueueOutboxWithMessage atomically inserts into both outbox and messages tables.
 // The message is immediately visible in the TUI with status 'queued'.
-func (db *DB) QueueOutboxWithMessage(clientMsgID, chatJID, body string) error {
+// waMsgID is the WhatsApp message ID assigned ahead of sending, kept as
+// server_msg_id so that every attempt sends the same message; empty lets
+// WhatsApp assign one. A non-empty replyToMsgID is the local msg_id of the
+// message being quoted.
+func (db *DB) QueueOutboxWithMessage(clientMsgID, waMsgID, chatJID, body, replyToMsgID string) error {
+	return db.queueWithMessage(clientMsgID, waMsgID, chatJID, body, replyToMsgID, nil)
+}
+
+// QueueMedia queues an attachment like QueueOutboxWithMessage, with m's
+// caption as body. The media row, keyed by clientMsgID, must have
+// LocalPath set to the file to upload.
+func (db *DB) QueueMedia(clientMsgID, waMsgID, chatJID, replyToMsgID string, m *Media) error {
+	return db.queueWithMessage(clientMsgID, waMsgID, chatJID, m.Caption, replyToMsgID, m)
+}
+
+func (db *DB) queueWithMessage(clientMsgID, waMsgID, chatJID, body, replyToMsgID string, m *Media) error {
+	kind, messageType := "text", "text"
+	if m != nil {
+		kind, messageType = "media", m.MediaType
+	}
 	now := time.Now().UnixMilli()
 	tx, err := db.Begin()
 	if err != nil {
@@ -26,51 +58,211 @@ func (db *DB) QueueOutboxWithMessage(clientMsgID, chatJID, body string) error {
 	defer func() { _ = tx.Rollback() }()
 
 	if _, err := tx.Exec(`
-		INSERT INTO outbox (client_msg_id, chat_jid, body, status, created_at, updated_at)
-		VALUES (?, ?, ?, 'queued', ?, ?)`,
-		clientMsgID, chatJID, body, now, now); err != nil {
+		INSERT INTO outbox (client_msg_id, server_msg_id, chat_jid, body, kind, target_msg_id, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, 'queued', ?, ?)`,
+		clientMsgID, waMsgID, chatJID, body, kind, replyToMsgID, now, now); err != nil {
 		return fmt.Errorf("insert outbox: %w", err)
 	}
 
+	var quotedSender, quotedBody string
+	if replyToMsgID != "" {
+		err := tx.QueryRow(`SELECT sender_jid, body FROM messages WHERE chat_jid = ? AND msg_id = ?`, chatJID, replyToMsgID).Scan(&quotedSender, &quotedBody)
+		if err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("get quoted message: %w", err)
+		}
+	}
+
 	if _, err := tx.Exec(`
-		INSERT INTO messages (chat_jid, msg_id, sender_jid, sender_name, body, message_type, from_me, status, timestamp, created_at)
-		VALUES (?, ?, '', '', ?, 'text', 1, 'queued', ?, ?)
+		INSERT INTO messages (chat_jid, msg_id, sender_jid, sender_name, body, message_type, from_me, status, timestamp, quoted_msg_id, quoted_sender, quoted_body, created_at)
+		VALUES (?, ?, '', '', ?, ?, 1, 'queued', ?, ?, ?, ?, ?)
 		ON CONFLICT(chat_jid, msg_id) DO UPDATE SET
 			body = excluded.body,
 			status = excluded.status`,
-		chatJID, clientMsgID, body, now, now); err != nil {
+		chatJID, clientMsgID, body, messageType, now, replyToMsgID, quotedSender, quotedBody, now); err != nil {
 		return fmt.Errorf("insert message: %w", err)
 	}
 
+	if m != nil {
+		if _, err := tx.Exec(`
+			INSERT OR REPLACE INTO media (chat_jid, msg_id, media_type, mimetype, file_length, file_name, caption,
+				thumbnail, voice, local_path, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			chatJID, clientMsgID, m.MediaType, m.Mimetype, m.FileLength, m.FileName, m.Caption,
+			m.Thumbnail, m.Voice, m.LocalPath, now); err != nil {
+			return fmt.Errorf("insert media: %w", err)
+		}
+	}
+
 	return tx.Commit()
 }
 
-// MarkOutboxSending updates an outbox entry to 'sending' status.
-func (db *DB) MarkOutboxSending(clientMsgID string) error {
+// ScheduleOutbox adds a text to the send outbox to be sent at sendAt (Unix
+// ms). Arguments are as for QueueOutboxWithMessage, but no message row is
+// created until the sender picks the entry up, so the message takes its
+// place in the chat when it goes out.
+func (db *DB) ScheduleOutbox(clientMsgID, waMsgID, chatJID, body, replyToMsgID string, sendAt int64) error {
+	now := time.Now().UnixMilli()
+	_, err := db.Exec(`
+		INSERT INTO outbox (client_msg_id, server_msg_id, chat_jid, body, target_msg_id, status, send_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, 'queued', ?, ?, ?)`,
+		clientMsgID, waMsgID, chatJID, body, replyToMsgID, sendAt, now, now)
+	return err
+}
+
+// QueueReaction adds a reaction to the send outbox. The emoji is kept in body
+// (empty removes our reaction) and targetMsgID is the local msg_id of the
+// message reacted to. waMsgID is assigned ahead of sending as for
+// QueueOutboxWithMessage. No message row is created; the reaction is
+// recorded once WhatsApp accepts it.
+func (db *DB) QueueReaction(clientMsgID, waMsgID, chatJID, targetMsgID, emoji string) error {
+	now := time.Now().UnixMilli()
+	_, err := db.Exec(`
+		INSERT INTO outbox (client_msg_id, server_msg_id, chat_jid, body, kind, target_msg_id, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, 'reaction', ?, 'queued', ?, ?)`,
+		clientMsgID, waMsgID, chatJID, emoji, targetMsgID, now, now)
+	return err
+}
+
+// QueueMessageChange adds an edit or revoke of one of our messages to the
+// send outbox. c.MsgID is the local msg_id of the message and c.Body the
+// new text of an edit; the change is kept in target_msg_id and body.
+// waMsgID is assigned ahead of sending as for QueueOutboxWithMessage. The
+// message is changed locally once WhatsApp accepts the change.
+func (db *DB) QueueMessageChange(clientMsgID, waMsgID string, c *MessageChange) error {
+	if c.Kind != "edit" && c.Kind != "revoke" {
+		return fmt.Errorf("unknown message change kind %q", c.Kind)
+	}
 	now := time.Now().UnixMilli()
-	_, err := db.Exec(`UPDATE outbox SET status = 'sending', updated_at = ? WHERE client_msg_id = ?`, now, clientMsgID)
+	_, err := db.Exec(`
+		INSERT INTO outbox (client_msg_id, server_msg_id, chat_jid, body, kind, target_msg_id, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, 'queued', ?, ?)`,
+		clientMsgID, waMsgID, c.ChatJID, c.Body, c.Kind, c.MsgID, now, now)
 	return err
 }
 
+// MarkOutboxSending claims a queued outbox entry for sending and counts the
+// attempt. Returns ErrOutboxState if the entry is no longer queued: it was
+// cancelled, or found sent by ReconcileOutbox.
+func (db *DB) MarkOutboxSending(clientMsgID string) error {
+	now := time.Now().UnixMilli()
+	result, err := db.Exec(`
+		UPDATE outbox SET status = 'sending', attempts = attempts + 1, updated_at = ?
+		WHERE client_msg_id = ? AND status = 'queued'`, now, clientMsgID)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return cmp.Or(err, ErrOutboxState)
+	}
+	return nil
+}
+
 // MarkOutboxSent updates an outbox entry to 'sent' with the server message ID.
+// The message row of a text or attachment moves from its client_msg_id to
+// the server ID, the one WhatsApp and other devices know it by, and becomes
+// 'sent' unless a receipt already moved it further.
 func (db *DB) MarkOutboxSent(clientMsgID, serverMsgID string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var e OutboxEntry
+	err = tx.QueryRow(`
+		UPDATE outbox SET status = 'sent', server_msg_id = ?, updated_at = ?
+		WHERE client_msg_id = ?
+		RETURNING chat_jid, kind`, serverMsgID, time.Now().UnixMilli(), clientMsgID).Scan(&e.ChatJID, &e.Kind)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("update outbox: %w", err)
+	}
+	if e.HasMessage() && serverMsgID != "" {
+		if err := rekeyMessage(tx, e.ChatJID, clientMsgID, serverMsgID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`
+			UPDATE messages SET status = 'sent'
+			WHERE chat_jid = ? AND msg_id = ? AND `+statusRank("status")+` < `+statusRank("'sent'"),
+			e.ChatJID, serverMsgID); err != nil {
+			return fmt.Errorf("update message: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// ReconcileOutbox handles a message from our own device seen on the wire
+// before its send was recorded, as after a crash between sending and
+// MarkOutboxSent: a pending text or media entry with that server ID is marked sent,
+// so it is not sent twice. Returns the entry's client_msg_id, or "" if no
+// pending entry has that ID.
+func (db *DB) ReconcileOutbox(chatJID, serverMsgID string) (string, error) {
+	var clientMsgID string
+	err := db.QueryRow(`
+		SELECT client_msg_id FROM outbox
+		WHERE chat_jid = ? AND server_msg_id = ? AND kind IN ('text', 'media') AND status IN ('queued', 'sending', 'dead')`,
+		chatJID, serverMsgID).Scan(&clientMsgID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return clientMsgID, db.MarkOutboxSent(clientMsgID, serverMsgID)
+}
+
+// rekeyMessage renames a message from one msg_id to another along with
+// everything keyed by it. If a row with the new ID exists already, the old
+// row is dropped in its favour.
+func rekeyMessage(tx *sql.Tx, chatJID, from, to string) error {
+	stmts := []string{
+		`UPDATE OR IGNORE messages SET msg_id = ?2 WHERE chat_jid = ?1 AND msg_id = ?3`,
+		`DELETE FROM messages WHERE chat_jid = ?1 AND msg_id = ?3`,
+		`UPDATE OR IGNORE reactions SET msg_id = ?2 WHERE chat_jid = ?1 AND msg_id = ?3`,
+		`DELETE FROM reactions WHERE chat_jid = ?1 AND msg_id = ?3`,
+		`UPDATE OR IGNORE media SET msg_id = ?2 WHERE chat_jid = ?1 AND msg_id = ?3`,
+		`DELETE FROM media WHERE chat_jid = ?1 AND msg_id = ?3`,
+		`UPDATE message_revisions SET msg_id = ?2 WHERE chat_jid = ?1 AND msg_id = ?3`,
+		`UPDATE messages SET quoted_msg_id = ?2 WHERE chat_jid = ?1 AND quoted_msg_id = ?3`,
+		`UPDATE outbox SET target_msg_id = ?2 WHERE chat_jid = ?1 AND target_msg_id = ?3`,
+	}
+	for _, q := range stmts {
+		if _, err := tx.Exec(q, chatJID, to, from); err != nil {
+			return fmt.Errorf("rekey message: %w", err)
+		}
+	}
+	return nil
+}
+
+// MarkOutboxRetry puts a failed outbox entry back in the queue, to be sent
+// again no earlier than nextAttempt (Unix ms).
+func (db *DB) MarkOutboxRetry(clientMsgID, errMsg string, nextAttempt int64) error {
 	now := time.Now().UnixMilli()
-	_, err := db.Exec(`UPDATE outbox SET status = 'sent', server_msg_id = ?, updated_at = ? WHERE client_msg_id = ?`, serverMsgID, now, clientMsgID)
+	_, err := db.Exec(`UPDATE outbox SET status = 'queued', error_message = ?, next_attempt_at = ?, updated_at = ? WHERE client_msg_id = ?`, errMsg, nextAttempt, now, clientMsgID)
 	return err
 }
 
-// MarkOutboxFailed updates an outbox entry to 'failed' with an error message.
-func (db *DB) MarkOutboxFailed(clientMsgID, errMsg string) error {
+// MarkOutboxDead updates an outbox entry that ran out of attempts to 'dead'.
+// It stays there until RetryOutbox or CancelOutbox.
+func (db *DB) MarkOutboxDead(clientMsgID, errMsg string) error {
 	now := time.Now().UnixMilli()
-	_, err := db.Exec(`UPDATE outbox SET status = 'failed', error_message = ?, updated_at = ? WHERE client_msg_id = ?`, errMsg, now, clientMsgID)
+	_, err := db.Exec(`UPDATE outbox SET status = 'dead', error_message = ?, updated_at = ? WHERE client_msg_id = ?`, errMsg, now, clientMsgID)
 	return err
 }
 
-// PendingOutbox returns outbox entries that are still queued.
+// PendingOutbox returns the queued outbox entries that are due now, oldest
+// first. An entry is held back while an earlier entry of its chat waits for
+// a retry, so a chat's messages are never sent out of order. Entries
+// scheduled for later hold nothing back.
 func (db *DB) PendingOutbox() ([]OutboxEntry, error) {
-	rows, err := db.Query(`
-		SELECT id, client_msg_id, chat_jid, body, status, error_message, server_msg_id
-		FROM outbox WHERE status = 'queued' ORDER BY created_at ASC`)
+	now := time.Now().UnixMilli()
+	rows, err := db.Query(outboxSelect+` o
+		WHERE status = 'queued' AND next_attempt_at <= ? AND send_at <= ? AND NOT EXISTS (
+			SELECT 1 FROM outbox w
+			WHERE w.chat_jid = o.chat_jid AND w.status = 'queued' AND w.next_attempt_at > ? AND w.id < o.id)
+		ORDER BY created_at ASC, id ASC`, now, now, now)
 	if err != nil {
 		return nil, err
 	}
@@ -78,15 +270,139 @@ func (db *DB) PendingOutbox() ([]OutboxEntry, error) {
 
 	var entries []OutboxEntry
 	for rows.Next() {
-		var e OutboxEntry
-		if err := rows.Scan(&e.ID, &e.ClientMsgID, &e.ChatJID, &e.Body, &e.Status, &e.ErrorMessage, &e.ServerMsgID); err != nil {
+		e, err := scanOutbox(rows)
+		if err != nil {
 			return nil, err
 		}
-		entries = append(entries, e)
+		entries = append(entries, *e)
 	}
 	return entries, rows.Err()
 }
 
+// NextOutboxAttempt returns when the earliest queued entry waiting for a
+// retry or scheduled for later becomes due after the given time, in Unix
+// ms, or 0 if none is waiting. Passing the time taken before PendingOutbox
+// leaves no gap for an entry that comes due between the two.
+func (db *DB) NextOutboxAttempt(after int64) (int64, error) {
+	var next sql.NullInt64
+	err := db.QueryRow(`SELECT MIN(MAX(next_attempt_at, send_at)) FROM outbox WHERE status = 'queued' AND MAX(next_attempt_at, send_at) > ?`,
+		after).Scan(&next)
+	return next.Int64, err
+}
+
+// ListScheduledOutbox returns the texts scheduled for later that are still
+// queued, soonest first, in chatJID or in every chat when it is empty.
+func (db *DB) ListScheduledOutbox(chatJID string) ([]OutboxEntry, error) {
+	rows, err := db.Query(outboxSelect+`
+		WHERE status = 'queued' AND send_at > ? AND (? = '' OR chat_jid = ?)
+		ORDER BY send_at ASC, id ASC`, time.Now().UnixMilli(), chatJID, chatJID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var entries []OutboxEntry
+	for rows.Next() {
+		e, err := scanOutbox(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *e)
+	}
+	return entries, rows.Err()
+}
+
+// GetOutbox returns the outbox entry of clientMsgID, or nil if there is none.
+func (db *DB) GetOutbox(clientMsgID string) (*OutboxEntry, error) {
+	return scanOutboxRow(db.QueryRow(outboxSelect+` WHERE client_msg_id = ?`, clientMsgID))
+}
+
+// RetryOutbox queues a dead or queued entry to be sent right away with a
+// fresh attempt budget, even if it was scheduled for later. Its message
+// row, if any, goes back to 'queued'.
+// Returns nil if there is no such entry and ErrOutboxState if it is being
+// sent, was sent or was cancelled.
+func (db *DB) RetryOutbox(clientMsgID string) (*OutboxEntry, error) {
+	return db.updateSettledOutbox(clientMsgID, func(tx *sql.Tx, e *OutboxEntry, now int64) error {
+		if _, err := tx.Exec(`
+			UPDATE outbox SET status = 'queued', attempts = 0, next_attempt_at = 0, send_at = 0, error_message = '', updated_at = ?
+			WHERE id = ?`, now, e.ID); err != nil {
+			return fmt.Errorf("update outbox: %w", err)
+		}
+		if e.HasMessage() {
+			if _, err := tx.Exec(`UPDATE messages SET status = 'queued' WHERE chat_jid = ? AND msg_id = ?`, e.ChatJID, e.ClientMsgID); err != nil {
+				return fmt.Errorf("update message: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// CancelOutbox drops a dead or queued entry so it is never sent, and
+// deletes its message and media rows, if any. Returns nil if there is no such entry
+// and ErrOutboxState if it is being sent, was sent or was cancelled.
+func (db *DB) CancelOutbox(clientMsgID string) (*OutboxEntry, error) {
+	return db.updateSettledOutbox(clientMsgID, func(tx *sql.Tx, e *OutboxEntry, now int64) error {
+		if _, err := tx.Exec(`UPDATE outbox SET status = 'cancelled', updated_at = ? WHERE id = ?`, now, e.ID); err != nil {
+			return fmt.Errorf("update outbox: %w", err)
+		}
+		if e.HasMessage() {
+			if _, err := tx.Exec(`DELETE FROM messages WHERE chat_jid = ? AND msg_id = ?`, e.ChatJID, e.ClientMsgID); err != nil {
+				return fmt.Errorf("delete message: %w", err)
+			}
+			if _, err := tx.Exec(`DELETE FROM media WHERE chat_jid = ? AND msg_id = ?`, e.ChatJID, e.ClientMsgID); err != nil {
+				return fmt.Errorf("delete media: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// updateSettledOutbox runs update in a transaction on the outbox entry of
+// clientMsgID if it is queued or dead, the states the sender is not
+// working on.
+func (db *DB) updateSettledOutbox(clientMsgID string, update func(tx *sql.Tx, e *OutboxEntry, now int64) error) (*OutboxEntry, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	e, err := scanOutboxRow(tx.QueryRow(outboxSelect+` WHERE client_msg_id = ?`, clientMsgID))
+	if err != nil || e == nil {
+		return nil, err
+	}
+	if e.Status != "queued" && e.Status != "dead" {
+		return e, ErrOutboxState
+	}
+	if err := update(tx, e, time.Now().UnixMilli()); err != nil {
+		return nil, err
+	}
+	return e, tx.Commit()
+}
+
+func scanOutbox(rows *sql.Rows) (*OutboxEntry, error) {
+	var e OutboxEntry
+	if err := rows.Scan(&e.ID, &e.ClientMsgID, &e.ChatJID, &e.Body, &e.Status, &e.ErrorMessage, &e.ServerMsgID, &e.Kind, &e.TargetMsgID,
+		&e.Attempts, &e.NextAttempt, &e.SendAt); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func scanOutboxRow(row *sql.Row) (*OutboxEntry, error) {
+	var e OutboxEntry
+	err := row.Scan(&e.ID, &e.ClientMsgID, &e.ChatJID, &e.Body, &e.Status, &e.ErrorMessage, &e.ServerMsgID, &e.Kind, &e.TargetMsgID,
+		&e.Attempts, &e.NextAttempt, &e.SendAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
 // RecoverOutbox resets any 'sending' entries back to 'queued' so they are retried.
 // Call on daemon startup to reclaim in-flight messages from a previous crash.
 func (db *DB) RecoverOutbox() (int64, error) {
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+)
+
+// localMsgID resolves a WhatsApp message ID to the msg_id stored locally:
+// messages sent from this device may keep their client_msg_id. Takes the
+// same ID twice as arguments.
+const localMsgID = `COALESCE((SELECT o.client_msg_id FROM outbox o
+	JOIN messages c ON c.chat_jid = o.chat_jid AND c.msg_id = o.client_msg_id
+	WHERE o.server_msg_id = ? AND o.kind IN ('text', 'media')), ?)`
+
+// ApplyReaction records, replaces or (for an empty emoji) removes a sender's
+// reaction to a message. Reactions older than the one stored are ignored.
+// Returns the local msg_id of the target message.
+func (db *DB) ApplyReaction(r *Reaction) (string, error) {
+	var msgID string
+	if err := db.QueryRow(`SELECT `+localMsgID, r.MsgID, r.MsgID).Scan(&msgID); err != nil {
+		return "", fmt.Errorf("resolve target: %w", err)
+	}
+	sender := r.SenderJID
+	if r.FromMe {
+		sender = ""
+	}
+
+	if r.Emoji == "" {
+		_, err := db.Exec(`
+			DELETE FROM reactions
+			WHERE chat_jid = ? AND msg_id = ? AND sender_jid = ? AND timestamp <= ?`,
+			r.ChatJID, msgID, sender, r.Timestamp)
+		return msgID, err
+	}
+
+	_, err := db.Exec(`
+		INSERT INTO reactions (chat_jid, msg_id, sender_jid, emoji, from_me, timestamp)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(chat_jid, msg_id, sender_jid) DO UPDATE SET
+			emoji = excluded.emoji,
+			timestamp = excluded.timestamp
+		WHERE excluded.timestamp >= reactions.timestamp`,
+		r.ChatJID, msgID, sender, r.Emoji, r.FromMe, r.Timestamp)
+	return msgID, err
+}
+
+// loadReactions fills the Reactions of each message with per-emoji counts,
+// in the order the emojis were first used.
+func (db *DB) loadReactions(msgs []*Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	type key struct{ chat, msg string }
+	byKey := make(map[key]*Message, len(msgs))
+	args := make([]any, 0, 2*len(msgs))
+	for _, m := range msgs {
+		byKey[key{m.ChatJID, m.MsgID}] = m
+		args = append(args, m.ChatJID, m.MsgID)
+	}
+	values := strings.TrimSuffix(strings.Repeat("(?, ?),", len(msgs)), ",")
+
+	rows, err := db.Query(`
+		SELECT chat_jid, msg_id, emoji, COUNT(*), MAX(from_me)
+		FROM reactions
+		WHERE (chat_jid, msg_id) IN (VALUES `+values+`)
+		GROUP BY chat_jid, msg_id, emoji
+		ORDER BY MIN(timestamp)`, args...)
+	if err != nil {
+		return fmt.Errorf("load reactions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var k key
+		var rc ReactionCount
+		if err := rows.Scan(&k.chat, &k.msg, &rc.Emoji, &rc.Count, &rc.FromMe); err != nil {
+			return err
+		}
+		if m := byKey[k]; m != nil {
+			m.Reactions = append(m.Reactions, rc)
+		}
+	}
+	return rows.Err()
+}
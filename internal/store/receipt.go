@@ -0,0 +1,59 @@
+package store
+
+import "fmt"
+
+// statusRank returns a SQL expression ordering outgoing message states, so
+// receipts only ever move a message forward. Other states (received, failed)
+// rank lowest, which lets a receipt override them.
+func statusRank(expr string) string {
+	return `(CASE ` + expr + `
+		WHEN 'queued' THEN 1
+		WHEN 'sending' THEN 2
+		WHEN 'sent' THEN 3
+		WHEN 'delivered' THEN 4
+		WHEN 'read' THEN 5
+		WHEN 'played' THEN 6
+		ELSE 0 END)`
+}
+
+// ApplyReceipt advances the status of our own messages named in a receipt.
+// IDs are matched through matchMsgID, so messages sent from this device are
+// found by their server ID. Status never moves backwards, so a late
+// "delivered" does not override "read". Returns the msg_ids that changed.
+func (db *DB) ApplyReceipt(r *Receipt) ([]string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	q := `
+		UPDATE messages SET status = ?
+		WHERE chat_jid = ? AND from_me = 1
+			AND ` + matchMsgID + `
+			AND ` + statusRank("status") + ` < ` + statusRank("?") + `
+		RETURNING msg_id`
+
+	var changed []string
+	for _, id := range r.MsgIDs {
+		rows, err := tx.Query(q, r.Status, r.ChatJID, id, id, r.Status)
+		if err != nil {
+			return nil, fmt.Errorf("apply receipt %q: %w", id, err)
+		}
+		for rows.Next() {
+			var msgID string
+			if err := rows.Scan(&msgID); err != nil {
+				_ = rows.Close()
+				return nil, err
+			}
+			changed = append(changed, msgID)
+		}
+		if err := rows.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+	return changed, nil
+}
@@ -1,25 +1,44 @@
 package store
 
-// SearchMessages performs a full-text search on message bodies.
-func (db *DB) SearchMessages(query string, chatJID string, limit int) ([]SearchResult, error) {
+import "strings"
+
+// SearchMessages returns up to limit messages matching query older than
+// the cursor, newest first. Free text is matched against the full-text
+// index of message bodies and file names; a query with operators only
+// scans messages and uses the body as the snippet.
+func (db *DB) SearchMessages(query SearchQuery, chatJID string, before Cursor, limit int) ([]SearchResult, error) {
 	if limit <= 0 {
 		limit = 50
 	}
 
-	q := `
-		SELECT m.id, m.chat_jid, m.msg_id, m.sender_jid, m.sender_name, m.body,
-		       m.message_type, m.from_me, m.status, m.timestamp,
-		       snippet(messages_fts, 0, '<<', '>>', '...', 32)
-		FROM messages_fts f
-		JOIN messages m ON m.id = f.rowid
-		WHERE messages_fts MATCH ?`
-
-	args := []any{query}
+	conds, args := query.where()
+	conds = append(conds, "m.deleted = 0")
+	from := "messages m"
+	snippet := "m.body"
+	if match := query.match(); match != "" {
+		from = "messages_fts f JOIN messages m ON m.id = f.rowid"
+		snippet = "snippet(messages_fts, -1, '<<', '>>', '...', 32)"
+		conds = append([]string{"messages_fts MATCH ?"}, conds...)
+		args = append([]any{match}, args...)
+	} else if len(query.Terms) > 0 {
+		// Only punctuation was typed: nothing can match.
+		return nil, nil
+	}
 	if chatJID != "" {
-		q += " AND m.chat_jid = ?"
+		conds = append(conds, "m.chat_jid = ?")
 		args = append(args, chatJID)
 	}
-	q += " ORDER BY rank LIMIT ?"
+	conds = append(conds, "(? OR m.timestamp < ? OR (m.timestamp = ? AND m.id < ?))")
+	args = append(args, before.IsZero(), before.Timestamp, before.Timestamp, before.ID)
+
+	q := `
+		SELECT m.id, m.chat_jid, m.msg_id, m.sender_jid, m.sender_name, m.body,
+		       m.message_type, m.from_me, m.status, m.timestamp, m.edited_at, m.deleted,
+		       ` + quotedColumns + `,
+		       ` + snippet + `
+		FROM ` + from + quotedJoin + `
+		WHERE ` + strings.Join(conds, " AND ")
+	q += " ORDER BY m.timestamp DESC, m.id DESC LIMIT ?"
 	args = append(args, limit)
 
 	rows, err := db.Query(q, args...)
@@ -35,11 +54,23 @@ func (db *DB) SearchMessages(query string, chatJID string, limit int) ([]SearchR
 			&r.Message.ID, &r.Message.ChatJID, &r.Message.MsgID,
 			&r.Message.SenderJID, &r.Message.SenderName, &r.Message.Body,
 			&r.Message.MessageType, &r.Message.FromMe, &r.Message.Status,
-			&r.Message.Timestamp, &r.Snippet,
+			&r.Message.Timestamp, &r.Message.EditedAt, &r.Message.Deleted,
+			&r.Message.QuotedMsgID, &r.Message.QuotedSender, &r.Message.QuotedBody, &r.Snippet,
 		); err != nil {
 			return nil, err
 		}
 		results = append(results, r)
 	}
-	return results, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*Message, len(results))
+	for i := range results {
+		ptrs[i] = &results[i].Message
+	}
+	if err := db.loadAttachments(ptrs); err != nil {
+		return nil, err
+	}
+	return results, nil
 }
@@ -0,0 +1,172 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SearchQuery is a parsed message search. Free text goes to the full-text
+// index; operators narrow the results with plain SQL conditions.
+type SearchQuery struct {
+	Terms    []string // free-text words and quoted phrases, all required
+	From     []string // sender jid or name substring; "me" for own messages
+	In       []string // chat jid or display name substring
+	Before   int64    // exclusive upper bound on timestamp, in Unix ms
+	After    int64    // inclusive lower bound on timestamp, in Unix ms
+	HasLink  bool
+	HasMedia bool
+	Type     string // message_type
+}
+
+// searchTypes are the message types accepted by type:.
+var searchTypes = map[string]bool{
+	"text": true, "image": true, "video": true, "audio": true, "document": true,
+	"sticker": true, "contact": true, "location": true, "system": true,
+}
+
+// ParseSearchQuery parses the message search language: whitespace-separated
+// terms that must all match.
+//
+//	from:alice from:5511...@s.whatsapp.net from:me
+//	in:family             chat jid or display name contains family
+//	after:2026-01-01      sent on or after that day (local time)
+//	before:2026-02-01     sent before that day (local time)
+//	has:link has:media
+//	type:image            message type (text, image, video, audio, ...)
+//	foo "foo bar" foo*    words, phrases and prefixes of the text
+//
+// Values may be double-quoted to include spaces, as in in:"book club";
+// an unterminated quote runs to the end of the query.
+// Words that look like operators but are not (10:30, re:) are searched as
+// text. Free text is escaped, so quotes and punctuation never reach FTS5
+// as syntax.
+func ParseSearchQuery(s string) (SearchQuery, error) {
+	var q SearchQuery
+	terms, err := splitTerms(s)
+	if err != nil {
+		// A stray quote closes at the end rather than failing the search.
+		if terms, err = splitTerms(s + `"`); err != nil {
+			return q, err
+		}
+	}
+	for _, term := range terms {
+		key, value, _ := strings.Cut(term, ":")
+		value = unquote(value)
+		if value == "" {
+			q.Terms = append(q.Terms, term)
+			continue
+		}
+		switch key {
+		case "from":
+			q.From = append(q.From, value)
+		case "in":
+			q.In = append(q.In, value)
+		case "before", "after":
+			day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+			if err != nil {
+				return q, fmt.Errorf("%s: want YYYY-MM-DD, got %q", key, value)
+			}
+			if key == "before" {
+				q.Before = day.UnixMilli()
+			} else {
+				q.After = day.UnixMilli()
+			}
+		case "has":
+			switch value {
+			case "link":
+				q.HasLink = true
+			case "media":
+				q.HasMedia = true
+			default:
+				return q, fmt.Errorf("unknown operator %q", term)
+			}
+		case "type":
+			if !searchTypes[value] {
+				return q, fmt.Errorf("unknown message type %q", value)
+			}
+			q.Type = value
+		default:
+			q.Terms = append(q.Terms, term)
+		}
+	}
+	if q.IsZero() {
+		return q, fmt.Errorf("empty search query")
+	}
+	return q, nil
+}
+
+// IsZero reports whether q has neither text nor operators.
+func (q SearchQuery) IsZero() bool {
+	return len(q.Terms) == 0 && len(q.From) == 0 && len(q.In) == 0 &&
+		q.Before == 0 && q.After == 0 && !q.HasLink && !q.HasMedia && q.Type == ""
+}
+
+// match returns the FTS5 MATCH expression of the free text, or "" when
+// there is none. Every word or phrase becomes a quoted FTS5 string, with
+// a trailing '*' kept as a prefix query. Terms with no letters or digits
+// are dropped, since the tokenizer would reduce them to nothing.
+func (q SearchQuery) match() string {
+	var parts []string
+	for _, term := range q.Terms {
+		prefix := strings.HasSuffix(term, "*")
+		text := unquote(strings.TrimSuffix(term, "*"))
+		if !strings.ContainsFunc(text, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsNumber(r) }) {
+			continue
+		}
+		part := `"` + text + `"`
+		if prefix {
+			part += "*"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
+
+// where returns the SQL conditions and arguments of the operators of q
+// over the messages m of SearchMessages.
+func (q SearchQuery) where() ([]string, []any) {
+	var conds []string
+	var args []any
+	for _, from := range q.From {
+		switch {
+		case from == "me":
+			conds = append(conds, "m.from_me = 1")
+		case strings.Contains(from, "@"):
+			conds = append(conds, "m.sender_jid = ?")
+			args = append(args, from)
+		default:
+			conds = append(conds, `(m.sender_name LIKE ? ESCAPE '\' OR m.sender_jid IN (
+				SELECT jid FROM contacts WHERE push_name LIKE ? ESCAPE '\' OR name LIKE ? ESCAPE '\'))`)
+			args = append(args, likePattern(from), likePattern(from), likePattern(from))
+		}
+	}
+	for _, in := range q.In {
+		conds = append(conds, `m.chat_jid IN (
+			SELECT c.jid FROM chats c
+			LEFT JOIN contacts ct ON c.jid = ct.jid
+			LEFT JOIN groups g ON c.jid = g.jid
+			WHERE c.jid = ? OR `+chatDisplayName+` LIKE ? ESCAPE '\')`)
+		args = append(args, in, likePattern(in))
+	}
+	if q.Before > 0 {
+		conds = append(conds, "m.timestamp < ?")
+		args = append(args, q.Before)
+	}
+	if q.After > 0 {
+		conds = append(conds, "m.timestamp >= ?")
+		args = append(args, q.After)
+	}
+	if q.HasLink {
+		conds = append(conds, "(m.body LIKE '%http://%' OR m.body LIKE '%https://%' OR m.body LIKE '%www.%')")
+	}
+	if q.HasMedia {
+		conds = append(conds, "EXISTS (SELECT 1 FROM media md WHERE md.chat_jid = m.chat_jid AND md.msg_id = m.msg_id)")
+	}
+	if q.Type != "" {
+		conds = append(conds, "m.message_type = ?")
+		args = append(args, q.Type)
+	}
+	return conds, args
+}
@@ -1,8 +1,11 @@
 package store
 
 import (
+	"errors"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func testDB(t *testing.T) *DB {
@@ -19,6 +22,15 @@ func testDB(t *testing.T) *DB {
 	return db
 }
 
+func searchQuery(t *testing.T, s string) SearchQuery {
+	t.Helper()
+	q, err := ParseSearchQuery(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return q
+}
+
 func TestMigrateAppliesOnFreshDB(t *testing.T) {
 	db := testDB(t)
 
@@ -31,8 +43,8 @@ func TestMigrateAppliesOnFreshDB(t *testing.T) {
 	if result.Changed {
 		t.Error("second Migrate() should report Changed=false")
 	}
-	if result.Version != 3 {
-		t.Errorf("version = %d, want 3 (init + fts + lid_map)", result.Version)
+	if result.Version != 15 {
+		t.Errorf("version = %d, want 15 (init + fts + lid_map + message_revisions + reactions + quoted_replies + media + groups + chat_flags + events + outbox_retry + outbox_schedule + media_voice + chat_app_state + quoted_body)", result.Version)
 	}
 }
 
@@ -89,7 +101,7 @@ func TestChatUpsertAndList(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	chats, err := db.ListChats(10, 0)
+	chats, err := db.ListChats(10, Cursor{}, ChatFilter{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -142,7 +154,7 @@ func TestMessageUpsertIdempotent(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	msgs, err := db.ListMessages("chat@s", 0, 100)
+	msgs, err := db.ListMessages("chat@s", Cursor{}, 100)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -154,6 +166,145 @@ func TestMessageUpsertIdempotent(t *testing.T) {
 	}
 }
 
+func TestGetMessage(t *testing.T) {
+	db := testDB(t)
+
+	if err := db.UpsertChat(&Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpsertContact(&Contact{JID: "alice@s", PushName: "Alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpsertMessage(&Message{ChatJID: "chat@s", MsgID: "m1", SenderJID: "alice@s", Body: "hi", MessageType: "text", Timestamp: 1000}); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := db.GetMessage("chat@s", "m1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m == nil || m.Body != "hi" || m.SenderName != "Alice" {
+		t.Errorf("GetMessage = %+v, want m1 from Alice", m)
+	}
+	if m, err := db.GetMessage("chat@s", "missing"); err != nil || m != nil {
+		t.Errorf("GetMessage(missing) = %+v, %v; want nil, nil", m, err)
+	}
+}
+
+func TestOldestMessage(t *testing.T) {
+	db := testDB(t)
+
+	if m, err := db.OldestMessage("chat@s"); err != nil || m != nil {
+		t.Fatalf("OldestMessage(empty) = %+v, %v; want nil, nil", m, err)
+	}
+	if err := db.UpsertChat(&Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*Message{
+		{ChatJID: "chat@s", MsgID: "system-500-joined-a@s", Body: "A joined", MessageType: "system", Status: "received", Timestamp: 500},
+		{ChatJID: "chat@s", MsgID: "queued", Body: "unsent", MessageType: "text", FromMe: true, Status: "queued", Timestamp: 600},
+		{ChatJID: "chat@s", MsgID: "m1", Body: "first", MessageType: "text", Status: "received", Timestamp: 1000},
+		{ChatJID: "chat@s", MsgID: "m2", Body: "second", MessageType: "text", Status: "received", Timestamp: 2000},
+	} {
+		if err := db.UpsertMessage(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m, err := db.OldestMessage("chat@s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m == nil || m.MsgID != "m1" {
+		t.Errorf("OldestMessage = %+v, want m1 (system notices and unsent messages skipped)", m)
+	}
+}
+
+func TestClearChat(t *testing.T) {
+	db := testDB(t)
+
+	if err := db.UpsertChat(&Chat{JID: "chat@s", UnreadCount: 2, LastMessagePreview: "second"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*Message{
+		{ChatJID: "chat@s", MsgID: "m1", Body: "first", MessageType: "text", Status: "received", Timestamp: 1000},
+		{ChatJID: "chat@s", MsgID: "m2", Body: "second", MessageType: "text", Status: "received", Timestamp: 2000},
+		{ChatJID: "chat@s", MsgID: "queued", Body: "unsent", MessageType: "text", FromMe: true, Status: "queued", Timestamp: 1500},
+	} {
+		if err := db.UpsertMessage(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exists := func(msgID string) bool {
+		t.Helper()
+		ok, err := db.MessageExists("chat@s", msgID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ok
+	}
+
+	// Deleting up to m1 keeps the chat, which still has messages.
+	if deleted, err := db.ClearChat(&ChatClear{ChatJID: "chat@s", Before: 1000, Delete: true}); err != nil || deleted {
+		t.Fatalf("ClearChat(before m2) = %v, %v; want false, nil", deleted, err)
+	}
+	if exists("m1") || !exists("m2") || !exists("queued") {
+		t.Errorf("after clearing up to m1: m1 %v, m2 %v, queued %v; want false, true, true", exists("m1"), exists("m2"), exists("queued"))
+	}
+	c, err := db.GetChat("chat@s")
+	if err != nil || c == nil {
+		t.Fatalf("GetChat = %+v, %v", c, err)
+	}
+	if c.UnreadCount != 0 || c.LastMessagePreview != "second" {
+		t.Errorf("chat = %+v, want unread reset and preview kept", c)
+	}
+
+	// Clearing everything keeps the unsent message and the chat.
+	if deleted, err := db.ClearChat(&ChatClear{ChatJID: "chat@s", Delete: true}); err != nil || deleted {
+		t.Fatalf("ClearChat(all) = %v, %v; want false, nil while a message is unsent", deleted, err)
+	}
+	if exists("m2") || !exists("queued") {
+		t.Errorf("after clearing all: m2 %v, queued %v; want false, true", exists("m2"), exists("queued"))
+	}
+	if c, _ := db.GetChat("chat@s"); c == nil || c.LastMessagePreview != "unsent" {
+		t.Errorf("chat = %+v, want the unsent message as preview", c)
+	}
+
+	if _, err := db.Exec(`DELETE FROM messages WHERE msg_id = 'queued'`); err != nil {
+		t.Fatal(err)
+	}
+	if deleted, err := db.ClearChat(&ChatClear{ChatJID: "chat@s", Delete: true}); err != nil || !deleted {
+		t.Fatalf("ClearChat(empty) = %v, %v; want true, nil", deleted, err)
+	}
+	if c, err := db.GetChat("chat@s"); err != nil || c != nil {
+		t.Errorf("GetChat after delete = %+v, %v; want nil, nil", c, err)
+	}
+}
+
+func TestMarkOutboxSendingAfterCancel(t *testing.T) {
+	db := testDB(t)
+
+	if err := db.UpsertChat(&Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueOutbox("c1", "chat@s", "hi"); err != nil {
+		t.Fatal(err)
+	}
+	if pending, err := db.PendingOutbox(); err != nil || len(pending) != 1 {
+		t.Fatalf("PendingOutbox = %+v, %v; want c1", pending, err)
+	}
+
+	// A cancel that lands after the sender read the entry must win.
+	if _, err := db.CancelOutbox("c1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.MarkOutboxSending("c1"); !errors.Is(err, ErrOutboxState) {
+		t.Errorf("MarkOutboxSending after cancel = %v, want ErrOutboxState", err)
+	}
+	if e, err := db.GetOutbox("c1"); err != nil || e == nil || e.Status != "cancelled" {
+		t.Errorf("GetOutbox = %+v, %v; want cancelled", e, err)
+	}
+}
+
 func TestSearchMessages(t *testing.T) {
 	db := testDB(t)
 
@@ -167,7 +318,7 @@ func TestSearchMessages(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	results, err := db.SearchMessages("hello", "", 10)
+	results, err := db.SearchMessages(searchQuery(t, "hello"), "", Cursor{}, 10)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -179,6 +330,83 @@ func TestSearchMessages(t *testing.T) {
 	}
 }
 
+func TestSearchOperators(t *testing.T) {
+	db := testDB(t)
+
+	day := func(s string) int64 {
+		d, err := time.ParseInLocation("2006-01-02", s, time.Local)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d.UnixMilli() + 12*3600*1000
+	}
+	for _, c := range []*Chat{{JID: "family@g.us", Name: "Family", IsGroup: true}, {JID: "bob@s"}} {
+		if err := db.UpsertChat(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.UpsertContact(&Contact{JID: "alice@s", PushName: "Alice"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*Message{
+		{ChatJID: "family@g.us", MsgID: "m1", SenderJID: "alice@s", Body: "dinner at 8? see https://example.com", MessageType: "text", Timestamp: day("2026-01-10")},
+		{ChatJID: "family@g.us", MsgID: "m2", SenderJID: "me@s", FromMe: true, Body: "e-mail me the photo", MessageType: "image", Timestamp: day("2026-02-10")},
+		{ChatJID: "bob@s", MsgID: "m3", SenderJID: "bob@s", SenderName: "Bob", Body: "dinner tomorrow", MessageType: "text", Timestamp: day("2026-03-10")},
+	} {
+		if err := db.UpsertMessage(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.UpsertMedia(&Media{ChatJID: "family@g.us", MsgID: "m2", MediaType: "image"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"dinner", "m3 m1"},
+		{"dinner from:alice", "m1"},
+		{"from:bob@s", "m3"},
+		{"from:me", "m2"},
+		{"in:fam", "m2 m1"},
+		{"dinner in:bob@s", "m3"},
+		{"after:2026-02-01", "m3 m2"},
+		{"before:2026-02-10", "m1"},
+		{"has:link", "m1"},
+		{"has:media", "m2"},
+		{"type:image", "m2"},
+		{"din*", "m3 m1"},
+		{`"dinner tomorrow"`, "m3"},
+		// FTS5 syntax in free text is searched literally.
+		{`e-mail`, "m2"},
+		{`dinner"`, "m3 m1"},
+		{`"dinner tomorrow`, "m3"},
+		{`8? AND NOT`, ""},
+		{`--`, ""},
+	}
+	for _, tt := range tests {
+		results, err := db.SearchMessages(searchQuery(t, tt.query), "", Cursor{}, 10)
+		if err != nil {
+			t.Errorf("search %q: %v", tt.query, err)
+			continue
+		}
+		var ids []string
+		for _, r := range results {
+			ids = append(ids, r.Message.MsgID)
+		}
+		if got := strings.Join(ids, " "); got != tt.want {
+			t.Errorf("search %q = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "type:gif", "has:poll", "before:yesterday"} {
+		if _, err := ParseSearchQuery(bad); err == nil {
+			t.Errorf("ParseSearchQuery(%q) succeeded, want error", bad)
+		}
+	}
+}
+
 func TestOutbox(t *testing.T) {
 	db := testDB(t)
 
@@ -216,6 +444,194 @@ func TestOutbox(t *testing.T) {
 	}
 }
 
+func TestOutboxRetryAndCancel(t *testing.T) {
+	db := testDB(t)
+	if err := db.UpsertChat(&Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueOutboxWithMessage("c1", "", "chat@s", "hello", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// A failed attempt waits for its retry time.
+	if err := db.MarkOutboxSending("c1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.MarkOutboxRetry("c1", "offline", time.Now().Add(time.Hour).UnixMilli()); err != nil {
+		t.Fatal(err)
+	}
+	if pending, _ := db.PendingOutbox(); len(pending) != 0 {
+		t.Errorf("got %d pending before the retry time, want 0", len(pending))
+	}
+
+	// Retrying makes a dead entry due at once with a fresh budget.
+	if err := db.MarkOutboxDead("c1", "offline"); err != nil {
+		t.Fatal(err)
+	}
+	e, err := db.RetryOutbox("c1")
+	if err != nil || e == nil {
+		t.Fatalf("RetryOutbox = %v, %v", e, err)
+	}
+	pending, err := db.PendingOutbox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].Attempts != 0 || pending[0].ErrorMessage != "" {
+		t.Fatalf("pending = %+v, want c1 with no attempts", pending)
+	}
+
+	// An entry being sent can be neither retried nor cancelled.
+	if err := db.MarkOutboxSending("c1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.CancelOutbox("c1"); !errors.Is(err, ErrOutboxState) {
+		t.Errorf("CancelOutbox while sending = %v, want ErrOutboxState", err)
+	}
+
+	// Cancelling drops the entry and its message.
+	if err := db.MarkOutboxDead("c1", "offline"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.CancelOutbox("c1"); err != nil {
+		t.Fatal(err)
+	}
+	if m, err := db.GetMessage("chat@s", "c1"); err != nil || m != nil {
+		t.Errorf("GetMessage after cancel = %v, %v, want nil", m, err)
+	}
+	if _, err := db.RetryOutbox("c1"); !errors.Is(err, ErrOutboxState) {
+		t.Errorf("RetryOutbox after cancel = %v, want ErrOutboxState", err)
+	}
+	if e, err := db.RetryOutbox("missing"); err != nil || e != nil {
+		t.Errorf("RetryOutbox(missing) = %v, %v, want nil", e, err)
+	}
+}
+
+func TestScheduleOutbox(t *testing.T) {
+	db := testDB(t)
+	if err := db.UpsertChat(&Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	sendAt := time.Now().Add(time.Hour).UnixMilli()
+	if err := db.ScheduleOutbox("c1", "", "chat@s", "later", "", sendAt); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueOutboxWithMessage("c2", "", "chat@s", "now", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// The scheduled entry waits, holds nothing back and has no message yet.
+	pending, err := db.PendingOutbox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].ClientMsgID != "c2" {
+		t.Errorf("pending = %+v, want only c2", pending)
+	}
+	if next, err := db.NextOutboxAttempt(time.Now().UnixMilli()); err != nil || next != sendAt {
+		t.Errorf("NextOutboxAttempt = %d, %v, want %d", next, err, sendAt)
+	}
+	if m, err := db.GetMessage("chat@s", "c1"); err != nil || m != nil {
+		t.Errorf("GetMessage(c1) = %+v, %v, want none until sent", m, err)
+	}
+
+	scheduled, err := db.ListScheduledOutbox("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scheduled) != 1 || scheduled[0].ClientMsgID != "c1" || scheduled[0].SendAt != sendAt {
+		t.Errorf("scheduled = %+v, want c1 at %d", scheduled, sendAt)
+	}
+	if scheduled, _ := db.ListScheduledOutbox("other@s"); len(scheduled) != 0 {
+		t.Errorf("scheduled in other chat = %+v, want none", scheduled)
+	}
+
+	// Retrying sends it right away.
+	if _, err := db.RetryOutbox("c1"); err != nil {
+		t.Fatal(err)
+	}
+	pending, err = db.PendingOutbox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 {
+		t.Errorf("pending after retry = %+v, want c1 and c2", pending)
+	}
+	if scheduled, _ := db.ListScheduledOutbox(""); len(scheduled) != 0 {
+		t.Errorf("scheduled after retry = %+v, want none", scheduled)
+	}
+}
+
+func TestOutboxRekeyAndReconcile(t *testing.T) {
+	db := testDB(t)
+	if err := db.UpsertChat(&Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueOutboxWithMessage("c1", "3EB0A1", "chat@s", "hello", ""); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := db.PendingOutbox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].ServerMsgID != "3EB0A1" {
+		t.Fatalf("pending = %+v, want c1 with its pre-assigned id", pending)
+	}
+
+	// A reaction and a reply made before the send follow the message to
+	// its WhatsApp id.
+	if _, err := db.ApplyReaction(&Reaction{ChatJID: "chat@s", MsgID: "c1", FromMe: true, SenderJID: "me@s", Emoji: "👍", Timestamp: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueOutboxWithMessage("c2", "3EB0A2", "chat@s", "again", "c1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The echo of the send arrives while the entry is still marked sending,
+	// as after a crash between the send and its bookkeeping.
+	if err := db.MarkOutboxSending("c1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.MarkOutboxSending("c1"); !errors.Is(err, ErrOutboxState) {
+		t.Errorf("MarkOutboxSending twice = %v, want ErrOutboxState", err)
+	}
+	id, err := db.ReconcileOutbox("chat@s", "3EB0A1")
+	if err != nil || id != "c1" {
+		t.Fatalf("ReconcileOutbox = %q, %v, want c1", id, err)
+	}
+	if id, err := db.ReconcileOutbox("chat@s", "3EB0A1"); err != nil || id != "" {
+		t.Errorf("second ReconcileOutbox = %q, %v, want none", id, err)
+	}
+	if e, err := db.GetOutbox("c1"); err != nil || e == nil || e.Status != "sent" {
+		t.Errorf("GetOutbox(c1) = %+v, %v, want sent", e, err)
+	}
+
+	if m, err := db.GetMessage("chat@s", "c1"); err != nil || m != nil {
+		t.Errorf("GetMessage(c1) = %+v, %v, want re-keyed away", m, err)
+	}
+	m, err := db.GetMessage("chat@s", "3EB0A1")
+	if err != nil || m == nil {
+		t.Fatalf("GetMessage(3EB0A1) = %v, %v", m, err)
+	}
+	if m.Status != "sent" || len(m.Reactions) != 1 || m.Reactions[0].Emoji != "👍" {
+		t.Errorf("re-keyed message = %+v, want sent with one 👍", m)
+	}
+	reply, err := db.GetMessage("chat@s", "c2")
+	if err != nil || reply == nil || reply.QuotedMsgID != "3EB0A1" || reply.QuotedBody != "hello" {
+		t.Errorf("reply = %+v, %v, want quote of 3EB0A1", reply, err)
+	}
+
+	// A late acknowledgement never downgrades a receipt.
+	if _, err := db.ApplyReceipt(&Receipt{ChatJID: "chat@s", MsgIDs: []string{"3EB0A1"}, Status: "read"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.MarkOutboxSent("c1", "3EB0A1"); err != nil {
+		t.Fatal(err)
+	}
+	if m, _ := db.GetMessage("chat@s", "3EB0A1"); m == nil || m.Status != "read" {
+		t.Errorf("status after late ack = %+v, want read", m)
+	}
+}
+
 func TestContact(t *testing.T) {
 	db := testDB(t)
 
@@ -248,7 +664,7 @@ func TestContactNameResolution(t *testing.T) {
 	}
 
 	// Before contact upsert, sender_name should fall back to sender JID.
-	msgs, err := db.ListMessages("chat@s", 0, 10)
+	msgs, err := db.ListMessages("chat@s", Cursor{}, 10)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -265,7 +681,7 @@ func TestContactNameResolution(t *testing.T) {
 	}
 
 	// After contact upsert, sender_name should resolve to push_name.
-	msgs, err = db.ListMessages("chat@s", 0, 10)
+	msgs, err = db.ListMessages("chat@s", Cursor{}, 10)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -305,7 +721,7 @@ func TestChatNameFallback(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	chats, err := db.ListChats(10, 0)
+	chats, err := db.ListChats(10, Cursor{}, ChatFilter{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -387,7 +803,7 @@ func TestReconcileLIDs(t *testing.T) {
 	}
 
 	// Verify both chats exist before reconciliation.
-	chats, err := db.ListChats(100, 0)
+	chats, err := db.ListChats(100, Cursor{}, ChatFilter{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -427,7 +843,7 @@ func TestReconcileLIDs(t *testing.T) {
 	}
 
 	// Verify PN chat inherited the LID messages.
-	msgs, err := db.ListMessages("558592403672@s.whatsapp.net", 0, 100)
+	msgs, err := db.ListMessages("558592403672@s.whatsapp.net", Cursor{}, 100)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -474,7 +890,7 @@ func TestListChatsFiltersLIDs(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	chats, err := db.ListChats(100, 0)
+	chats, err := db.ListChats(100, Cursor{}, ChatFilter{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -520,3 +936,693 @@ func TestChatAndMessageCounts(t *testing.T) {
 		t.Errorf("message count = %d, want 1", mcount)
 	}
 }
+
+func TestApplyReceipt(t *testing.T) {
+	db := testDB(t)
+
+	if err := db.UpsertChat(&Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	// Message sent from this device: re-keyed to its server id once sent.
+	if err := db.QueueOutboxWithMessage("c1", "", "chat@s", "hi", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.MarkOutboxSent("c1", "SRV1"); err != nil {
+		t.Fatal(err)
+	}
+	// Message sent from another device: keyed by server id.
+	if err := db.UpsertMessage(&Message{ChatJID: "chat@s", MsgID: "SRV2", Body: "yo", FromMe: true, Status: "received", Timestamp: 2000}); err != nil {
+		t.Fatal(err)
+	}
+	// Incoming message must never be touched by receipts.
+	if err := db.UpsertMessage(&Message{ChatJID: "chat@s", MsgID: "SRV3", Body: "in", Status: "received", Timestamp: 3000}); err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := db.ApplyReceipt(&Receipt{ChatJID: "chat@s", MsgIDs: []string{"SRV1", "SRV2", "SRV3"}, Status: "read"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 2 || changed[0] != "SRV1" || changed[1] != "SRV2" {
+		t.Errorf("changed = %v, want [SRV1 SRV2]", changed)
+	}
+
+	// A late delivered receipt must not downgrade read.
+	changed, err = db.ApplyReceipt(&Receipt{ChatJID: "chat@s", MsgIDs: []string{"SRV1"}, Status: "delivered"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 0 {
+		t.Errorf("changed = %v, want none (no downgrade)", changed)
+	}
+
+	msgs, err := db.ListMessages("chat@s", Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"SRV1": "read", "SRV2": "read", "SRV3": "received"}
+	for _, m := range msgs {
+		if m.Status != want[m.MsgID] {
+			t.Errorf("%s status = %q, want %q", m.MsgID, m.Status, want[m.MsgID])
+		}
+	}
+
+	// Re-ingesting our messages, as history sync or an own-device echo
+	// does, keeps their receipts.
+	for _, id := range []string{"SRV1", "SRV2"} {
+		for _, st := range []string{"sent", "received"} {
+			if err := db.UpsertMessage(&Message{ChatJID: "chat@s", MsgID: id, Body: "again", FromMe: true, Status: st, Timestamp: 2000}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if m, err := db.GetMessage("chat@s", id); err != nil || m.Status != "read" {
+			t.Errorf("%s after re-ingest = %+v, %v; want read", id, m, err)
+		}
+	}
+}
+
+func TestApplyMessageChange(t *testing.T) {
+	db := testDB(t)
+
+	if err := db.UpsertChat(&Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpsertMessage(&Message{ChatJID: "chat@s", MsgID: "m1", SenderJID: "alice@s", Body: "helo world", Timestamp: 1000}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the sender may edit.
+	msgID, err := db.ApplyMessageChange(&MessageChange{ChatJID: "chat@s", MsgID: "m1", Kind: "edit", Body: "spam", SenderJID: "bob@s", Timestamp: 1500})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msgID != "" {
+		t.Errorf("edit by another sender changed %q", msgID)
+	}
+	if msgID, err = db.ApplyMessageChange(&MessageChange{ChatJID: "chat@s", MsgID: "m1", Kind: "edit", Body: "spam", FromMe: true, Timestamp: 1500}); err != nil || msgID != "" {
+		t.Errorf("our edit of another's message = %q, %v, want no change", msgID, err)
+	}
+
+	// Edit replaces the body, keeps the prior one, and updates FTS.
+	msgID, err = db.ApplyMessageChange(&MessageChange{ChatJID: "chat@s", MsgID: "m1", Kind: "edit", Body: "hello world", SenderJID: "alice@s", Timestamp: 2000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msgID != "m1" {
+		t.Errorf("edit changed %q, want m1", msgID)
+	}
+	results, err := db.SearchMessages(searchQuery(t, "hello"), "", Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Message.EditedAt != 2000 {
+		t.Errorf("search after edit = %+v, want 1 result edited at 2000", results)
+	}
+
+	// The edit survives re-ingestion of the original.
+	if err := db.UpsertMessage(&Message{ChatJID: "chat@s", MsgID: "m1", SenderJID: "alice@s", Body: "helo world", Timestamp: 1000}); err != nil {
+		t.Fatal(err)
+	}
+	msgs, err := db.ListMessages("chat@s", Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 1 || msgs[0].Body != "hello world" {
+		t.Errorf("after re-ingest = %+v, want edited body kept", msgs)
+	}
+
+	// Revoke leaves a tombstone that survives re-ingestion.
+	if msgID, err := db.ApplyMessageChange(&MessageChange{ChatJID: "chat@s", MsgID: "m1", Kind: "revoke", SenderJID: "bob@s"}); err != nil || msgID != "" {
+		t.Errorf("revoke by another sender = %q, %v, want no change", msgID, err)
+	}
+	if _, err := db.ApplyMessageChange(&MessageChange{ChatJID: "chat@s", MsgID: "m1", Kind: "revoke", SenderJID: "alice@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpsertMessage(&Message{ChatJID: "chat@s", MsgID: "m1", Body: "helo world", Timestamp: 1000}); err != nil {
+		t.Fatal(err)
+	}
+	msgs, err = db.ListMessages("chat@s", Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 1 || !msgs[0].Deleted || msgs[0].Body != "" {
+		t.Errorf("after revoke = %+v, want deleted with empty body", msgs)
+	}
+	results, err = db.SearchMessages(searchQuery(t, "hello"), "", Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 0 {
+		t.Errorf("revoked message still searchable: %+v", results)
+	}
+
+	revs, err := db.ListRevisions("chat@s", "m1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revs) != 2 || revs[0].Body != "helo world" || revs[1].Body != "hello world" {
+		t.Errorf("revisions = %+v, want [helo world, hello world]", revs)
+	}
+
+	// Changes to unknown messages are no-ops.
+	msgID, err = db.ApplyMessageChange(&MessageChange{ChatJID: "chat@s", MsgID: "missing", Kind: "edit", Body: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msgID != "" {
+		t.Errorf("edit of missing message changed %q", msgID)
+	}
+
+	// A group admin may revoke another member's message.
+	if err := db.UpsertChat(&Chat{JID: "g@g.us", IsGroup: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpsertGroup(&Group{JID: "g@g.us", Participants: []GroupParticipant{{JID: "alice@s"}, {JID: "admin@s", IsAdmin: true}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpsertMessage(&Message{ChatJID: "g@g.us", MsgID: "g1", SenderJID: "alice@s", Body: "hi", Timestamp: 1000}); err != nil {
+		t.Fatal(err)
+	}
+	if msgID, err := db.ApplyMessageChange(&MessageChange{ChatJID: "g@g.us", MsgID: "g1", Kind: "edit", Body: "x", SenderJID: "admin@s"}); err != nil || msgID != "" {
+		t.Errorf("edit by admin = %q, %v, want no change", msgID, err)
+	}
+	if msgID, err := db.ApplyMessageChange(&MessageChange{ChatJID: "g@g.us", MsgID: "g1", Kind: "revoke", SenderJID: "admin@s"}); err != nil || msgID != "g1" {
+		t.Errorf("revoke by admin = %q, %v, want g1", msgID, err)
+	}
+}
+
+func TestApplyReaction(t *testing.T) {
+	db := testDB(t)
+
+	if err := db.UpsertChat(&Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpsertMessage(&Message{ChatJID: "chat@s", MsgID: "m1", Body: "hi", Timestamp: 1000}); err != nil {
+		t.Fatal(err)
+	}
+	// A message sent from this device, known to others by its server ID.
+	if err := db.QueueOutboxWithMessage("c1", "", "chat@s", "mine", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.MarkOutboxSent("c1", "SRV1"); err != nil {
+		t.Fatal(err)
+	}
+
+	apply := func(r Reaction) string {
+		t.Helper()
+		msgID, err := db.ApplyReaction(&r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return msgID
+	}
+	apply(Reaction{ChatJID: "chat@s", MsgID: "m1", SenderJID: "a@s", Emoji: "👍", Timestamp: 10})
+	apply(Reaction{ChatJID: "chat@s", MsgID: "m1", SenderJID: "b@s", Emoji: "👍", Timestamp: 11})
+	apply(Reaction{ChatJID: "chat@s", MsgID: "m1", FromMe: true, SenderJID: "me@s", Emoji: "😂", Timestamp: 12})
+	// A later reaction from the same sender replaces the earlier one; a stale one is ignored.
+	apply(Reaction{ChatJID: "chat@s", MsgID: "m1", SenderJID: "b@s", Emoji: "😂", Timestamp: 13})
+	apply(Reaction{ChatJID: "chat@s", MsgID: "m1", SenderJID: "b@s", Emoji: "🙏", Timestamp: 5})
+	if got := apply(Reaction{ChatJID: "chat@s", MsgID: "SRV1", SenderJID: "a@s", Emoji: "🔥", Timestamp: 14}); got != "SRV1" {
+		t.Errorf("reaction to server ID resolved to %q, want SRV1", got)
+	}
+
+	msgs, err := db.ListMessages("chat@s", Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byID := map[string][]ReactionCount{}
+	for _, m := range msgs {
+		byID[m.MsgID] = m.Reactions
+	}
+	want := []ReactionCount{{Emoji: "👍", Count: 1}, {Emoji: "😂", Count: 2, FromMe: true}}
+	if len(byID["m1"]) != 2 || byID["m1"][0] != want[0] || byID["m1"][1] != want[1] {
+		t.Errorf("m1 reactions = %+v, want %+v", byID["m1"], want)
+	}
+	if len(byID["SRV1"]) != 1 || byID["SRV1"][0].Emoji != "🔥" {
+		t.Errorf("SRV1 reactions = %+v, want one 🔥", byID["SRV1"])
+	}
+
+	// An empty emoji removes the sender's reaction, whichever device sent it.
+	apply(Reaction{ChatJID: "chat@s", MsgID: "m1", FromMe: true, SenderJID: "me@s.other", Timestamp: 15})
+	msgs, err = db.ListMessages("chat@s", Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range msgs {
+		if m.MsgID == "m1" && (len(m.Reactions) != 2 || m.Reactions[1].FromMe || m.Reactions[1].Count != 1) {
+			t.Errorf("after removal m1 reactions = %+v, want our 😂 gone", m.Reactions)
+		}
+	}
+
+	key, err := db.GetMessageKey("chat@s", "SRV1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key == nil || key.ID != "SRV1" || !key.FromMe {
+		t.Errorf("GetMessageKey(SRV1) = %+v, want server ID SRV1 from me", key)
+	}
+}
+
+func TestQuotedReply(t *testing.T) {
+	db := testDB(t)
+
+	if err := db.UpsertChat(&Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpsertMessage(&Message{ChatJID: "chat@s", MsgID: "m1", SenderJID: "bob@s", Body: "lunch?", Timestamp: 1000}); err != nil {
+		t.Fatal(err)
+	}
+	// Our reply is queued with its reply context.
+	if err := db.QueueOutboxWithMessage("c1", "", "chat@s", "sure", "m1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.MarkOutboxSent("c1", "SRV1"); err != nil {
+		t.Fatal(err)
+	}
+	// Bob replies to our message by its server ID.
+	if err := db.UpsertMessage(&Message{ChatJID: "chat@s", MsgID: "m2", SenderJID: "bob@s", Body: "great", Timestamp: 2000, QuotedMsgID: "SRV1", QuotedSender: "me@s"}); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs, err := db.ListMessages("chat@s", Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byID := map[string]Message{}
+	for _, m := range msgs {
+		byID[m.MsgID] = m
+	}
+	if m := byID["SRV1"]; m.QuotedMsgID != "m1" || m.QuotedSender != "bob@s" || m.QuotedBody != "lunch?" {
+		t.Errorf("SRV1 quote = %q/%q/%q, want m1/bob@s/lunch?", m.QuotedMsgID, m.QuotedSender, m.QuotedBody)
+	}
+	if m := byID["m2"]; m.QuotedMsgID != "SRV1" || m.QuotedBody != "sure" {
+		t.Errorf("m2 quote = %q/%q, want SRV1/sure", m.QuotedMsgID, m.QuotedBody)
+	}
+	if m := byID["m1"]; m.QuotedMsgID != "" || m.QuotedBody != "" {
+		t.Errorf("m1 has quote %q/%q, want none", m.QuotedMsgID, m.QuotedBody)
+	}
+
+	q, err := db.GetQuotedMessage("chat@s", "m1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q == nil || q.Key.ID != "m1" || q.Key.SenderJID != "bob@s" || q.Key.FromMe || q.Body != "lunch?" {
+		t.Errorf("GetQuotedMessage(m1) = %+v", q)
+	}
+
+	// A reply to a message not stored shows the text it carried, and a
+	// re-ingest that first lacked the reply context gains it.
+	if err := db.UpsertMessage(&Message{ChatJID: "chat@s", MsgID: "m3", SenderJID: "bob@s", Body: "still on?", Timestamp: 3000}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpsertMessage(&Message{ChatJID: "chat@s", MsgID: "m3", SenderJID: "bob@s", Body: "still on?", Timestamp: 3000, QuotedMsgID: "OLD", QuotedSender: "me@s", QuotedBody: "dinner at 8"}); err != nil {
+		t.Fatal(err)
+	}
+	if m, err := db.GetMessage("chat@s", "m3"); err != nil || m == nil || m.QuotedMsgID != "OLD" || m.QuotedSender != "me@s" || m.QuotedBody != "dinner at 8" {
+		t.Errorf("GetMessage(m3) = %+v, %v, want quote of OLD with its excerpt", m, err)
+	}
+}
+
+func TestQueueMedia(t *testing.T) {
+	db := testDB(t)
+	if err := db.UpsertChat(&Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	m := &Media{
+		MediaType: "audio", Mimetype: "audio/ogg; codecs=opus", FileLength: 4,
+		Voice: true, LocalPath: "/cache/ab/abc.ogg",
+	}
+	if err := db.QueueMedia("c1", "", "chat@s", "", m); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs, err := db.ListMessages("chat@s", Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 1 || msgs[0].MessageType != "audio" || msgs[0].Status != "queued" || msgs[0].Media == nil {
+		t.Fatalf("messages = %+v, want one queued audio", msgs)
+	}
+	if md := msgs[0].Media; !md.Voice || md.LocalPath != m.LocalPath {
+		t.Errorf("media = %+v, want a cached voice note", md)
+	}
+	pending, err := db.PendingOutbox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].Kind != "media" || !pending[0].HasMessage() {
+		t.Errorf("pending = %+v, want one media entry", pending)
+	}
+
+	// Cancelling drops the media with the message.
+	if _, err := db.CancelOutbox("c1"); err != nil {
+		t.Fatal(err)
+	}
+	if md, err := db.GetMedia("chat@s", "c1"); err != nil || md != nil {
+		t.Errorf("GetMedia after cancel = %+v, %v, want none", md, err)
+	}
+}
+
+func TestQueueMessageChange(t *testing.T) {
+	db := testDB(t)
+	if err := db.UpsertChat(&Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueOutboxWithMessage("c1", "SRV1", "chat@s", "teh plan", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.MarkOutboxSent("c1", "SRV1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueMessageChange("e1", "SRV2", &MessageChange{ChatJID: "chat@s", MsgID: "SRV1", Kind: "edit", Body: "the plan"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueMessageChange("x1", "", &MessageChange{ChatJID: "chat@s", MsgID: "SRV1", Kind: "react"}); err == nil {
+		t.Error("QueueMessageChange(react) succeeded, want error")
+	}
+
+	pending, err := db.PendingOutbox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].Kind != "edit" || pending[0].TargetMsgID != "SRV1" || pending[0].Body != "the plan" || pending[0].HasMessage() {
+		t.Fatalf("pending = %+v, want one edit of SRV1", pending)
+	}
+	// The edit's own ID is not taken for an echo of a message.
+	if id, err := db.ReconcileOutbox("chat@s", "SRV2"); err != nil || id != "" {
+		t.Errorf("ReconcileOutbox(SRV2) = %q, %v, want none", id, err)
+	}
+
+	// Cancelling the edit leaves the message alone.
+	if _, err := db.CancelOutbox("e1"); err != nil {
+		t.Fatal(err)
+	}
+	m, err := db.GetMessage("chat@s", "SRV1")
+	if err != nil || m == nil {
+		t.Fatalf("GetMessage(SRV1) = %v, %v", m, err)
+	}
+	if m.Body != "teh plan" || m.EditedAt != 0 {
+		t.Errorf("message = %+v, want unchanged", m)
+	}
+}
+
+func TestMediaSearchAndRevoke(t *testing.T) {
+	db := testDB(t)
+
+	if err := db.UpsertChat(&Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpsertMessage(&Message{ChatJID: "chat@s", MsgID: "m1", Body: "see attached", MessageType: "document", Timestamp: 1000}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpsertMedia(&Media{
+		ChatJID: "chat@s", MsgID: "m1", MediaType: "document", Mimetype: "application/pdf",
+		FileLength: 2048, FileName: "quarterly-report.pdf", Caption: "see attached",
+		DirectPath: "/v/t62/abc", MediaKey: []byte{1, 2, 3},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both the caption (body) and the file name are searchable.
+	for _, q := range []string{"attached", "quarterly"} {
+		results, err := db.SearchMessages(searchQuery(t, q), "", Cursor{}, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(results) != 1 || results[0].Message.Media == nil || results[0].Message.Media.FileName != "quarterly-report.pdf" {
+			t.Errorf("search %q = %+v, want m1 with its media", q, results)
+		}
+	}
+
+	m, err := db.GetMedia("chat@s", "m1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m == nil || m.DirectPath != "/v/t62/abc" || len(m.MediaKey) != 3 || m.FileLength != 2048 {
+		t.Errorf("GetMedia = %+v", m)
+	}
+
+	// Revoking drops the media metadata and its file name from search.
+	if _, err := db.ApplyMessageChange(&MessageChange{ChatJID: "chat@s", MsgID: "m1", Kind: "revoke", SenderJID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if m, _ := db.GetMedia("chat@s", "m1"); m != nil {
+		t.Errorf("media still stored after revoke: %+v", m)
+	}
+	results, err := db.SearchMessages(searchQuery(t, "quarterly"), "", Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 0 {
+		t.Errorf("revoked file name still searchable: %+v", results)
+	}
+}
+
+func TestChatUnreadState(t *testing.T) {
+	db := testDB(t)
+
+	if err := db.UpsertChat(&Chat{JID: "g@g.us", Name: "Team"}); err != nil {
+		t.Fatal(err)
+	}
+	for i, id := range []string{"m1", "m2", "m3"} {
+		if err := db.UpsertMessage(&Message{ChatJID: "g@g.us", MsgID: id, SenderJID: "bob@s", Body: id, MessageType: "text", Timestamp: int64(1000 * (i + 1))}); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.TouchChat(&Chat{JID: "g@g.us", IsGroup: true, UnreadCount: 1, LastMessageAt: int64(1000 * (i + 1)), LastMessagePreview: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// An older message must not rewind the preview.
+	if err := db.TouchChat(&Chat{JID: "g@g.us", IsGroup: true, LastMessageAt: 500, LastMessagePreview: "old"}); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := db.GetChat("g@g.us")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Name != "Team" || c.UnreadCount != 3 || c.LastMessageAt != 3000 || c.LastMessagePreview != "m3" {
+		t.Errorf("chat = %+v, want Team with 3 unread and preview m3", c)
+	}
+
+	if _, err := db.SetChatUnread("g@g.us", 2); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := db.UnreadMessages("g@g.us")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys[0].ID != "m2" || keys[1].ID != "m3" || keys[0].SenderJID != "bob@s" {
+		t.Errorf("unread = %+v, want m2, m3 from bob", keys)
+	}
+
+	// Marked unread without a count raises a read chat to one.
+	if _, err := db.SetChatUnread("g@g.us", 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.SetChatUnread("g@g.us", -1); err != nil {
+		t.Fatal(err)
+	}
+	if c, _ := db.GetChat("g@g.us"); c.UnreadCount != 1 {
+		t.Errorf("unread = %d, want 1 after mark unread", c.UnreadCount)
+	}
+
+	ok, err := db.SetChatUnread("missing@s", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Error("SetChatUnread reported a missing chat as stored")
+	}
+}
+
+func TestGroups(t *testing.T) {
+	db := testDB(t)
+
+	if err := db.UpsertChat(&Chat{JID: "g@g.us", IsGroup: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpsertContact(&Contact{JID: "bob@s", PushName: "Bob"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpsertGroup(&Group{
+		JID: "g@g.us", Name: "Team", Topic: "standups", OwnerJID: "alice@s", CreatedAt: 1000,
+		Participants: []GroupParticipant{
+			{JID: "alice@s", IsAdmin: true, IsSuperAdmin: true},
+			{JID: "bob@s"},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	chat, err := db.GetChat("g@g.us")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chat.Name != "Team" {
+		t.Errorf("chat name = %q, want group subject Team", chat.Name)
+	}
+
+	name := "Team 2"
+	if err := db.ApplyGroupChange(&GroupChange{
+		GroupJID: "g@g.us", Name: &name,
+		Join: []string{"carol@s"}, Leave: []string{"alice@s"}, Promote: []string{"bob@s"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := db.GetGroup("g@g.us")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Name != "Team 2" || g.Topic != "standups" {
+		t.Errorf("group = %+v, want Team 2 with topic kept", g)
+	}
+	if len(g.Participants) != 2 || g.Participants[0].Name != "Bob" || !g.Participants[0].IsAdmin || g.Participants[1].JID != "carol@s" {
+		t.Errorf("participants = %+v, want admin Bob then carol", g.Participants)
+	}
+
+	missing, err := db.GetGroup("none@g.us")
+	if err != nil || missing != nil {
+		t.Errorf("GetGroup(missing) = %+v, %v; want nil, nil", missing, err)
+	}
+}
+
+func TestKeysetPagination(t *testing.T) {
+	db := testDB(t)
+
+	// Equal timestamps must neither repeat nor skip rows across pages.
+	for i, ts := range []int64{3000, 2000, 2000, 2000, 1000} {
+		jid := string(rune('a'+i)) + "@s"
+		if err := db.UpsertChat(&Chat{JID: jid, LastMessageAt: ts}); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.UpsertMessage(&Message{ChatJID: "a@s", MsgID: jid, Body: "hello " + jid, MessageType: "text", Timestamp: ts}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var chats []string
+	var after Cursor
+	for {
+		page, err := db.ListChats(2, after, ChatFilter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, c := range page {
+			chats = append(chats, c.JID)
+		}
+		last := page[len(page)-1]
+		after = Cursor{Timestamp: last.LastMessageAt, JID: last.JID}
+	}
+	if got := strings.Join(chats, ","); got != "a@s,d@s,c@s,b@s,e@s" {
+		t.Errorf("chat pages = %s, want a@s,d@s,c@s,b@s,e@s", got)
+	}
+
+	var msgs, found []string
+	var before Cursor
+	for {
+		page, err := db.ListMessages("a@s", before, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		results, err := db.SearchMessages(searchQuery(t, "hello"), "a@s", before, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for i, m := range page {
+			msgs = append(msgs, m.MsgID)
+			found = append(found, results[i].Message.MsgID)
+		}
+		last := page[len(page)-1]
+		before = Cursor{Timestamp: last.Timestamp, ID: last.ID}
+	}
+	if got := strings.Join(msgs, ","); got != "a@s,d@s,c@s,b@s,e@s" {
+		t.Errorf("message pages = %s, want a@s,d@s,c@s,b@s,e@s", got)
+	}
+	if got := strings.Join(found, ","); got != strings.Join(msgs, ",") {
+		t.Errorf("search pages = %s, want %s", got, strings.Join(msgs, ","))
+	}
+}
+
+func TestChatFilter(t *testing.T) {
+	db := testDB(t)
+
+	day := func(s string) int64 {
+		d, err := time.ParseInLocation("2006-01-02", s, time.Local)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d.UnixMilli()
+	}
+	for _, c := range []Chat{
+		{JID: "alice@s", Name: "Alice", UnreadCount: 2, LastMessageAt: day("2026-03-01"), LastMessagePreview: "lunch at 10:30?"},
+		{JID: "bob@s", Name: "Bob", LastMessageAt: day("2025-12-31"), LastMessagePreview: "100% done"},
+		{JID: "club@g.us", Name: "Book Club", IsGroup: true, UnreadCount: 1, LastMessageAt: day("2026-02-01")},
+	} {
+		if err := db.UpsertChat(&c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	archived, pinnedAt, mutedUntil, expired := true, int64(1), int64(-1), int64(1)
+	for _, s := range []ChatSettings{
+		{ChatJID: "bob@s", Archived: &archived},
+		{ChatJID: "alice@s", PinnedAt: &pinnedAt, MutedUntil: &expired},
+		{ChatJID: "club@g.us", MutedUntil: &mutedUntil},
+	} {
+		if ok, err := db.SetChatSettings(&s); err != nil || !ok {
+			t.Fatalf("SetChatSettings(%s) = %v, %v", s.ChatJID, ok, err)
+		}
+	}
+
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{"", "alice@s,club@g.us,bob@s"},
+		{"is:unread", "alice@s,club@g.us"},
+		{"is:unread is:dm", "alice@s"},
+		{"is:group", "club@g.us"},
+		{"is:archived", "bob@s"},
+		{"-is:archived", "alice@s,club@g.us"},
+		{"is:pinned", "alice@s"},
+		{"is:muted", "club@g.us"},
+		{"-is:muted", "alice@s,bob@s"},
+		{`name:"book club"`, "club@g.us"},
+		{"since:2026-01-01", "alice@s,club@g.us"},
+		{"lunch", "alice@s"},
+		{"100%", "bob@s"},
+		{"1%", ""},
+		{"10:30", "alice@s"},
+		{"re:lunch", ""},
+	}
+	for _, tt := range tests {
+		f, err := ParseChatFilter(tt.filter)
+		if err != nil {
+			t.Fatalf("ParseChatFilter(%q): %v", tt.filter, err)
+		}
+		chats, err := db.ListChats(10, Cursor{}, f)
+		if err != nil {
+			t.Fatalf("ListChats(%q): %v", tt.filter, err)
+		}
+		var got []string
+		for _, c := range chats {
+			got = append(got, c.JID)
+		}
+		if strings.Join(got, ",") != tt.want {
+			t.Errorf("filter %q = %v, want %s", tt.filter, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"is:starred", "since:yesterday", "-name:x", `name:"open`} {
+		if _, err := ParseChatFilter(bad); err == nil {
+			t.Errorf("ParseChatFilter(%q) succeeded, want error", bad)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// sync_state keys of the history sync progress.
+const (
+	keyHistorySyncType   = "history_sync_type"
+	keyHistoryChunkOrder = "history_chunk_order"
+	keyHistoryProgress   = "history_progress"
+	keyHistoryMessages   = "history_messages"
+	keyHistoryChats      = "history_chats"
+
+	// keyBackfillPrefix keys the on-demand history request pending for
+	// a chat by its JID.
+	keyBackfillPrefix = "backfill_request:"
+)
+
+// RecordHistoryBatch notes an ingested history chunk of b's sync type,
+// order and progress that stored messages new messages and chats new
+// chats, adding them to the totals. On-demand chunks answer a backfill of
+// one chat and leave the sync type and progress alone.
+func (db *DB) RecordHistoryBatch(b *HistoryBatch, messages, chats int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now().UnixMilli()
+	progress := map[string]string{
+		keyHistorySyncType:   b.SyncType,
+		keyHistoryChunkOrder: strconv.Itoa(b.ChunkOrder),
+		keyHistoryProgress:   strconv.Itoa(b.Progress),
+	}
+	if b.SyncType == "on_demand" {
+		progress = nil
+	}
+	for key, value := range progress {
+		if _, err := tx.Exec(`
+			INSERT INTO sync_state (key, value, updated_at) VALUES (?, ?, ?)
+			ON CONFLICT(key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at`,
+			key, value, now); err != nil {
+			return fmt.Errorf("set %s: %w", key, err)
+		}
+	}
+	for key, n := range map[string]int{keyHistoryMessages: messages, keyHistoryChats: chats} {
+		if _, err := tx.Exec(`
+			INSERT INTO sync_state (key, value, updated_at) VALUES (?, ?, ?)
+			ON CONFLICT(key) DO UPDATE SET
+				value = CAST(sync_state.value AS INTEGER) + CAST(excluded.value AS INTEGER),
+				updated_at = excluded.updated_at`,
+			key, strconv.Itoa(n), now); err != nil {
+			return fmt.Errorf("add to %s: %w", key, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetHistorySyncState returns the history sync progress, zero before the
+// first chunk.
+func (db *DB) GetHistorySyncState() (*HistorySyncState, error) {
+	rows, err := db.Query(`SELECT key, value, updated_at FROM sync_state WHERE key IN (?, ?, ?, ?, ?)`,
+		keyHistorySyncType, keyHistoryChunkOrder, keyHistoryProgress, keyHistoryMessages, keyHistoryChats)
+	if err != nil {
+		return nil, fmt.Errorf("query sync state: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var s HistorySyncState
+	for rows.Next() {
+		var key, value string
+		var updatedAt int64
+		if err := rows.Scan(&key, &value, &updatedAt); err != nil {
+			return nil, fmt.Errorf("scan sync state: %w", err)
+		}
+		s.LastSyncAt = max(s.LastSyncAt, updatedAt)
+		n, _ := strconv.ParseInt(value, 10, 64)
+		switch key {
+		case keyHistorySyncType:
+			s.SyncType = value
+		case keyHistoryChunkOrder:
+			s.ChunkOrder = int(n)
+		case keyHistoryProgress:
+			s.Progress = int(n)
+		case keyHistoryMessages:
+			s.MessagesSynced = n
+		case keyHistoryChats:
+			s.ChatsSynced = n
+		}
+	}
+	return &s, rows.Err()
+}
+
+// SetBackfillRequest records requestID as the on-demand history request
+// pending for chatJID, replacing any earlier one.
+func (db *DB) SetBackfillRequest(chatJID, requestID string) error {
+	_, err := db.Exec(`
+		INSERT INTO sync_state (key, value, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at`,
+		keyBackfillPrefix+chatJID, requestID, time.Now().UnixMilli())
+	return err
+}
+
+// ClaimBackfillRequest returns and forgets the on-demand history request
+// pending for chatJID, or "" if there is none.
+func (db *DB) ClaimBackfillRequest(chatJID string) (string, error) {
+	var requestID string
+	err := db.QueryRow(`DELETE FROM sync_state WHERE key = ? RETURNING value`, keyBackfillPrefix+chatJID).Scan(&requestID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return requestID, err
+}
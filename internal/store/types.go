@@ -8,6 +8,86 @@ type Chat struct {
 	UnreadCount        int
 	LastMessageAt      int64
 	LastMessagePreview string
+	Archived           bool
+	PinnedAt           int64 // 0 when not pinned
+	MutedUntil         int64 // 0 when not muted, -1 when muted indefinitely
+}
+
+// Muted reports whether the chat is muted at now (Unix ms).
+func (c *Chat) Muted(now int64) bool {
+	return c.MutedUntil < 0 || c.MutedUntil > now
+}
+
+// Cursor is a keyset position in a list ordered newest first by timestamp,
+// then by row id, or by JID for chats. A page starting at a Cursor holds
+// the rows strictly after it; the zero Cursor starts at the newest row.
+type Cursor struct {
+	Timestamp int64
+	ID        int64
+	JID       string
+}
+
+// IsZero reports whether c is the start of the list.
+func (c Cursor) IsZero() bool {
+	return c == Cursor{}
+}
+
+// ChatReadState is the read state WhatsApp reports for a chat. An
+// UnreadCount of -1 means the chat was marked unread without a count.
+type ChatReadState struct {
+	ChatJID     string
+	UnreadCount int
+}
+
+// ChatSettings is how a chat is organized in WhatsApp app state. Nil
+// fields are unchanged; times are Unix ms as in Chat.
+type ChatSettings struct {
+	ChatJID    string
+	Archived   *bool
+	PinnedAt   *int64
+	MutedUntil *int64
+}
+
+// ChatClear is a chat cleared or deleted on another device. Messages up to
+// Before (Unix ms) are removed, all of them when Before is 0. A deleted
+// chat is removed once it has no messages left.
+type ChatClear struct {
+	ChatJID string
+	Before  int64
+	Delete  bool
+}
+
+// Group is the metadata of a group chat. Participants is nil when it was
+// not loaded or is not known.
+type Group struct {
+	JID          string
+	Name         string
+	Topic        string
+	OwnerJID     string
+	CreatedAt    int64
+	Participants []GroupParticipant
+}
+
+// GroupParticipant is a member of a group. Name is the resolved display
+// name when read from the store.
+type GroupParticipant struct {
+	JID          string
+	Name         string
+	IsAdmin      bool
+	IsSuperAdmin bool
+}
+
+// GroupChange is an incremental update to a group from a group
+// notification. Name and Topic are nil when unchanged.
+type GroupChange struct {
+	GroupJID  string
+	Name      *string
+	Topic     *string
+	Join      []string
+	Leave     []string
+	Promote   []string
+	Demote    []string
+	Timestamp int64
 }
 
 // Contact represents a synced contact.
@@ -29,6 +109,39 @@ type Message struct {
 	FromMe      bool
 	Status      string
 	Timestamp   int64
+	EditedAt    int64
+	Deleted     bool
+	Reactions   []ReactionCount
+
+	// Reply context. QuotedMsgID is the local msg_id of the quoted message
+	// and QuotedBody its current body or, when it is not stored, the text
+	// the reply carried of it.
+	QuotedMsgID  string
+	QuotedSender string
+	QuotedBody   string
+
+	// Media is the attachment of media messages. It is set by the parser
+	// on ingest and loaded on reads.
+	Media *Media
+}
+
+// MessageChange is an edit or revoke targeting an existing message.
+type MessageChange struct {
+	ChatJID   string
+	MsgID     string // ID of the message being changed
+	Kind      string // edit, revoke
+	Body      string // new body, for edits
+	SenderJID string // who made the change
+	FromMe    bool   // the change was made by us
+	Timestamp int64
+}
+
+// MessageRevision is a prior body of a message, kept when it was edited or revoked.
+type MessageRevision struct {
+	ChatJID   string
+	MsgID     string
+	Body      string
+	RevisedAt int64
 }
 
 // OutboxEntry represents a pending outgoing message.
@@ -37,9 +150,20 @@ type OutboxEntry struct {
 	ClientMsgID  string
 	ChatJID      string
 	Body         string
-	Status       string // queued, sending, sent, failed
-	ErrorMessage string
+	Status       string // queued, sending, sent, dead, cancelled
+	ErrorMessage string // error of the last failed attempt
 	ServerMsgID  string
+	Kind         string // text, media, reaction, edit, revoke
+	TargetMsgID  string // message a reaction, edit or revoke applies to, or a text replies to
+	Attempts     int    // send attempts made so far
+	NextAttempt  int64  // Unix ms before which a queued entry is not sent
+	SendAt       int64  // Unix ms a scheduled entry is due; 0 when not scheduled
+}
+
+// HasMessage reports whether the entry has a message row in its chat:
+// texts and media do; reactions, edits and revokes change another message.
+func (e *OutboxEntry) HasMessage() bool {
+	return e.Kind == "text" || e.Kind == "media"
 }
 
 // SearchResult holds a message with a search snippet.
@@ -47,3 +171,105 @@ type SearchResult struct {
 	Message Message
 	Snippet string
 }
+
+// Receipt is a delivery state change reported by WhatsApp for one or more
+// messages in a chat. Status is one of delivered, read or played.
+type Receipt struct {
+	ChatJID   string
+	MsgIDs    []string
+	Status    string
+	Timestamp int64
+}
+
+// Reaction is one sender's emoji reaction to a message. An empty Emoji
+// removes the sender's reaction. Our own reactions have FromMe set and
+// no SenderJID.
+type Reaction struct {
+	ChatJID   string
+	MsgID     string
+	SenderJID string
+	Emoji     string
+	FromMe    bool
+	Timestamp int64
+}
+
+// ReactionCount aggregates the reactions to a message by emoji.
+type ReactionCount struct {
+	Emoji  string
+	Count  int
+	FromMe bool // one of the reactions is ours
+}
+
+// MessageKey identifies a stored message the way WhatsApp addresses it:
+// ID is the WhatsApp message ID (the server ID for messages sent from
+// this device) and SenderJID is empty when FromMe is set.
+type MessageKey struct {
+	ChatJID   string
+	ID        string
+	SenderJID string
+	FromMe    bool
+}
+
+// QuotedMessage is what a reply needs to quote a stored message.
+type QuotedMessage struct {
+	Key  MessageKey
+	Body string
+}
+
+// Media is the metadata of a message attachment: everything needed to
+// download and decrypt it, plus what to show before it is downloaded.
+// LocalPath is set once the file is in the media cache.
+type Media struct {
+	ChatJID       string
+	MsgID         string
+	MediaType     string // image, video, audio, document, sticker
+	Mimetype      string
+	FileLength    int64
+	FileName      string
+	Caption       string
+	DirectPath    string
+	MediaKey      []byte
+	FileSHA256    []byte
+	FileEncSHA256 []byte
+	Thumbnail     []byte
+	Voice         bool // audio recorded as a voice note
+	LocalPath     string
+}
+
+// Event is a journaled bus event. ID increases monotonically and is the
+// cursor clients resume from. Payload is the encoded proto payload.
+type Event struct {
+	ID            int64
+	Kind          string
+	ChatJID       string
+	CorrelationID string
+	Payload       []byte
+	OccurredAt    int64
+}
+
+// HistoryBatch is one chunk of a history sync pushed by the phone.
+// SyncType is the lowercased whatsmeow sync type (initial_bootstrap,
+// recent, full, on_demand, ...); Progress is the percent of that sync
+// done, 0 when the chunk does not say. Chats lists the conversations of
+// the chunk, including those without messages.
+type HistoryBatch struct {
+	SyncType   string
+	ChunkOrder int
+	Progress   int
+	Chats      []string
+	Messages   []*Message
+}
+
+// HistorySyncState is the progress of history sync, kept in sync_state.
+// SyncType, ChunkOrder and Progress are of the last chunk not on demand.
+// The totals count the messages and chats history sync stored first, so
+// chunks sent again do not add to them; LastSyncAt is when the last chunk
+// was, 0 before any.
+type HistorySyncState struct {
+	SyncType       string
+	ChunkOrder     int
+	Progress       int
+	MessagesSynced int64
+	ChatsSynced    int64
+	LastSyncAt     int64
+}
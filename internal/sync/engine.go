@@ -3,6 +3,7 @@ package sync
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,7 +33,7 @@ func NewEngine(db *store.DB, b *bus.Bus, logger *zap.Logger) *Engine {
 // Start subscribes to inbound WhatsApp events on the bus.
 func (e *Engine) Start(ctx context.Context) {
 	ctx, e.cancel = context.WithCancel(ctx)
-	ch, unsub := e.bus.Subscribe("wa.", 256)
+	ch, unsub := e.bus.Subscribe("wa.", 256, bus.Lossless(), bus.Named("sync-engine"))
 
 	go func() {
 		defer unsub()
@@ -65,14 +66,99 @@ func (e *Engine) handleEvent(evt bus.Event) {
 			e.logger.Error("failed to ingest message", zap.Error(err), zap.String("msg_id", msg.MsgID))
 		}
 	case "wa.history_batch":
-		msgs, ok := evt.Payload.([]*store.Message)
+		batch, ok := evt.Payload.(*store.HistoryBatch)
 		if !ok {
 			return
 		}
-		if err := e.IngestHistoryBatch(msgs); err != nil {
-			e.logger.Error("failed to ingest history batch", zap.Error(err), zap.Int("count", len(msgs)))
+		if err := e.IngestHistoryBatch(batch); err != nil {
+			e.logger.Error("failed to ingest history batch", zap.Error(err), zap.Int("count", len(batch.Messages)))
 		} else {
-			e.logger.Info("history batch ingested", zap.Int("messages", len(msgs)))
+			e.logger.Info("history batch ingested", zap.Int("messages", len(batch.Messages)),
+				zap.String("sync_type", batch.SyncType), zap.Int("progress", batch.Progress))
+		}
+	case "wa.receipt":
+		r, ok := evt.Payload.(*store.Receipt)
+		if !ok {
+			return
+		}
+		if err := e.IngestReceipt(r); err != nil {
+			e.logger.Error("failed to ingest receipt", zap.Error(err), zap.String("chat_jid", r.ChatJID))
+		}
+	case "wa.message_change":
+		change, ok := evt.Payload.(*store.MessageChange)
+		if !ok {
+			return
+		}
+		if err := e.IngestMessageChange(change); err != nil {
+			e.logger.Error("failed to ingest message change", zap.Error(err), zap.String("msg_id", change.MsgID))
+		}
+	case "wa.message_change_batch":
+		changes, ok := evt.Payload.([]*store.MessageChange)
+		if !ok {
+			return
+		}
+		for _, change := range changes {
+			if err := e.IngestMessageChange(change); err != nil {
+				e.logger.Error("failed to ingest message change", zap.Error(err), zap.String("msg_id", change.MsgID))
+			}
+		}
+	case "wa.reaction":
+		reaction, ok := evt.Payload.(*store.Reaction)
+		if !ok {
+			return
+		}
+		if err := e.IngestReaction(reaction); err != nil {
+			e.logger.Error("failed to ingest reaction", zap.Error(err), zap.String("msg_id", reaction.MsgID))
+		}
+	case "wa.reaction_batch":
+		reactions, ok := evt.Payload.([]*store.Reaction)
+		if !ok {
+			return
+		}
+		for _, reaction := range reactions {
+			if err := e.IngestReaction(reaction); err != nil {
+				e.logger.Error("failed to ingest reaction", zap.Error(err), zap.String("msg_id", reaction.MsgID))
+			}
+		}
+	case "wa.chat_read":
+		r, ok := evt.Payload.(*store.ChatReadState)
+		if !ok {
+			return
+		}
+		if err := e.IngestChatRead(r); err != nil {
+			e.logger.Error("failed to ingest chat read state", zap.Error(err), zap.String("chat_jid", r.ChatJID))
+		}
+	case "wa.chat_settings":
+		settings, ok := evt.Payload.(*store.ChatSettings)
+		if !ok {
+			return
+		}
+		if err := e.IngestChatSettings(settings); err != nil {
+			e.logger.Error("failed to ingest chat settings", zap.Error(err), zap.String("chat_jid", settings.ChatJID))
+		}
+	case "wa.chat_clear":
+		c, ok := evt.Payload.(*store.ChatClear)
+		if !ok {
+			return
+		}
+		if err := e.IngestChatClear(c); err != nil {
+			e.logger.Error("failed to clear chat", zap.Error(err), zap.String("chat_jid", c.ChatJID))
+		}
+	case "wa.group":
+		g, ok := evt.Payload.(*store.Group)
+		if !ok {
+			return
+		}
+		if err := e.IngestGroup(g); err != nil {
+			e.logger.Error("failed to ingest group", zap.Error(err), zap.String("jid", g.JID))
+		}
+	case "wa.group_change":
+		change, ok := evt.Payload.(*store.GroupChange)
+		if !ok {
+			return
+		}
+		if err := e.IngestGroupChange(change); err != nil {
+			e.logger.Error("failed to ingest group change", zap.Error(err), zap.String("jid", change.GroupJID))
 		}
 	case "wa.contact":
 		contact, ok := evt.Payload.(*store.Contact)
@@ -101,9 +187,39 @@ func (e *Engine) handleEvent(evt bus.Event) {
 
 // IngestMessage processes a single message into the store (idempotent).
 func (e *Engine) IngestMessage(msg *store.Message) error {
-	if err := e.db.UpsertChat(&store.Chat{
+	// Our own message may be one the outbox has not recorded as sent yet;
+	// settle it so it is not sent again and ingest into its row.
+	if msg.FromMe {
+		clientMsgID, err := e.db.ReconcileOutbox(msg.ChatJID, msg.MsgID)
+		if err != nil {
+			return fmt.Errorf("reconcile outbox: %w", err)
+		}
+		if clientMsgID != "" {
+			e.bus.Publish(bus.Event{
+				Kind:      "message.send_ack",
+				Timestamp: time.Now(),
+				Payload: map[string]string{
+					"client_msg_id": clientMsgID,
+					"chat_jid":      msg.ChatJID,
+					"server_msg_id": msg.MsgID,
+				},
+			})
+		}
+	}
+
+	// Only the first ingest of an incoming message counts as unread.
+	exists, err := e.db.MessageExists(msg.ChatJID, msg.MsgID)
+	if err != nil {
+		return fmt.Errorf("check message: %w", err)
+	}
+	unread := 0
+	if !exists && !msg.FromMe {
+		unread = 1
+	}
+	if err := e.db.TouchChat(&store.Chat{
 		JID:                msg.ChatJID,
 		IsGroup:            isGroup(msg.ChatJID),
+		UnreadCount:        unread,
 		LastMessageAt:      msg.Timestamp,
 		LastMessagePreview: truncate(msg.Body, 100),
 	}); err != nil {
@@ -113,6 +229,11 @@ func (e *Engine) IngestMessage(msg *store.Message) error {
 	if err := e.db.UpsertMessage(msg); err != nil {
 		return fmt.Errorf("upsert message: %w", err)
 	}
+	if msg.Media != nil {
+		if err := e.db.UpsertMedia(msg.Media); err != nil {
+			return fmt.Errorf("upsert media: %w", err)
+		}
+	}
 
 	e.bus.Publish(bus.Event{
 		Kind:      "message.upserted",
@@ -120,24 +241,249 @@ func (e *Engine) IngestMessage(msg *store.Message) error {
 		Payload: map[string]string{
 			"chat_jid": msg.ChatJID,
 			"msg_id":   msg.MsgID,
+			"is_new":   strconv.FormatBool(!exists),
+		},
+	})
+
+	return nil
+}
+
+// IngestMessageChange applies an edit or revoke to a stored message.
+// Changes whose target is not stored are dropped.
+func (e *Engine) IngestMessageChange(c *store.MessageChange) error {
+	msgID, err := e.db.ApplyMessageChange(c)
+	if err != nil {
+		return fmt.Errorf("apply %s: %w", c.Kind, err)
+	}
+	if msgID == "" {
+		return nil
+	}
+	e.bus.Publish(bus.Event{
+		Kind:      "message.upserted",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"chat_jid": c.ChatJID,
+			"msg_id":   msgID,
+		},
+	})
+	return nil
+}
+
+// IngestReaction stores a reaction and publishes message.upserted for the
+// target, whose reaction counts changed.
+func (e *Engine) IngestReaction(r *store.Reaction) error {
+	msgID, err := e.db.ApplyReaction(r)
+	if err != nil {
+		return fmt.Errorf("apply reaction: %w", err)
+	}
+	e.bus.Publish(bus.Event{
+		Kind:      "message.upserted",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"chat_jid": r.ChatJID,
+			"msg_id":   msgID,
 		},
 	})
+	return nil
+}
 
+// IngestChatRead applies the read state WhatsApp reports for a chat and
+// publishes chat.updated when the chat is stored.
+func (e *Engine) IngestChatRead(r *store.ChatReadState) error {
+	ok, err := e.db.SetChatUnread(r.ChatJID, r.UnreadCount)
+	if err != nil {
+		return fmt.Errorf("set chat unread: %w", err)
+	}
+	if !ok {
+		return nil
+	}
+	e.bus.Publish(bus.Event{
+		Kind:      "chat.updated",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"chat_jid": r.ChatJID,
+		},
+	})
 	return nil
 }
 
-// IngestHistoryBatch processes a batch of history messages in a transaction.
-func (e *Engine) IngestHistoryBatch(msgs []*store.Message) error {
+// IngestChatSettings applies the archive, pin and mute state WhatsApp
+// reports for a chat and publishes chat.updated when the chat is stored.
+func (e *Engine) IngestChatSettings(s *store.ChatSettings) error {
+	ok, err := e.db.SetChatSettings(s)
+	if err != nil {
+		return fmt.Errorf("set chat settings: %w", err)
+	}
+	if !ok {
+		return nil
+	}
+	e.bus.Publish(bus.Event{
+		Kind:      "chat.updated",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"chat_jid": s.ChatJID,
+		},
+	})
+	return nil
+}
+
+// IngestChatClear removes the messages of a chat cleared or deleted on
+// another device and publishes chat.cleared.
+func (e *Engine) IngestChatClear(c *store.ChatClear) error {
+	deleted, err := e.db.ClearChat(c)
+	if err != nil {
+		return fmt.Errorf("clear chat: %w", err)
+	}
+	e.bus.Publish(bus.Event{
+		Kind:      "chat.cleared",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"chat_jid": c.ChatJID,
+			"deleted":  strconv.FormatBool(deleted),
+		},
+	})
+	return nil
+}
+
+// IngestGroup stores full group metadata and publishes chat.updated.
+func (e *Engine) IngestGroup(g *store.Group) error {
+	if err := e.db.UpsertGroup(g); err != nil {
+		return fmt.Errorf("upsert group: %w", err)
+	}
+	e.bus.Publish(bus.Event{
+		Kind:      "chat.updated",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"chat_jid": g.JID,
+		},
+	})
+	return nil
+}
+
+// IngestGroupChange applies a group notification and records participant
+// joins and leaves as system messages in the group's thread.
+func (e *Engine) IngestGroupChange(c *store.GroupChange) error {
+	if err := e.db.ApplyGroupChange(c); err != nil {
+		return fmt.Errorf("apply group change: %w", err)
+	}
+
+	for _, p := range c.Join {
+		if err := e.ingestSystemMessage(c.GroupJID, p, "joined", c.Timestamp); err != nil {
+			return err
+		}
+	}
+	for _, p := range c.Leave {
+		if err := e.ingestSystemMessage(c.GroupJID, p, "left", c.Timestamp); err != nil {
+			return err
+		}
+	}
+
+	e.bus.Publish(bus.Event{
+		Kind:      "chat.updated",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"chat_jid": c.GroupJID,
+		},
+	})
+	return nil
+}
+
+// ingestSystemMessage stores a "<participant> <action>" row in a group's
+// thread. Its ID is derived from the event, so redelivery is idempotent,
+// and it never counts as unread.
+func (e *Engine) ingestSystemMessage(groupJID, participant, action string, ts int64) error {
+	name, err := e.db.DisplayName(participant)
+	if err != nil {
+		return fmt.Errorf("resolve participant name: %w", err)
+	}
+	msg := &store.Message{
+		ChatJID:     groupJID,
+		MsgID:       fmt.Sprintf("system-%d-%s-%s", ts, action, participant),
+		SenderJID:   participant,
+		Body:        name + " " + action,
+		MessageType: "system",
+		Status:      "received",
+		Timestamp:   ts,
+	}
+	if err := e.db.TouchChat(&store.Chat{
+		JID:                groupJID,
+		IsGroup:            true,
+		LastMessageAt:      ts,
+		LastMessagePreview: truncate(msg.Body, 100),
+	}); err != nil {
+		return fmt.Errorf("upsert chat: %w", err)
+	}
+	if err := e.db.UpsertMessage(msg); err != nil {
+		return fmt.Errorf("upsert system message: %w", err)
+	}
+	e.bus.Publish(bus.Event{
+		Kind:      "message.upserted",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"chat_jid": groupJID,
+			"msg_id":   msg.MsgID,
+		},
+	})
+	return nil
+}
+
+// IngestReceipt applies a delivery receipt to the matching outgoing messages
+// and publishes a message.status_changed event for each row that advanced.
+func (e *Engine) IngestReceipt(r *store.Receipt) error {
+	changed, err := e.db.ApplyReceipt(r)
+	if err != nil {
+		return fmt.Errorf("apply receipt: %w", err)
+	}
+	for _, msgID := range changed {
+		e.bus.Publish(bus.Event{
+			Kind:      "message.status_changed",
+			Timestamp: time.Now(),
+			Payload: map[string]string{
+				"chat_jid": r.ChatJID,
+				"msg_id":   msgID,
+				"status":   r.Status,
+			},
+		})
+	}
+	return nil
+}
+
+// IngestHistoryBatch processes a chunk of history messages in a
+// transaction and adds the messages and chats it stored first to the
+// history sync progress.
+func (e *Engine) IngestHistoryBatch(b *store.HistoryBatch) error {
 	tx, err := e.db.Begin()
 	if err != nil {
 		return fmt.Errorf("begin tx: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	chatsCount := 0
+	chats := make(map[string]bool)
+	for _, jid := range b.Chats {
+		chats[jid] = true
+	}
 	msgsCount := 0
+	// Chats and messages already stored, by a live event or an earlier
+	// chunk, are not counted again in the totals.
+	newChats := make(map[string]bool)
+	newMsgs := 0
+
+	for _, sm := range b.Messages {
+		if _, checked := newChats[sm.ChatJID]; !checked {
+			var exists bool
+			if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM chats WHERE jid = ?)`, sm.ChatJID).Scan(&exists); err != nil {
+				return fmt.Errorf("check chat in batch: %w", err)
+			}
+			newChats[sm.ChatJID] = !exists
+		}
+		var exists bool
+		if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM messages WHERE chat_jid = ? AND msg_id = ?)`, sm.ChatJID, sm.MsgID).Scan(&exists); err != nil {
+			return fmt.Errorf("check message in batch: %w", err)
+		}
+		if !exists {
+			newMsgs++
+		}
 
-	for _, sm := range msgs {
 		if _, err := tx.Exec(`
 			INSERT INTO chats (jid, is_group, last_message_at, last_message_preview, updated_at)
 			VALUES (?, ?, ?, ?, ?)
@@ -149,16 +495,9 @@ func (e *Engine) IngestHistoryBatch(msgs []*store.Message) error {
 			sm.ChatJID, isGroup(sm.ChatJID), sm.Timestamp, truncate(sm.Body, 100), time.Now().UnixMilli()); err != nil {
 			return fmt.Errorf("upsert chat in batch: %w", err)
 		}
-		chatsCount++
+		chats[sm.ChatJID] = true
 
-		if _, err := tx.Exec(`
-			INSERT INTO messages (chat_jid, msg_id, sender_jid, sender_name, body, message_type, from_me, status, timestamp, created_at)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-			ON CONFLICT(chat_jid, msg_id) DO UPDATE SET
-				sender_name = excluded.sender_name,
-				body = excluded.body,
-				status = excluded.status`,
-			sm.ChatJID, sm.MsgID, sm.SenderJID, sm.SenderName, sm.Body, sm.MessageType, sm.FromMe, sm.Status, sm.Timestamp, time.Now().UnixMilli()); err != nil {
+		if err := store.UpsertMessageTx(tx, sm); err != nil {
 			return fmt.Errorf("upsert message in batch: %w", err)
 		}
 		msgsCount++
@@ -168,12 +507,53 @@ func (e *Engine) IngestHistoryBatch(msgs []*store.Message) error {
 		return fmt.Errorf("commit batch: %w", err)
 	}
 
+	for _, sm := range b.Messages {
+		if sm.Media == nil {
+			continue
+		}
+		if err := e.db.UpsertMedia(sm.Media); err != nil {
+			return fmt.Errorf("upsert media in batch: %w", err)
+		}
+	}
+
+	createdChats := 0
+	for _, created := range newChats {
+		if created {
+			createdChats++
+		}
+	}
+	if err := e.db.RecordHistoryBatch(b, newMsgs, createdChats); err != nil {
+		return fmt.Errorf("record history progress: %w", err)
+	}
+	state, err := e.db.GetHistorySyncState()
+	if err != nil {
+		return fmt.Errorf("get history progress: %w", err)
+	}
+
+	// An on-demand chunk answers the backfill requested for its chat.
+	var chatJID, requestID string
+	if b.SyncType == "on_demand" && len(chats) == 1 {
+		for jid := range chats {
+			chatJID = jid
+		}
+		if requestID, err = e.db.ClaimBackfillRequest(chatJID); err != nil {
+			return fmt.Errorf("claim backfill request: %w", err)
+		}
+	}
+
 	e.bus.Publish(bus.Event{
 		Kind:      "sync.history_batch",
 		Timestamp: time.Now(),
-		Payload: map[string]int{
-			"messages_count": msgsCount,
-			"chats_count":    chatsCount,
+		Payload: map[string]string{
+			"chat_jid":       chatJID,
+			"request_id":     requestID,
+			"messages_count": strconv.Itoa(msgsCount),
+			"chats_count":    strconv.Itoa(len(chats)),
+			"sync_type":      b.SyncType,
+			"chunk_order":    strconv.Itoa(b.ChunkOrder),
+			"progress":       strconv.Itoa(b.Progress),
+			"total_messages": strconv.FormatInt(state.MessagesSynced, 10),
+			"total_chats":    strconv.FormatInt(state.ChatsSynced, 10),
 		},
 	})
 
@@ -52,7 +52,7 @@ func TestEngineIngestMessage(t *testing.T) {
 	}
 
 	// Verify message stored.
-	msgs, err := db.ListMessages("chat@s", 0, 10)
+	msgs, err := db.ListMessages("chat@s", store.Cursor{}, 10)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -66,9 +66,20 @@ func TestEngineIngestMessage(t *testing.T) {
 		if evt.Kind != "message.upserted" {
 			t.Errorf("event kind = %q, want message.upserted", evt.Kind)
 		}
+		if p, _ := evt.Payload.(map[string]string); p["is_new"] != "true" {
+			t.Errorf("payload = %v, want is_new true", evt.Payload)
+		}
 	case <-time.After(time.Second):
 		t.Fatal("timeout waiting for message.upserted event")
 	}
+
+	// Redelivery is not new.
+	if err := e.IngestMessage(msg); err != nil {
+		t.Fatal(err)
+	}
+	if evt := <-ch; evt.Payload.(map[string]string)["is_new"] != "false" {
+		t.Errorf("redelivered payload = %v, want is_new false", evt.Payload)
+	}
 }
 
 func TestEngineIngestMessageIdempotent(t *testing.T) {
@@ -87,7 +98,7 @@ func TestEngineIngestMessageIdempotent(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	msgs, err := db.ListMessages("chat@s", 0, 10)
+	msgs, err := db.ListMessages("chat@s", store.Cursor{}, 10)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -99,6 +110,57 @@ func TestEngineIngestMessageIdempotent(t *testing.T) {
 	}
 }
 
+// TestEngineReconcilesOwnEcho verifies that the echo of a message the
+// outbox has not recorded as sent settles the entry instead of leaving it
+// to be sent again.
+func TestEngineReconcilesOwnEcho(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	e := NewEngine(db, b, nil)
+
+	ch, unsub := b.Subscribe("message.send_ack", 10)
+	defer unsub()
+
+	if err := db.UpsertChat(&store.Chat{JID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueueOutboxWithMessage("c1", "3EB0E1", "chat@s", "hello", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.MarkOutboxSending("c1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The echo comes as any ingested message does; settling the outbox
+	// must leave the row sent all the same.
+	msg := &store.Message{
+		ChatJID: "chat@s", MsgID: "3EB0E1", Body: "hello", FromMe: true,
+		MessageType: "text", Status: "received", Timestamp: 1000,
+	}
+	if err := e.IngestMessage(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case evt := <-ch:
+		if p := evt.Payload.(map[string]string); p["client_msg_id"] != "c1" || p["server_msg_id"] != "3EB0E1" {
+			t.Errorf("ack payload = %v, want c1 -> 3EB0E1", p)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for message.send_ack event")
+	}
+	if entry, err := db.GetOutbox("c1"); err != nil || entry == nil || entry.Status != "sent" {
+		t.Errorf("outbox entry = %+v, %v, want sent", entry, err)
+	}
+	msgs, err := db.ListMessages("chat@s", store.Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 1 || msgs[0].MsgID != "3EB0E1" || msgs[0].Status != "sent" {
+		t.Errorf("messages = %+v, want one keyed 3EB0E1 and sent", msgs)
+	}
+}
+
 func TestEngineIngestHistoryBatch(t *testing.T) {
 	db := testDB(t)
 	b := bus.New()
@@ -113,12 +175,12 @@ func TestEngineIngestHistoryBatch(t *testing.T) {
 		{ChatJID: "b@s", MsgID: "m3", Body: "three", MessageType: "text", Timestamp: 3000, Status: "received"},
 	}
 
-	if err := e.IngestHistoryBatch(msgs); err != nil {
+	if err := e.IngestHistoryBatch(&store.HistoryBatch{SyncType: "initial_bootstrap", ChunkOrder: 1, Progress: 50, Messages: msgs}); err != nil {
 		t.Fatal(err)
 	}
 
 	// Verify chats created.
-	chats, err := db.ListChats(10, 0)
+	chats, err := db.ListChats(10, store.Cursor{}, store.ChatFilter{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -127,8 +189,8 @@ func TestEngineIngestHistoryBatch(t *testing.T) {
 	}
 
 	// Verify all messages stored.
-	msgsA, _ := db.ListMessages("a@s", 0, 10)
-	msgsB, _ := db.ListMessages("b@s", 0, 10)
+	msgsA, _ := db.ListMessages("a@s", store.Cursor{}, 10)
+	msgsB, _ := db.ListMessages("b@s", store.Cursor{}, 10)
 	if len(msgsA) != 2 || len(msgsB) != 1 {
 		t.Errorf("got %d+%d messages, want 2+1", len(msgsA), len(msgsB))
 	}
@@ -139,9 +201,62 @@ func TestEngineIngestHistoryBatch(t *testing.T) {
 		if evt.Kind != "sync.history_batch" {
 			t.Errorf("event kind = %q, want sync.history_batch", evt.Kind)
 		}
+		p, _ := evt.Payload.(map[string]string)
+		if p["messages_count"] != "3" || p["chats_count"] != "2" || p["progress"] != "50" || p["total_messages"] != "3" {
+			t.Errorf("payload = %v, want 3 messages in 2 chats at 50%%", p)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for sync.history_batch event")
+	}
+
+	// Progress is persisted and totals accumulate across chunks.
+	more := []*store.Message{{ChatJID: "c@s", MsgID: "m4", Body: "four", MessageType: "text", Timestamp: 4000, Status: "received"}}
+	if err := e.IngestHistoryBatch(&store.HistoryBatch{SyncType: "initial_bootstrap", ChunkOrder: 2, Progress: 100, Messages: more}); err != nil {
+		t.Fatal(err)
+	}
+	state, err := db.GetHistorySyncState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.SyncType != "initial_bootstrap" || state.ChunkOrder != 2 || state.Progress != 100 {
+		t.Errorf("state = %+v, want initial_bootstrap chunk 2 at 100%%", state)
+	}
+	if state.MessagesSynced != 4 || state.ChatsSynced != 3 || state.LastSyncAt == 0 {
+		t.Errorf("totals = %d messages, %d chats, last at %d; want 4, 3, set", state.MessagesSynced, state.ChatsSynced, state.LastSyncAt)
+	}
+}
+
+func TestEngineHistoryBatchAnswersBackfill(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	e := NewEngine(db, b, nil)
+
+	if err := db.SetBackfillRequest("a@s", "REQ1"); err != nil {
+		t.Fatal(err)
+	}
+	ch, unsub := b.Subscribe("sync.history_batch", 10)
+	defer unsub()
+
+	batch := &store.HistoryBatch{SyncType: "on_demand", Chats: []string{"a@s"}, Messages: []*store.Message{
+		{ChatJID: "a@s", MsgID: "old1", Body: "older", MessageType: "text", Timestamp: 500, Status: "received"},
+	}}
+	if err := e.IngestHistoryBatch(batch); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case evt := <-ch:
+		p, _ := evt.Payload.(map[string]string)
+		if p["chat_jid"] != "a@s" || p["request_id"] != "REQ1" {
+			t.Errorf("payload = %v, want the answer to REQ1 for a@s", p)
+		}
 	case <-time.After(time.Second):
 		t.Fatal("timeout waiting for sync.history_batch event")
 	}
+
+	// The request is answered once; a later chunk is not attributed to it.
+	if id, err := db.ClaimBackfillRequest("a@s"); err != nil || id != "" {
+		t.Errorf("ClaimBackfillRequest = %q, %v; want it claimed by the batch", id, err)
+	}
 }
 
 func TestEngineHistoryBatchIdempotent(t *testing.T) {
@@ -153,17 +268,37 @@ func TestEngineHistoryBatchIdempotent(t *testing.T) {
 	}
 
 	// Ingest twice.
-	if err := e.IngestHistoryBatch(msgs); err != nil {
+	if err := e.IngestHistoryBatch(&store.HistoryBatch{SyncType: "recent", Progress: 40, Messages: msgs}); err != nil {
 		t.Fatal(err)
 	}
-	if err := e.IngestHistoryBatch(msgs); err != nil {
+	if err := e.IngestHistoryBatch(&store.HistoryBatch{SyncType: "recent", Progress: 40, Messages: msgs}); err != nil {
 		t.Fatal(err)
 	}
 
-	stored, _ := db.ListMessages("a@s", 0, 10)
+	stored, _ := db.ListMessages("a@s", store.Cursor{}, 10)
 	if len(stored) != 1 {
 		t.Errorf("got %d messages, want 1 (idempotent batch)", len(stored))
 	}
+
+	// A backfill of a chat adds what it stores first, but is not the
+	// progress of the sync.
+	backfill := []*store.Message{
+		{ChatJID: "a@s", MsgID: "m0", Body: "older", MessageType: "text", Timestamp: 500, Status: "received"},
+		{ChatJID: "a@s", MsgID: "m1", Body: "hello", MessageType: "text", Timestamp: 1000, Status: "received"},
+	}
+	if err := e.IngestHistoryBatch(&store.HistoryBatch{SyncType: "on_demand", Progress: 100, Messages: backfill}); err != nil {
+		t.Fatal(err)
+	}
+	state, err := db.GetHistorySyncState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.SyncType != "recent" || state.Progress != 40 {
+		t.Errorf("state = %+v, want recent at 40%%", state)
+	}
+	if state.MessagesSynced != 2 || state.ChatsSynced != 1 {
+		t.Errorf("totals = %d messages, %d chats; want 2, 1", state.MessagesSynced, state.ChatsSynced)
+	}
 }
 
 // TestEngineBusSubscription verifies the engine processes events from the bus.
@@ -191,7 +326,7 @@ func TestEngineBusSubscription(t *testing.T) {
 	// Give the engine time to process.
 	time.Sleep(100 * time.Millisecond)
 
-	msgs, err := db.ListMessages("bus-test@s", 0, 10)
+	msgs, err := db.ListMessages("bus-test@s", store.Cursor{}, 10)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -206,15 +341,15 @@ func TestEngineBusSubscription(t *testing.T) {
 	b.Publish(bus.Event{
 		Kind:      "wa.history_batch",
 		Timestamp: time.Now(),
-		Payload: []*store.Message{
+		Payload: &store.HistoryBatch{Messages: []*store.Message{
 			{ChatJID: "batch@s", MsgID: "hm1", Body: "history", MessageType: "text", Timestamp: 6000, Status: "received"},
 			{ChatJID: "batch@s", MsgID: "hm2", Body: "history2", MessageType: "text", Timestamp: 7000, Status: "received"},
-		},
+		}},
 	})
 
 	time.Sleep(100 * time.Millisecond)
 
-	msgs, err = db.ListMessages("batch@s", 0, 10)
+	msgs, err = db.ListMessages("batch@s", store.Cursor{}, 10)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -222,3 +357,262 @@ func TestEngineBusSubscription(t *testing.T) {
 		t.Errorf("got %d messages, want 2 (history batch via bus)", len(msgs))
 	}
 }
+
+func TestEngineIngestReceipt(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	e := NewEngine(db, b, nil)
+
+	msg := &store.Message{
+		ChatJID: "chat@s", MsgID: "m1", Body: "hello",
+		MessageType: "text", FromMe: true, Status: "sent", Timestamp: 1000,
+	}
+	if err := e.IngestMessage(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	ch, unsub := b.Subscribe("message.status_changed", 10)
+	defer unsub()
+
+	if err := e.IngestReceipt(&store.Receipt{ChatJID: "chat@s", MsgIDs: []string{"m1"}, Status: "delivered"}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case evt := <-ch:
+		p, _ := evt.Payload.(map[string]string)
+		if p["msg_id"] != "m1" || p["status"] != "delivered" {
+			t.Errorf("payload = %v, want m1 delivered", p)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for message.status_changed event")
+	}
+
+	msgs, err := db.ListMessages("chat@s", store.Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 1 || msgs[0].Status != "delivered" {
+		t.Errorf("status = %q, want delivered", msgs[0].Status)
+	}
+}
+
+func TestEngineIngestMessageChange(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	e := NewEngine(db, b, nil)
+
+	if err := e.IngestMessage(&store.Message{ChatJID: "chat@s", MsgID: "m1", Body: "v1", MessageType: "text", Timestamp: 1000}); err != nil {
+		t.Fatal(err)
+	}
+
+	ch, unsub := b.Subscribe("message.upserted", 10)
+	defer unsub()
+
+	if err := e.IngestMessageChange(&store.MessageChange{ChatJID: "chat@s", MsgID: "m1", Kind: "edit", Body: "v2", SenderJID: "chat@s", Timestamp: 2000}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for message.upserted event")
+	}
+
+	msgs, err := db.ListMessages("chat@s", store.Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 1 || msgs[0].Body != "v2" || msgs[0].EditedAt != 2000 {
+		t.Errorf("got %+v, want body v2 edited at 2000", msgs)
+	}
+}
+
+func TestEngineIngestReaction(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	e := NewEngine(db, b, nil)
+
+	if err := e.IngestMessage(&store.Message{ChatJID: "chat@s", MsgID: "m1", Body: "hi", MessageType: "text", Timestamp: 1000}); err != nil {
+		t.Fatal(err)
+	}
+
+	ch, unsub := b.Subscribe("message.upserted", 10)
+	defer unsub()
+
+	if err := e.IngestReaction(&store.Reaction{ChatJID: "chat@s", MsgID: "m1", SenderJID: "bob@s", Emoji: "❤️", Timestamp: 2000}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case evt := <-ch:
+		p, _ := evt.Payload.(map[string]string)
+		if p["msg_id"] != "m1" {
+			t.Errorf("payload = %v, want msg_id m1", p)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for message.upserted event")
+	}
+
+	msgs, err := db.ListMessages("chat@s", store.Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 1 || len(msgs[0].Reactions) != 1 || msgs[0].Reactions[0].Emoji != "❤️" {
+		t.Errorf("got %+v, want one ❤️ reaction", msgs)
+	}
+}
+
+func TestEngineIngestMessageWithMedia(t *testing.T) {
+	db := testDB(t)
+	e := NewEngine(db, bus.New(), nil)
+
+	msg := &store.Message{
+		ChatJID: "chat@s", MsgID: "img1", Body: "sunset", MessageType: "image", Timestamp: 1000,
+		Media: &store.Media{ChatJID: "chat@s", MsgID: "img1", MediaType: "image", Mimetype: "image/jpeg", Caption: "sunset"},
+	}
+	if err := e.IngestMessage(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs, err := db.ListMessages("chat@s", store.Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 1 || msgs[0].Media == nil || msgs[0].Media.Mimetype != "image/jpeg" {
+		t.Errorf("got %+v, want image message with media", msgs)
+	}
+}
+
+func TestEngineUnreadCount(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	e := NewEngine(db, b, nil)
+
+	if err := db.UpsertChat(&store.Chat{JID: "chat@s", Name: "Alice"}); err != nil {
+		t.Fatal(err)
+	}
+	incoming := &store.Message{ChatJID: "chat@s", MsgID: "m1", Body: "hi", MessageType: "text", Timestamp: 1000}
+	for range 2 {
+		if err := e.IngestMessage(incoming); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := e.IngestMessage(&store.Message{ChatJID: "chat@s", MsgID: "m2", Body: "yo", MessageType: "text", FromMe: true, Timestamp: 2000}); err != nil {
+		t.Fatal(err)
+	}
+
+	chat, err := db.GetChat("chat@s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chat.UnreadCount != 1 || chat.Name != "Alice" {
+		t.Errorf("chat = %+v, want name Alice with 1 unread", chat)
+	}
+
+	ch, unsub := b.Subscribe("chat.updated", 10)
+	defer unsub()
+
+	if err := e.IngestChatRead(&store.ChatReadState{ChatJID: "chat@s"}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for chat.updated event")
+	}
+	if chat, _ := db.GetChat("chat@s"); chat.UnreadCount != 0 {
+		t.Errorf("unread = %d, want 0 after read", chat.UnreadCount)
+	}
+}
+
+func TestEngineChatSettingsAndClear(t *testing.T) {
+	db := testDB(t)
+	b := bus.New()
+	e := NewEngine(db, b, nil)
+
+	ch, unsub := b.Subscribe("chat.", 10)
+	defer unsub()
+
+	// Settings of a chat that is not stored yet are dropped.
+	archived := true
+	if err := e.IngestChatSettings(&store.ChatSettings{ChatJID: "chat@s", Archived: &archived}); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.IngestMessage(&store.Message{ChatJID: "chat@s", MsgID: "m1", Body: "hi", MessageType: "text", Timestamp: 1000}); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.IngestChatSettings(&store.ChatSettings{ChatJID: "chat@s", Archived: &archived}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case evt := <-ch:
+		if evt.Kind != "chat.updated" {
+			t.Fatalf("event kind = %q, want chat.updated", evt.Kind)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for chat.updated event")
+	}
+	if chat, _ := db.GetChat("chat@s"); chat == nil || !chat.Archived {
+		t.Errorf("chat = %+v, want archived", chat)
+	}
+
+	if err := e.IngestChatClear(&store.ChatClear{ChatJID: "chat@s", Delete: true}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case evt := <-ch:
+		m := evt.Payload.(map[string]string)
+		if evt.Kind != "chat.cleared" || m["chat_jid"] != "chat@s" || m["deleted"] != "true" {
+			t.Errorf("event = %s %v, want chat.cleared of deleted chat@s", evt.Kind, m)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for chat.cleared event")
+	}
+	if chat, _ := db.GetChat("chat@s"); chat != nil {
+		t.Errorf("chat = %+v, want deleted", chat)
+	}
+}
+
+func TestEngineIngestGroupChange(t *testing.T) {
+	db := testDB(t)
+	e := NewEngine(db, bus.New(), nil)
+
+	if err := db.UpsertContact(&store.Contact{JID: "bob@s", PushName: "Bob"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.IngestGroup(&store.Group{JID: "g@g.us", Name: "Team", Participants: []store.GroupParticipant{{JID: "alice@s"}}}); err != nil {
+		t.Fatal(err)
+	}
+	change := &store.GroupChange{GroupJID: "g@g.us", Join: []string{"bob@s"}, Leave: []string{"alice@s"}, Timestamp: 2000}
+	// Redelivered notifications must not duplicate system rows.
+	for range 2 {
+		if err := e.IngestGroupChange(change); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	msgs, err := db.ListMessages("g@g.us", store.Cursor{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 2 {
+		t.Fatalf("got %d messages, want 2 system rows", len(msgs))
+	}
+	bodies := map[string]bool{}
+	for _, m := range msgs {
+		if m.MessageType != "system" {
+			t.Errorf("message type = %q, want system", m.MessageType)
+		}
+		bodies[m.Body] = true
+	}
+	if !bodies["Bob joined"] || !bodies["alice@s left"] {
+		t.Errorf("bodies = %v, want Bob joined and alice@s left", bodies)
+	}
+
+	chat, err := db.GetChat("g@g.us")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chat.Name != "Team" || chat.UnreadCount != 0 {
+		t.Errorf("chat = %+v, want Team with no unread", chat)
+	}
+}
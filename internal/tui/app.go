@@ -3,7 +3,11 @@ package tui
 import (
 	"context"
 	"io"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
@@ -14,6 +18,8 @@ import (
 	"github.com/matheus3301/wpp/internal/tui/ui"
 	"github.com/matheus3301/wpp/internal/tui/views"
 	"github.com/rivo/tview"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
 )
 
 // App is the main TUI application shell.
@@ -41,6 +47,9 @@ type App struct {
 	promptVisible bool
 	promptRow     *tview.Flex
 
+	// Set while a page of chats or messages is loading.
+	loadingPage atomic.Bool
+
 	// Views.
 	convList  *views.ConversationList
 	msgThread *views.MessageThread
@@ -48,6 +57,10 @@ type App struct {
 	searchV   *views.SearchView
 	authView  *views.AuthView
 	helpView  *views.HelpView
+	schedV    *views.ScheduledView
+
+	// Message the user pressed D on once; a second D deletes it.
+	pendingDelete string
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -78,6 +91,7 @@ func NewApp(c *client.Client, sessionName string) *App {
 		searchV:     views.NewSearchView(theme),
 		authView:    views.NewAuthView(theme),
 		helpView:    views.NewHelpView(theme),
+		schedV:      views.NewScheduledView(theme),
 		ctx:         ctx,
 		cancel:      cancel,
 	}
@@ -98,21 +112,57 @@ func (a *App) setupCallbacks() {
 		}
 	})
 
+	// Infinite scroll: more chats at the bottom, older messages at the top.
+	a.convList.SetOnScrollEnd(func() {
+		go a.loadPage(a.vm.LoadMoreChats)
+	})
+	a.msgThread.SetOnScrollTop(func() {
+		go a.loadPage(func(ctx context.Context) error {
+			loaded, err := a.vm.LoadOlderMessages(ctx)
+			if err != nil || loaded {
+				return err
+			}
+			// Local history is exhausted: ask the phone for more.
+			_, err = a.vm.BackfillChat(ctx)
+			return err
+		})
+	})
+
 	// Message thread: send message.
-	a.msgThread.SetOnSend(func(text string) {
+	a.msgThread.SetOnSend(func(text, replyToMsgID string) {
 		chatJID := a.vm.ActiveChatJID
 		if chatJID == "" {
 			return
 		}
 		go func() {
 			clientMsgID := uuid.New().String()
-			if err := a.vm.SendText(a.ctx, chatJID, text, clientMsgID); err != nil {
+			if err := a.vm.SendText(a.ctx, chatJID, text, clientMsgID, replyToMsgID); err != nil {
 				a.vm.FlashUI.Err(err)
 				a.vm.SignalRefresh()
 			}
 		}()
 	})
 
+	// Message thread: tell the chat when we are typing.
+	a.msgThread.SetOnTyping(func(typing bool) {
+		if chatJID := a.vm.ActiveChatJID; chatJID != "" {
+			a.vm.SetComposing(a.ctx, chatJID, typing)
+		}
+	})
+
+	// Message thread: edit one of our messages.
+	a.msgThread.SetOnEdit(func(msgID, text string) {
+		chatJID := a.vm.ActiveChatJID
+		if chatJID == "" {
+			return
+		}
+		go func() {
+			if err := a.vm.EditMessage(a.ctx, chatJID, msgID, text, uuid.New().String()); err != nil {
+				a.vm.FlashUI.Err(err)
+			}
+		}()
+	})
+
 	// Search view: query.
 	a.searchV.SetOnQuery(func(query string) {
 		go func() {
@@ -143,7 +193,7 @@ func (a *App) setupCallbacks() {
 		a.hidePrompt()
 		// Clear filter when cancelling filter mode.
 		if a.pages.Current() == "conversations" {
-			a.convList.ClearFilter()
+			a.clearFilter()
 		}
 	})
 
@@ -151,6 +201,13 @@ func (a *App) setupCallbacks() {
 	a.pages.SetOnChange(func(stack []string) {
 		a.crumbs.Update(stack)
 		a.updateMenu()
+		// Presence is watched, showing us online, while a chat is open.
+		if slices.Contains(stack, "messages") {
+			a.vm.WatchPresence(a.ctx, a.vm.GetActiveChatJID())
+		} else {
+			a.vm.StopWatchingPresence()
+			a.msgThread.SetPresence("")
+		}
 	})
 }
 
@@ -162,6 +219,7 @@ func (a *App) setupLayout() {
 	a.pages.AddPage("search", a.searchV, true, false)
 	a.pages.AddPage("auth", a.authView, true, false)
 	a.pages.AddPage("help", a.helpView, true, false)
+	a.pages.AddPage("scheduled", a.schedV, true, false)
 
 	// Header: SessionInfo (fixed) | Menu (flex) | Logo (fixed).
 	a.header = tview.NewFlex().
@@ -195,9 +253,10 @@ func (a *App) setupInputCapture() {
 	a.app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		focused := a.app.GetFocus()
 
-		// Esc in composer: unfocus composer, return to messages view.
+		// Esc in composer: cancel any reply, unfocus composer, return to messages view.
 		if event.Key() == tcell.KeyEscape {
 			if inp, ok := focused.(*tview.InputField); ok && inp == a.msgThread.Composer() {
+				a.msgThread.CancelReply()
 				a.app.SetFocus(a.msgThread.Messages())
 				return nil
 			}
@@ -221,13 +280,13 @@ func (a *App) setupInputCapture() {
 			if a.promptVisible {
 				a.hidePrompt()
 				if currentPage == "conversations" {
-					a.convList.ClearFilter()
+					a.clearFilter()
 				}
 				return nil
 			}
 			// Clear active filter on conversations page.
 			if currentPage == "conversations" {
-				a.convList.ClearFilter()
+				a.clearFilter()
 				return nil
 			}
 			if a.pages.Depth() > 1 {
@@ -277,7 +336,7 @@ func (a *App) setupInputCapture() {
 		if currentPage == "conversations" {
 			// Numeric shortcuts 0-9.
 			if r == '0' {
-				a.convList.ClearFilter()
+				a.clearFilter()
 				return nil
 			}
 			if r >= '1' && r <= '9' {
@@ -292,6 +351,10 @@ func (a *App) setupInputCapture() {
 				// Sort cycling (future enhancement).
 				return nil
 			}
+			if r == 'a' || r == 'p' || r == 'm' {
+				a.toggleChatSetting(r)
+				return nil
+			}
 		}
 
 		// Message thread specific keys.
@@ -300,20 +363,186 @@ func (a *App) setupInputCapture() {
 				a.app.SetFocus(a.msgThread.Composer())
 				return nil
 			}
+			if r == 'k' {
+				a.msgThread.SelectOlder()
+				return nil
+			}
+			if r == 'j' {
+				a.msgThread.SelectNewer()
+				return nil
+			}
+			if r == 'r' {
+				if a.msgThread.ReplyToSelected() {
+					a.app.SetFocus(a.msgThread.Composer())
+				} else {
+					a.vm.FlashUI.Warn("Select a message to reply to with j/k first")
+				}
+				return nil
+			}
 			if r == 'd' {
-				chat := a.vm.GetChatByJID(a.msgThread.ChatJID())
-				if chat != nil {
-					a.convInfo.Update(chat)
+				a.showDetails(a.msgThread.ChatJID())
+				return nil
+			}
+			if r == 'R' || r == 'x' {
+				a.retryOrCancelSelected(r == 'R')
+				return nil
+			}
+			if r == 'e' {
+				if a.msgThread.EditSelected() {
+					a.app.SetFocus(a.msgThread.Composer())
+				} else {
+					a.vm.FlashUI.Warn("Select a text message you sent with j/k first")
 				}
-				a.pushView("details")
+				return nil
+			}
+			if r == 'D' {
+				a.deleteSelected()
 				return nil
 			}
 		}
 
+		// Scheduled messages specific keys.
+		if currentPage == "scheduled" && (r == 'R' || r == 'x') {
+			a.sendNowOrCancelScheduled(r == 'R')
+			return nil
+		}
+
 		return event
 	})
 }
 
+// retryOrCancelSelected resends or drops the selected message when it is
+// one of ours that was not sent.
+func (a *App) retryOrCancelSelected(retry bool) {
+	m := a.msgThread.SelectedMessage()
+	if m == nil || !m.FromMe || (m.Status != "failed" && m.Status != "queued") {
+		a.vm.FlashUI.Warn("Select a failed message with j/k first")
+		return
+	}
+	go func() {
+		var err error
+		if retry {
+			err = a.vm.RetryMessage(a.ctx, m.Id)
+		} else {
+			err = a.vm.CancelMessage(a.ctx, m.Id)
+		}
+		if err != nil {
+			a.vm.FlashUI.Err(err)
+		}
+	}()
+}
+
+// deleteSelected deletes the selected message for everyone when it is one
+// of ours. The first press only asks for confirmation.
+func (a *App) deleteSelected() {
+	m := a.msgThread.SelectedMessage()
+	if m == nil || !m.FromMe || m.Deleted {
+		a.vm.FlashUI.Warn("Select a message you sent with j/k first")
+		return
+	}
+	if a.pendingDelete != m.Id {
+		a.pendingDelete = m.Id
+		a.vm.FlashUI.Warn("Press D again to delete this message for everyone")
+		return
+	}
+	a.pendingDelete = ""
+	chatJID := a.vm.ActiveChatJID
+	go func() {
+		if err := a.vm.RevokeMessage(a.ctx, chatJID, m.Id, uuid.New().String()); err != nil {
+			a.vm.FlashUI.Err(err)
+		}
+	}()
+}
+
+// toggleChatSetting archives ('a'), pins ('p') or mutes ('m') the selected
+// chat on WhatsApp, or undoes it. The list follows from chat.updated.
+func (a *App) toggleChatSetting(r rune) {
+	chat := a.vm.GetChatByJID(a.convList.SelectedChat())
+	if chat == nil {
+		return
+	}
+	name := chat.Name
+	if name == "" {
+		name = chat.Jid
+	}
+	go func() {
+		var err error
+		var done string
+		switch r {
+		case 'a':
+			archive := !chat.Archived
+			err = a.vm.ArchiveChat(a.ctx, chat.Jid, archive)
+			done = "Unarchived"
+			if archive {
+				done = "Archived"
+			}
+		case 'p':
+			pin := chat.PinnedAtUnixMs == 0
+			err = a.vm.PinChat(a.ctx, chat.Jid, pin)
+			done = "Unpinned"
+			if pin {
+				done = "Pinned"
+			}
+		case 'm':
+			mute := chat.MutedUntilUnixMs >= 0 && chat.MutedUntilUnixMs <= time.Now().UnixMilli()
+			err = a.vm.MuteChat(a.ctx, chat.Jid, mute)
+			done = "Unmuted"
+			if mute {
+				done = "Muted"
+			}
+		}
+		if err != nil {
+			a.vm.FlashUI.Err(err)
+			return
+		}
+		a.vm.FlashUI.Info(done + " " + name)
+	}()
+}
+
+// sendNowOrCancelScheduled sends the selected scheduled message right away
+// or drops it, then reloads the list.
+func (a *App) sendNowOrCancelScheduled(now bool) {
+	m := a.schedV.SelectedMessage()
+	if m == nil {
+		a.vm.FlashUI.Warn("Select a scheduled message first")
+		return
+	}
+	go func() {
+		var err error
+		if now {
+			err = a.vm.RetryMessage(a.ctx, m.ClientMsgId)
+		} else {
+			err = a.vm.CancelScheduled(a.ctx, m.ClientMsgId)
+		}
+		if err != nil {
+			a.vm.FlashUI.Err(err)
+		}
+		a.loadScheduled()
+	}()
+}
+
+// showScheduled opens the list of messages scheduled for later.
+func (a *App) showScheduled() {
+	a.pushView("scheduled")
+	go a.loadScheduled()
+}
+
+func (a *App) loadScheduled() {
+	msgs, err := a.vm.ListScheduled(a.ctx)
+	if err != nil {
+		a.vm.FlashUI.Err(err)
+		return
+	}
+	a.app.QueueUpdateDraw(func() {
+		a.schedV.Update(msgs, func(jid string) string {
+			if chat := a.vm.GetChatByJID(jid); chat != nil && chat.Name != "" {
+				return chat.Name
+			}
+			return jid
+		})
+	})
+}
+
 func (a *App) showPrompt(mode ui.PromptMode) {
 	if a.promptVisible {
 		return
@@ -374,6 +603,10 @@ func (a *App) executeCommand(text string) {
 		if cmd.Args != "" {
 			a.openChatByName(cmd.Args)
 		}
+	case "scheduled", "sched":
+		a.showScheduled()
+	case "attach", "a":
+		a.attachFile(cmd.Args)
 	case "logout":
 		go func() {
 			_, err := a.grpc.Session.Logout(a.ctx, &wppv1.LogoutRequest{})
@@ -393,9 +626,73 @@ func (a *App) executeCommand(text string) {
 	}
 }
 
+// attachFile sends the file at path to the open chat. A leading ~ is
+// expanded, and relative paths are resolved against the working directory,
+// since the daemon reads the file itself.
+func (a *App) attachFile(path string) {
+	chatJID := a.vm.ActiveChatJID
+	if chatJID == "" {
+		a.vm.FlashUI.Warn("Open a chat to attach a file")
+		return
+	}
+	if path == "" {
+		a.vm.FlashUI.Warn("Usage: :attach <path>")
+		return
+	}
+	if rest, ok := strings.CutPrefix(path, "~"); ok && (rest == "" || rest[0] == '/') {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = home + rest
+		}
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		a.vm.FlashUI.Err(err)
+		return
+	}
+	go func() {
+		if err := a.vm.SendMedia(a.ctx, chatJID, abs, uuid.New().String()); err != nil {
+			a.vm.FlashUI.Err(err)
+			a.vm.SignalRefresh()
+		}
+	}()
+}
+
+// applyFilter filters the chat list server-side with the chat filter
+// language (is:unread, name:foo, since:2026-01-01, ...).
 func (a *App) applyFilter(text string) {
-	if a.pages.Current() == "conversations" {
-		a.convList.SetFilter(text)
+	if a.pages.Current() != "conversations" {
+		return
+	}
+	text = strings.TrimSpace(text)
+	go func() {
+		if err := a.vm.SetChatFilter(a.ctx, text); err != nil {
+			a.vm.FlashUI.Err(err)
+			return
+		}
+		a.app.QueueUpdateDraw(func() {
+			a.convList.SetFilter(text)
+		})
+	}()
+}
+
+// clearFilter drops the chat list filter, reloading the full list.
+func (a *App) clearFilter() {
+	if a.vm.ChatFilter() == "" {
+		return
+	}
+	a.convList.ClearFilter()
+	a.applyFilter("")
+}
+
+// loadPage runs one page load at a time; calls made while a load is in
+// flight are dropped.
+func (a *App) loadPage(load func(context.Context) error) {
+	if !a.loadingPage.CompareAndSwap(false, true) {
+		return
+	}
+	defer a.loadingPage.Store(false)
+	if err := load(a.ctx); err != nil {
+		a.vm.FlashUI.Err(err)
 	}
 }
 
@@ -405,6 +702,11 @@ func (a *App) openChat(jid string) {
 			a.vm.FlashUI.Err(err)
 			return
 		}
+		if chat := a.vm.GetChatByJID(jid); chat != nil && chat.UnreadCount > 0 {
+			if err := a.vm.MarkRead(a.ctx, jid); err != nil {
+				a.vm.FlashUI.Err(err)
+			}
+		}
 		chatName := jid
 		chat := a.vm.GetChatByJID(jid)
 		if chat != nil && chat.Name != "" {
@@ -419,6 +721,32 @@ func (a *App) openChat(jid string) {
 	}()
 }
 
+// showDetails opens the details page of a chat, loading group metadata
+// for group chats.
+func (a *App) showDetails(jid string) {
+	chat := a.vm.GetChatByJID(jid)
+	if chat != nil {
+		a.convInfo.Update(chat, nil)
+	}
+	a.pushView("details")
+	if chat == nil || !chat.IsGroup {
+		return
+	}
+	go func() {
+		group, err := a.vm.GetGroupInfo(a.ctx, jid)
+		if err != nil {
+			// Groups not fetched yet just show the chat details.
+			if grpcstatus.Code(err) != codes.NotFound {
+				a.vm.FlashUI.Err(err)
+			}
+			return
+		}
+		a.app.QueueUpdateDraw(func() {
+			a.convInfo.Update(chat, group)
+		})
+	}()
+}
+
 func (a *App) openChatByName(name string) {
 	name = strings.ToLower(name)
 	for _, chat := range a.vm.GetChats() {
@@ -453,6 +781,8 @@ func (a *App) focusCurrentPage() {
 		a.app.SetFocus(a.helpView)
 	case "details":
 		a.app.SetFocus(a.convInfo)
+	case "scheduled":
+		a.app.SetFocus(a.schedV)
 	}
 }
 
@@ -471,6 +801,8 @@ func (a *App) updateMenu() {
 		hints = a.helpView.Hints()
 	case "details":
 		hints = a.convInfo.Hints()
+	case "scheduled":
+		hints = a.schedV.Hints()
 	}
 	a.menu.Update(hints)
 }
@@ -498,6 +830,7 @@ func (a *App) Run() error {
 
 		a.vm.StartWatchingMessages(a.ctx)
 		a.vm.StartWatchingChats(a.ctx)
+		a.vm.StartWatchingSync(a.ctx)
 		a.startRefreshLoop()
 		a.startRefreshListener()
 		a.startFlashListener()
@@ -550,6 +883,7 @@ func (a *App) startRefreshListener() {
 						a.convList.Update(a.vm.GetChats())
 					case "messages":
 						a.msgThread.Update(a.vm.GetMessages())
+						a.msgThread.SetPresence(a.vm.PresenceLine())
 					}
 					a.sessionInfo.Update(a.vm.GetSessionInfo())
 				})
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	wppv1 "github.com/matheus3301/wpp/gen/wpp/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// backfillRetry is how long a backfill request waits for the phone's
+// answer before the same chat may ask again.
+const backfillRetry = time.Minute
+
+// BackfillChat asks the phone for history older than the oldest message
+// of the active chat, once every local page is loaded. It does nothing
+// while older pages remain or the chat's last request is still awaited.
+// Reports whether it asked.
+func (vm *ViewModel) BackfillChat(ctx context.Context) (bool, error) {
+	vm.mu.Lock()
+	chatJID := vm.ActiveChatJID
+	if chatJID == "" || vm.messagesCursor != "" ||
+		(vm.backfillChat == chatJID && time.Since(vm.backfillAt) < backfillRetry) {
+		vm.mu.Unlock()
+		return false, nil
+	}
+	vm.backfillChat, vm.backfillAt = chatJID, time.Now()
+	vm.mu.Unlock()
+
+	_, err := vm.client.Sync.BackfillChat(ctx, &wppv1.BackfillChatRequest{ChatJid: chatJID})
+	if err != nil {
+		vm.mu.Lock()
+		if vm.backfillChat == chatJID {
+			vm.backfillChat = ""
+		}
+		vm.mu.Unlock()
+		// An empty chat has nothing to backfill from.
+		if status.Code(err) == codes.FailedPrecondition {
+			return false, nil
+		}
+		return false, err
+	}
+	vm.FlashUI.Info("Asking your phone for older messages...")
+	vm.SignalRefresh()
+	return true, nil
+}
+
+// applyHistoryBatch reloads the active chat when the phone answers its
+// backfill, keeping the loaded messages so the older ones can be scrolled
+// to.
+func (vm *ViewModel) applyHistoryBatch(ctx context.Context, evt *wppv1.EventEnvelope) {
+	var batch wppv1.SyncHistoryBatch
+	if proto.Unmarshal(evt.Payload, &batch) != nil || batch.ChatJid == "" {
+		return
+	}
+	vm.mu.Lock()
+	active := vm.ActiveChatJID == batch.ChatJid
+	if vm.backfillChat == batch.ChatJid {
+		vm.backfillChat = ""
+	}
+	vm.mu.Unlock()
+	if !active {
+		return
+	}
+	if err := vm.LoadMessages(ctx, batch.ChatJid); err != nil {
+		vm.FlashUI.Err(err)
+		return
+	}
+	if batch.MessagesCount == 0 {
+		vm.FlashUI.Info("No older messages on your phone")
+	} else {
+		vm.FlashUI.Info(fmt.Sprintf("Loaded %d older messages", batch.MessagesCount))
+	}
+	vm.SignalRefresh()
+}
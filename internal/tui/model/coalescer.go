@@ -0,0 +1,56 @@
+package model
+
+import (
+	"sync"
+	"time"
+)
+
+// coalescer collects keys from bursts of events and flushes them together
+// once delay has passed since the first key of the burst, so a history
+// sync turns into a few batched updates instead of one per event.
+type coalescer struct {
+	delay time.Duration
+	flush func(keys []string, reload bool)
+
+	flushMu sync.Mutex // flushes run one at a time, in order
+
+	mu     sync.Mutex
+	keys   map[string]struct{}
+	reload bool
+	timer  *time.Timer
+}
+
+func newCoalescer(delay time.Duration, flush func(keys []string, reload bool)) *coalescer {
+	return &coalescer{delay: delay, flush: flush, keys: make(map[string]struct{})}
+}
+
+// Add queues key for the next flush. An empty key asks for a full reload.
+func (c *coalescer) Add(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if key == "" {
+		c.reload = true
+	} else {
+		c.keys[key] = struct{}{}
+	}
+	if c.timer == nil {
+		c.timer = time.AfterFunc(c.delay, c.fire)
+	}
+}
+
+func (c *coalescer) fire() {
+	c.mu.Lock()
+	keys := make([]string, 0, len(c.keys))
+	for k := range c.keys {
+		keys = append(keys, k)
+	}
+	reload := c.reload
+	c.keys = make(map[string]struct{})
+	c.reload = false
+	c.timer = nil
+	c.mu.Unlock()
+
+	c.flushMu.Lock()
+	defer c.flushMu.Unlock()
+	c.flush(keys, reload)
+}
@@ -0,0 +1,248 @@
+package model
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	wppv1 "github.com/matheus3301/wpp/gen/wpp/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Delta tuning: events are coalesced for deltaDelay, and a burst touching
+// more than maxDeltas chats or messages falls back to a full reload.
+const (
+	deltaDelay = 150 * time.Millisecond
+	maxDeltas  = 20
+)
+
+// eventTarget decodes the chat and message an event is about. msgID is
+// empty for chat-level events, and chatJID is empty when the event does
+// not say, in which case the affected views must be reloaded.
+func eventTarget(evt *wppv1.EventEnvelope) (chatJID, msgID string) {
+	switch evt.Kind {
+	case "message.upserted":
+		var p wppv1.MessageUpserted
+		if proto.Unmarshal(evt.Payload, &p) == nil {
+			return p.ChatJid, p.MsgId
+		}
+	case "message.status_changed":
+		var p wppv1.MessageStatusChanged
+		if proto.Unmarshal(evt.Payload, &p) == nil {
+			return p.ChatJid, p.MsgId
+		}
+	case "message.send_ack":
+		// The client_msg_id row is gone: see applyMessageEvent.
+		var p wppv1.MessageSendAck
+		if proto.Unmarshal(evt.Payload, &p) == nil {
+			return p.ChatJid, p.ClientMsgId
+		}
+	case "message.send_failed":
+		var p wppv1.MessageSendFailed
+		if proto.Unmarshal(evt.Payload, &p) == nil {
+			return p.ChatJid, p.ClientMsgId
+		}
+	case "chat.updated":
+		var p wppv1.ChatUpdated
+		if proto.Unmarshal(evt.Payload, &p) == nil {
+			return p.ChatJid, ""
+		}
+	case "chat.cleared":
+		var p wppv1.ChatCleared
+		if proto.Unmarshal(evt.Payload, &p) == nil {
+			return p.ChatJid, ""
+		}
+	}
+	return "", ""
+}
+
+// messageKey joins a chat and message ID into a coalescer key.
+func messageKey(chatJID, msgID string) string {
+	return chatJID + "\n" + msgID
+}
+
+// applyMessageEvent queues the message an event touched when it belongs to
+// the active chat.
+func (vm *ViewModel) applyMessageEvent(evt *wppv1.EventEnvelope) {
+	chatJID, msgID := eventTarget(evt)
+	active := vm.GetActiveChatJID()
+	switch {
+	case active == "":
+	case chatJID == "" || msgID == "":
+		vm.messageDeltas.Add("")
+	case chatJID == active:
+		vm.messageDeltas.Add(messageKey(chatJID, msgID))
+		// A sent message is re-keyed from its client_msg_id to its
+		// WhatsApp ID: drop the former and fetch the latter.
+		var ack wppv1.MessageSendAck
+		if evt.Kind == "message.send_ack" && proto.Unmarshal(evt.Payload, &ack) == nil && ack.ServerMsgId != "" {
+			vm.messageDeltas.Add(messageKey(chatJID, ack.ServerMsgId))
+		}
+	}
+}
+
+// applyChatEvent queues the chat an event touched. Only events that can
+// change a chat's badge, preview or position are considered. Clearing the
+// active chat also reloads its thread.
+func (vm *ViewModel) applyChatEvent(evt *wppv1.EventEnvelope) {
+	if evt.Kind != "chat.updated" && evt.Kind != "chat.cleared" && evt.Kind != "message.upserted" {
+		return
+	}
+	chatJID, _ := eventTarget(evt)
+	vm.chatDeltas.Add(chatJID)
+	if evt.Kind == "chat.cleared" && chatJID != "" && chatJID == vm.GetActiveChatJID() {
+		vm.messageDeltas.Add("")
+	}
+}
+
+// flushMessageDeltas refetches the queued messages and patches them into
+// the thread, or reloads it when asked to or when too many changed. Messages
+// received in the active chat are marked read, as when the chat is opened.
+func (vm *ViewModel) flushMessageDeltas(ctx context.Context, keys []string, reload bool) {
+	chatJID := vm.GetActiveChatJID()
+	if chatJID == "" {
+		return
+	}
+	if reload || len(keys) > maxDeltas {
+		if err := vm.LoadMessages(ctx, chatJID); err != nil {
+			log.Printf("reload messages: %v", err)
+			return
+		}
+		vm.markChatRead(ctx, chatJID)
+		return
+	}
+
+	changed, received := false, false
+	for _, key := range keys {
+		keyChat, msgID, _ := strings.Cut(key, "\n")
+		if keyChat != chatJID {
+			continue // the user switched chats since the event
+		}
+		resp, err := vm.client.Message.GetMessage(ctx, &wppv1.GetMessageRequest{ChatJid: chatJID, MsgId: msgID})
+		if err != nil && status.Code(err) != codes.NotFound {
+			log.Printf("get message %s: %v", msgID, err)
+			continue
+		}
+		vm.mu.Lock()
+		if vm.ActiveChatJID == chatJID {
+			if resp == nil {
+				vm.Messages = removeMessage(vm.Messages, msgID)
+			} else {
+				vm.Messages = upsertMessage(vm.Messages, resp.Message, vm.messagesCursor != "")
+				received = received || !resp.Message.FromMe
+			}
+			changed = true
+		}
+		vm.mu.Unlock()
+	}
+	if changed {
+		vm.SignalRefresh()
+	}
+	if received {
+		vm.markChatRead(ctx, chatJID)
+	}
+}
+
+// markChatRead marks a chat read, logging failures. The daemon skips a chat
+// with nothing unread.
+func (vm *ViewModel) markChatRead(ctx context.Context, chatJID string) {
+	if err := vm.MarkRead(ctx, chatJID); err != nil && status.Code(err) != codes.NotFound {
+		log.Printf("mark read %s: %v", chatJID, err)
+	}
+}
+
+// flushChatDeltas refetches the queued chats and moves them to their
+// place in the list. Filtered lists are reloaded instead, since only the
+// daemon knows whether a changed chat still matches the filter.
+func (vm *ViewModel) flushChatDeltas(ctx context.Context, jids []string, reload bool) {
+	if reload || len(jids) > maxDeltas || vm.ChatFilter() != "" {
+		if err := vm.LoadChats(ctx); err != nil {
+			log.Printf("reload chats: %v", err)
+		}
+		return
+	}
+
+	for _, jid := range jids {
+		if strings.HasSuffix(jid, "@lid") {
+			continue // LID chats are merged into their phone number chat
+		}
+		resp, err := vm.client.Chat.GetChat(ctx, &wppv1.GetChatRequest{Jid: jid})
+		if err != nil && status.Code(err) != codes.NotFound {
+			log.Printf("get chat %s: %v", jid, err)
+			continue
+		}
+		vm.mu.Lock()
+		if vm.chatFilter == "" {
+			vm.Chats = removeChat(vm.Chats, jid)
+			if resp != nil {
+				vm.Chats = insertChat(vm.Chats, resp.Chat, vm.chatsCursor != "")
+			}
+		}
+		vm.mu.Unlock()
+	}
+	vm.SignalRefresh()
+}
+
+// upsertMessage replaces m in msgs (newest first) or inserts it in
+// timestamp order. A message older than every loaded one is left for
+// LoadOlderMessages when more pages exist.
+func upsertMessage(msgs []*wppv1.Message, m *wppv1.Message, more bool) []*wppv1.Message {
+	for i, old := range msgs {
+		if old.Id == m.Id {
+			out := append([]*wppv1.Message(nil), msgs...)
+			out[i] = m
+			return out
+		}
+	}
+	i := 0
+	for i < len(msgs) && msgs[i].TimestampUnixMs > m.TimestampUnixMs {
+		i++
+	}
+	if i == len(msgs) && more {
+		return msgs
+	}
+	out := make([]*wppv1.Message, 0, len(msgs)+1)
+	out = append(out, msgs[:i]...)
+	out = append(out, m)
+	return append(out, msgs[i:]...)
+}
+
+func removeMessage(msgs []*wppv1.Message, id string) []*wppv1.Message {
+	out := make([]*wppv1.Message, 0, len(msgs))
+	for _, m := range msgs {
+		if m.Id != id {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// insertChat inserts c into chats ordered by last message, newest first.
+// A chat that sorts after every loaded one is left for LoadMoreChats when
+// more pages exist.
+func insertChat(chats []*wppv1.Chat, c *wppv1.Chat, more bool) []*wppv1.Chat {
+	i := 0
+	for i < len(chats) && chats[i].LastMessageAtUnixMs >= c.LastMessageAtUnixMs {
+		i++
+	}
+	if i == len(chats) && more {
+		return chats
+	}
+	out := make([]*wppv1.Chat, 0, len(chats)+1)
+	out = append(out, chats[:i]...)
+	out = append(out, c)
+	return append(out, chats[i:]...)
+}
+
+func removeChat(chats []*wppv1.Chat, jid string) []*wppv1.Chat {
+	out := make([]*wppv1.Chat, 0, len(chats))
+	for _, c := range chats {
+		if c.Jid != jid {
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,199 @@
+package model
+
+import (
+	"context"
+	"io"
+	"log"
+	"time"
+
+	wppv1 "github.com/matheus3301/wpp/gen/wpp/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// typingTimeout clears a typing indicator whose paused state was lost;
+	// WhatsApp repeats composing while someone keeps typing.
+	typingTimeout = 25 * time.Second
+	// composingInterval is how often our composing state is resent while
+	// we keep typing.
+	composingInterval = 10 * time.Second
+)
+
+// chatPresence is what the active chat shows of its members' presence.
+type chatPresence struct {
+	chatJID  string
+	cancel   context.CancelFunc
+	typing   map[string]typingState // by sender JID
+	online   bool
+	lastSeen int64 // Unix ms, 0 when unknown
+}
+
+type typingState struct {
+	state string // composing, recording
+	until time.Time
+}
+
+// WatchPresence streams the presence of chatJID, replacing the stream of
+// any other chat. The daemon shows us online while it runs.
+func (vm *ViewModel) WatchPresence(ctx context.Context, chatJID string) {
+	vm.mu.Lock()
+	if vm.presence.chatJID == chatJID && vm.presence.cancel != nil {
+		vm.mu.Unlock()
+		return
+	}
+	if vm.presence.cancel != nil {
+		vm.presence.cancel()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	vm.presence = chatPresence{chatJID: chatJID, cancel: cancel}
+	vm.mu.Unlock()
+
+	go func() {
+		for {
+			if err := vm.watchPresence(ctx, chatJID); err != nil && ctx.Err() == nil {
+				log.Printf("presence stream error: %v, reconnecting...", err)
+			}
+			select {
+			case <-time.After(2 * time.Second):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// StopWatchingPresence ends the presence stream, if any.
+func (vm *ViewModel) StopWatchingPresence() {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	if vm.presence.cancel != nil {
+		vm.presence.cancel()
+	}
+	vm.presence = chatPresence{}
+}
+
+func (vm *ViewModel) watchPresence(ctx context.Context, chatJID string) error {
+	stream, err := vm.client.Chat.WatchPresence(ctx, &wppv1.WatchPresenceRequest{ChatJid: chatJID})
+	if err != nil {
+		return err
+	}
+	for {
+		evt, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		vm.applyPresenceEvent(chatJID, evt)
+	}
+}
+
+func (vm *ViewModel) applyPresenceEvent(chatJID string, evt *wppv1.EventEnvelope) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	p := &vm.presence
+	if p.chatJID != chatJID {
+		return
+	}
+	switch evt.Kind {
+	case "presence.chat_state":
+		var cs wppv1.PresenceChatState
+		if proto.Unmarshal(evt.Payload, &cs) != nil {
+			return
+		}
+		if cs.State == "paused" {
+			delete(p.typing, cs.SenderJid)
+			break
+		}
+		if p.typing == nil {
+			p.typing = make(map[string]typingState)
+		}
+		p.typing[cs.SenderJid] = typingState{state: cs.State, until: time.Now().Add(typingTimeout)}
+		time.AfterFunc(typingTimeout, vm.SignalRefresh)
+	case "presence.updated":
+		var pu wppv1.PresenceUpdated
+		if proto.Unmarshal(evt.Payload, &pu) != nil {
+			return
+		}
+		p.online = pu.Online
+		if pu.LastSeenUnixMs > 0 {
+			p.lastSeen = pu.LastSeenUnixMs
+		}
+	default:
+		return
+	}
+	vm.SignalRefresh()
+}
+
+// PresenceLine describes the presence of the active chat for its title:
+// who is typing, else whether the contact is online or when they were
+// last seen. Empty when nothing is known.
+func (vm *ViewModel) PresenceLine() string {
+	vm.mu.RLock()
+	defer vm.mu.RUnlock()
+	p := vm.presence
+	now := time.Now()
+	recording := false
+	typing := 0
+	for _, t := range p.typing {
+		if now.Before(t.until) {
+			typing++
+			recording = recording || t.state == "recording"
+		}
+	}
+	switch {
+	case recording:
+		return "recording audio…"
+	case typing > 1:
+		return "several people are typing…"
+	case typing == 1:
+		return "typing…"
+	case p.online:
+		return "online"
+	case p.lastSeen > 0:
+		return "last seen " + formatLastSeen(time.UnixMilli(p.lastSeen), now)
+	}
+	return ""
+}
+
+func formatLastSeen(t, now time.Time) string {
+	y, m, d := t.Date()
+	ny, nm, nd := now.Date()
+	switch {
+	case y == ny && m == nm && d == nd:
+		return "today at " + t.Format("15:04")
+	case y == ny:
+		return t.Format("Jan 2 at 15:04")
+	}
+	return t.Format("Jan 2 2006")
+}
+
+// SetComposing tells chatJID whether we are typing. Composing is resent at
+// most every composingInterval while typing goes on, and paused only
+// follows a composing, so it can be called on every keystroke.
+func (vm *ViewModel) SetComposing(ctx context.Context, chatJID string, composing bool) {
+	vm.mu.Lock()
+	var state string
+	switch {
+	case composing && (vm.composingChat != chatJID || time.Since(vm.composingAt) >= composingInterval):
+		if vm.composingChat != "" && vm.composingChat != chatJID {
+			go vm.sendChatPresence(ctx, vm.composingChat, "paused")
+		}
+		state = "composing"
+		vm.composingChat, vm.composingAt = chatJID, time.Now()
+	case !composing && vm.composingChat != "":
+		chatJID, state = vm.composingChat, "paused"
+		vm.composingChat, vm.composingAt = "", time.Time{}
+	}
+	vm.mu.Unlock()
+	if state != "" {
+		go vm.sendChatPresence(ctx, chatJID, state)
+	}
+}
+
+func (vm *ViewModel) sendChatPresence(ctx context.Context, chatJID, state string) {
+	if _, err := vm.client.Chat.SendChatPresence(ctx, &wppv1.SendChatPresenceRequest{ChatJid: chatJID, State: state}); err != nil {
+		log.Printf("send chat presence: %v", err)
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"io"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
 	wppv1 "github.com/matheus3301/wpp/gen/wpp/v1"
 	"github.com/matheus3301/wpp/internal/tui/client"
 	"github.com/matheus3301/wpp/internal/tui/ui"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // SessionInfo holds cached session metadata for the header.
@@ -36,8 +39,44 @@ type ViewModel struct {
 	FlashUI       *ui.FlashModel
 
 	refreshCh chan struct{}
+
+	// Cursors of the next page of chats and of older messages in the
+	// active chat; "" when there is nothing more to load.
+	chatsCursor    string
+	messagesCursor string
+
+	// chatFilter is the ListChatsRequest.filter applied to the chat list.
+	chatFilter string
+
+	// Event ids last received on the message and chat streams. Each is
+	// only touched by its watch goroutine; reconnects resume from it.
+	messageEventCursor string
+	chatEventCursor    string
+
+	// Coalesce stream events into batched thread and chat list updates.
+	// Set by StartWatchingMessages and StartWatchingChats.
+	messageDeltas *coalescer
+	chatDeltas    *coalescer
+
+	// Presence of the active chat, while WatchPresence streams it.
+	presence chatPresence
+
+	// Chat we last told we are composing, and when, for SetComposing.
+	composingChat string
+	composingAt   time.Time
+
+	// Chat whose older history was last requested from the phone, and
+	// when, for BackfillChat.
+	backfillChat string
+	backfillAt   time.Time
 }
 
+// Page sizes used when loading chats and messages.
+const (
+	chatPageSize    = 100
+	messagePageSize = 100
+)
+
 // NewViewModel creates a new view model connected to the daemon client.
 func NewViewModel(c *client.Client) *ViewModel {
 	return &ViewModel{
@@ -86,26 +125,102 @@ func (vm *ViewModel) LoadSyncStatus(ctx context.Context) error {
 	return nil
 }
 
-// LoadChats fetches the chat list.
+// LoadChats fetches the chat list. A reload keeps as many chats as are
+// already loaded, so pages fetched by LoadMoreChats survive refreshes.
 func (vm *ViewModel) LoadChats(ctx context.Context) error {
+	vm.mu.RLock()
+	limit := max(chatPageSize, len(vm.Chats))
+	filter := vm.chatFilter
+	vm.mu.RUnlock()
+
+	resp, err := vm.client.Chat.ListChats(ctx, &wppv1.ListChatsRequest{
+		Pagination: &wppv1.Pagination{Limit: int32(limit)},
+		Filter:     filter,
+	})
+	if err != nil {
+		return err
+	}
+	vm.mu.Lock()
+	if filter != vm.chatFilter {
+		// The filter changed while loading; its own load wins.
+		vm.mu.Unlock()
+		return nil
+	}
+	vm.Chats = resp.Chats
+	vm.chatsCursor = resp.GetPageInfo().GetNextCursor()
+	vm.mu.Unlock()
+	vm.SignalRefresh()
+	return nil
+}
+
+// SetChatFilter replaces the chat list filter and reloads the first page.
+// An invalid filter is rejected by the daemon and leaves the list as is.
+func (vm *ViewModel) SetChatFilter(ctx context.Context, filter string) error {
 	resp, err := vm.client.Chat.ListChats(ctx, &wppv1.ListChatsRequest{
-		Pagination: &wppv1.Pagination{Limit: 100},
+		Pagination: &wppv1.Pagination{Limit: chatPageSize},
+		Filter:     filter,
 	})
 	if err != nil {
 		return err
 	}
 	vm.mu.Lock()
+	vm.chatFilter = filter
 	vm.Chats = resp.Chats
+	vm.chatsCursor = resp.GetPageInfo().GetNextCursor()
 	vm.mu.Unlock()
 	vm.SignalRefresh()
 	return nil
 }
 
-// LoadMessages fetches messages for the active chat.
+// ChatFilter returns the active chat list filter.
+func (vm *ViewModel) ChatFilter() string {
+	vm.mu.RLock()
+	defer vm.mu.RUnlock()
+	return vm.chatFilter
+}
+
+// LoadMoreChats appends the next page of chats. It does nothing when every
+// chat is loaded.
+func (vm *ViewModel) LoadMoreChats(ctx context.Context) error {
+	vm.mu.RLock()
+	cursor, filter := vm.chatsCursor, vm.chatFilter
+	vm.mu.RUnlock()
+	if cursor == "" {
+		return nil
+	}
+
+	resp, err := vm.client.Chat.ListChats(ctx, &wppv1.ListChatsRequest{
+		Pagination: &wppv1.Pagination{Limit: chatPageSize, Cursor: cursor},
+		Filter:     filter,
+	})
+	if err != nil {
+		return err
+	}
+	vm.mu.Lock()
+	// A concurrent reload already moved past this page.
+	if vm.chatsCursor == cursor && vm.chatFilter == filter {
+		vm.Chats = append(vm.Chats, resp.Chats...)
+		vm.chatsCursor = resp.GetPageInfo().GetNextCursor()
+	}
+	vm.mu.Unlock()
+	vm.SignalRefresh()
+	return nil
+}
+
+// LoadMessages fetches messages for the active chat. Reloading the active
+// chat keeps as many messages as are already loaded, so older pages
+// fetched by LoadOlderMessages survive refreshes.
 func (vm *ViewModel) LoadMessages(ctx context.Context, chatJID string) error {
+	vm.mu.RLock()
+	limit := messagePageSize
+	if chatJID == vm.ActiveChatJID {
+		limit = max(limit, len(vm.Messages))
+	}
+	vm.mu.RUnlock()
+
 	resp, err := vm.client.Message.ListMessages(ctx, &wppv1.ListMessagesRequest{
 		ChatJid:    chatJID,
-		Pagination: &wppv1.Pagination{Limit: 100},
+		Pagination: &wppv1.Pagination{Limit: int32(limit)},
 	})
 	if err != nil {
 		return err
@@ -113,11 +228,73 @@ func (vm *ViewModel) LoadMessages(ctx context.Context, chatJID string) error {
 	vm.mu.Lock()
 	vm.ActiveChatJID = chatJID
 	vm.Messages = resp.Messages
+	vm.messagesCursor = resp.GetPageInfo().GetNextCursor()
 	vm.mu.Unlock()
 	vm.SignalRefresh()
 	return nil
 }
 
+// LoadOlderMessages appends the next page of older messages of the active
+// chat. Returns false when there was nothing more to load.
+func (vm *ViewModel) LoadOlderMessages(ctx context.Context) (bool, error) {
+	vm.mu.RLock()
+	chatJID, cursor := vm.ActiveChatJID, vm.messagesCursor
+	vm.mu.RUnlock()
+	if chatJID == "" || cursor == "" {
+		return false, nil
+	}
+
+	resp, err := vm.client.Message.ListMessages(ctx, &wppv1.ListMessagesRequest{
+		ChatJid:    chatJID,
+		Pagination: &wppv1.Pagination{Limit: messagePageSize, Cursor: cursor},
+	})
+	if err != nil {
+		return false, err
+	}
+	vm.mu.Lock()
+	loaded := vm.ActiveChatJID == chatJID && vm.messagesCursor == cursor
+	if loaded {
+		vm.Messages = append(vm.Messages, resp.Messages...)
+		vm.messagesCursor = resp.GetPageInfo().GetNextCursor()
+	}
+	vm.mu.Unlock()
+	vm.SignalRefresh()
+	return loaded, nil
+}
+
+// MarkRead marks the chat read on WhatsApp and clears its unread count.
+func (vm *ViewModel) MarkRead(ctx context.Context, chatJID string) error {
+	_, err := vm.client.Chat.MarkRead(ctx, &wppv1.MarkReadRequest{ChatJid: chatJID})
+	return err
+}
+
+// ArchiveChat archives or unarchives a chat on WhatsApp.
+func (vm *ViewModel) ArchiveChat(ctx context.Context, chatJID string, archived bool) error {
+	_, err := vm.client.Chat.ArchiveChat(ctx, &wppv1.ArchiveChatRequest{ChatJid: chatJID, Archived: archived})
+	return err
+}
+
+// PinChat pins or unpins a chat on WhatsApp.
+func (vm *ViewModel) PinChat(ctx context.Context, chatJID string, pinned bool) error {
+	_, err := vm.client.Chat.PinChat(ctx, &wppv1.PinChatRequest{ChatJid: chatJID, Pinned: pinned})
+	return err
+}
+
+// MuteChat mutes a chat on WhatsApp indefinitely, or unmutes it.
+func (vm *ViewModel) MuteChat(ctx context.Context, chatJID string, muted bool) error {
+	_, err := vm.client.Chat.MuteChat(ctx, &wppv1.MuteChatRequest{ChatJid: chatJID, Muted: muted})
+	return err
+}
+
+// GetGroupInfo fetches the metadata and participants of a group chat.
+func (vm *ViewModel) GetGroupInfo(ctx context.Context, jid string) (*wppv1.GroupInfo, error) {
+	resp, err := vm.client.Chat.GetGroupInfo(ctx, &wppv1.GetGroupInfoRequest{Jid: jid})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Group, nil
+}
+
 // SearchMessages performs a search query.
 func (vm *ViewModel) SearchMessages(ctx context.Context, query string) ([]*wppv1.SearchResult, error) {
 	resp, err := vm.client.Message.SearchMessages(ctx, &wppv1.SearchMessagesRequest{
@@ -130,23 +307,111 @@ func (vm *ViewModel) SearchMessages(ctx context.Context, query string) ([]*wppv1
 	return resp.Results, nil
 }
 
-// SendText sends a text message.
-func (vm *ViewModel) SendText(ctx context.Context, chatJID, text, clientMsgID string) error {
+// SendText sends a text message, quoting replyToMsgID when it is not empty.
+func (vm *ViewModel) SendText(ctx context.Context, chatJID, text, clientMsgID, replyToMsgID string) error {
 	resp, err := vm.client.Message.SendText(ctx, &wppv1.SendTextRequest{
+		ClientMsgId:  clientMsgID,
+		ChatJid:      chatJID,
+		Text:         text,
+		ReplyToMsgId: replyToMsgID,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Accepted {
+		vm.FlashUI.Info("Message sent")
+	}
+	vm.SignalRefresh()
+	return nil
+}
+
+// SendMedia sends the file at path, an absolute path on the daemon's host,
+// to a chat. Its media type is detected from the file.
+func (vm *ViewModel) SendMedia(ctx context.Context, chatJID, path, clientMsgID string) error {
+	resp, err := vm.client.Message.SendMedia(ctx, &wppv1.SendMediaRequest{
 		ClientMsgId: clientMsgID,
 		ChatJid:     chatJID,
-		Text:        text,
+		Path:        path,
 	})
 	if err != nil {
 		return err
 	}
 	if resp.Accepted {
-		vm.FlashUI.Info("Message sent")
+		vm.FlashUI.Info("Attachment queued for sending")
 	}
 	vm.SignalRefresh()
 	return nil
 }
 
+// RetryMessage resends a failed message now.
+func (vm *ViewModel) RetryMessage(ctx context.Context, clientMsgID string) error {
+	if _, err := vm.client.Message.RetryMessage(ctx, &wppv1.RetryMessageRequest{ClientMsgId: clientMsgID}); err != nil {
+		return err
+	}
+	vm.FlashUI.Info("Message queued for sending")
+	return nil
+}
+
+// CancelMessage drops a failed or queued message that was not sent.
+func (vm *ViewModel) CancelMessage(ctx context.Context, clientMsgID string) error {
+	if _, err := vm.client.Message.CancelMessage(ctx, &wppv1.CancelMessageRequest{ClientMsgId: clientMsgID}); err != nil {
+		return err
+	}
+	vm.FlashUI.Info("Message cancelled")
+	return nil
+}
+
+// EditMessage replaces the text of one of our sent messages.
+func (vm *ViewModel) EditMessage(ctx context.Context, chatJID, msgID, text, clientMsgID string) error {
+	if _, err := vm.client.Message.EditMessage(ctx, &wppv1.EditMessageRequest{
+		ClientMsgId: clientMsgID,
+		ChatJid:     chatJID,
+		MsgId:       msgID,
+		Text:        text,
+	}); err != nil {
+		return err
+	}
+	vm.FlashUI.Info("Edit queued for sending")
+	return nil
+}
+
+// RevokeMessage deletes one of our messages for everyone, or cancels it if
+// it was not sent yet.
+func (vm *ViewModel) RevokeMessage(ctx context.Context, chatJID, msgID, clientMsgID string) error {
+	resp, err := vm.client.Message.RevokeMessage(ctx, &wppv1.RevokeMessageRequest{
+		ClientMsgId: clientMsgID,
+		ChatJid:     chatJID,
+		MsgId:       msgID,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Message == "cancelled" {
+		vm.FlashUI.Info("Message cancelled")
+	} else {
+		vm.FlashUI.Info("Deletion queued for sending")
+	}
+	return nil
+}
+
+// ListScheduled returns the messages scheduled for later, soonest first.
+func (vm *ViewModel) ListScheduled(ctx context.Context) ([]*wppv1.ScheduledMessage, error) {
+	resp, err := vm.client.Message.ListScheduled(ctx, &wppv1.ListScheduledRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Messages, nil
+}
+
+// CancelScheduled drops a scheduled message before it is sent.
+func (vm *ViewModel) CancelScheduled(ctx context.Context, clientMsgID string) error {
+	if _, err := vm.client.Message.CancelScheduled(ctx, &wppv1.CancelScheduledRequest{ClientMsgId: clientMsgID}); err != nil {
+		return err
+	}
+	vm.FlashUI.Info("Scheduled message cancelled")
+	return nil
+}
+
 // GetChats returns a snapshot of the current chat list.
 func (vm *ViewModel) GetChats() []*wppv1.Chat {
 	vm.mu.RLock()
@@ -175,7 +440,7 @@ func (vm *ViewModel) GetSessionInfo() *ui.SessionData {
 	if vm.SessionStatus == nil {
 		return nil
 	}
-	return &ui.SessionData{
+	data := &ui.SessionData{
 		Session:      vm.SessionStatus.Session,
 		Phone:        vm.SessionStatus.PhoneNumber,
 		Status:       vm.SessionStatus.StatusMessage,
@@ -183,6 +448,11 @@ func (vm *ViewModel) GetSessionInfo() *ui.SessionData {
 		MessageCount: vm.SessionStatus.MessageCount,
 		Uptime:       time.Duration(vm.SessionStatus.UptimeMs) * time.Millisecond,
 	}
+	if vm.SyncStatus != nil {
+		data.SyncType = strings.ReplaceAll(vm.SyncStatus.HistorySyncType, "_", " ")
+		data.SyncProgress = vm.SyncStatus.HistoryProgress
+	}
+	return data
 }
 
 // GetActiveChatJID returns the JID of the currently active chat.
@@ -206,6 +476,9 @@ func (vm *ViewModel) GetChatByJID(jid string) *wppv1.Chat {
 
 // StartWatchingMessages subscribes to the message event stream.
 func (vm *ViewModel) StartWatchingMessages(ctx context.Context) {
+	vm.messageDeltas = newCoalescer(deltaDelay, func(keys []string, reload bool) {
+		vm.flushMessageDeltas(ctx, keys, reload)
+	})
 	go func() {
 		for {
 			if err := vm.watchMessages(ctx); err != nil {
@@ -220,27 +493,70 @@ func (vm *ViewModel) StartWatchingMessages(ctx context.Context) {
 }
 
 func (vm *ViewModel) watchMessages(ctx context.Context) error {
-	stream, err := vm.client.Message.WatchMessageEvents(ctx, &wppv1.WatchMessageEventsRequest{})
+	stream, err := vm.client.Message.WatchMessageEvents(ctx, &wppv1.WatchMessageEventsRequest{Cursor: vm.messageEventCursor})
 	if err != nil {
 		return err
 	}
 	for {
-		_, err := stream.Recv()
+		evt, err := stream.Recv()
 		if err == io.EOF {
 			return nil
 		}
+		if status.Code(err) == codes.OutOfRange {
+			// Missed events were pruned: reload and watch from now.
+			vm.messageEventCursor = ""
+			vm.messageDeltas.Add("")
+			return nil
+		}
 		if err != nil {
 			return err
 		}
-		chatJID := vm.GetActiveChatJID()
-		if chatJID != "" {
-			_ = vm.LoadMessages(ctx, chatJID)
+		vm.messageEventCursor = evt.EventId
+		vm.applyMessageEvent(evt)
+	}
+}
+
+// StartWatchingSync follows the sync event stream from now on, reloading
+// the sync status as history arrives so the header shows its progress.
+func (vm *ViewModel) StartWatchingSync(ctx context.Context) {
+	go func() {
+		for {
+			if err := vm.watchSync(ctx); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				log.Printf("sync stream error: %v, reconnecting...", err)
+				time.Sleep(2 * time.Second)
+			}
+		}
+	}()
+}
+
+func (vm *ViewModel) watchSync(ctx context.Context) error {
+	stream, err := vm.client.Sync.WatchSyncEvents(ctx, &wppv1.WatchSyncEventsRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		evt, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if evt.Kind == "sync.history_batch" {
+			_ = vm.LoadSyncStatus(ctx)
+			vm.applyHistoryBatch(ctx, evt)
 		}
 	}
 }
 
 // StartWatchingChats subscribes to the chat update stream.
 func (vm *ViewModel) StartWatchingChats(ctx context.Context) {
+	vm.chatDeltas = newCoalescer(deltaDelay, func(jids []string, reload bool) {
+		vm.flushChatDeltas(ctx, jids, reload)
+	})
 	go func() {
 		for {
 			if err := vm.watchChats(ctx); err != nil {
@@ -255,18 +571,24 @@ func (vm *ViewModel) StartWatchingChats(ctx context.Context) {
 }
 
 func (vm *ViewModel) watchChats(ctx context.Context) error {
-	stream, err := vm.client.Chat.WatchChatUpdates(ctx, &wppv1.WatchChatUpdatesRequest{})
+	stream, err := vm.client.Chat.WatchChatUpdates(ctx, &wppv1.WatchChatUpdatesRequest{Cursor: vm.chatEventCursor})
 	if err != nil {
 		return err
 	}
 	for {
-		_, err := stream.Recv()
+		evt, err := stream.Recv()
 		if err == io.EOF {
 			return nil
 		}
+		if status.Code(err) == codes.OutOfRange {
+			vm.chatEventCursor = ""
+			vm.chatDeltas.Add("")
+			return nil
+		}
 		if err != nil {
 			return err
 		}
-		_ = vm.LoadChats(ctx)
+		vm.chatEventCursor = evt.EventId
+		vm.applyChatEvent(evt)
 	}
 }
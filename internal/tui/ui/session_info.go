@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/rivo/tview"
@@ -15,8 +16,15 @@ type SessionData struct {
 	ChatCount    int32
 	MessageCount int32
 	Uptime       time.Duration
+	// History sync in progress: its type and percent done. SyncProgress
+	// is 0 when no sync is running.
+	SyncType     string
+	SyncProgress int32
 }
 
+// gaugeWidth is the number of cells of the sync progress bar.
+const gaugeWidth = 20
+
 // SessionInfo displays session metadata in the header.
 type SessionInfo struct {
 	*tview.TextView
@@ -68,9 +76,20 @@ func (si *SessionInfo) Update(data *SessionData) {
 		fgColor, counterColor, uptime,
 	)
 
+	if data.SyncProgress > 0 && data.SyncProgress < 100 {
+		text += fmt.Sprintf("\n[%s::b]Sync:[-:-:-]    [%s]%s[-] %d%% %s",
+			fgColor, counterColor, gauge(data.SyncProgress), data.SyncProgress, data.SyncType)
+	}
+
 	_, _ = fmt.Fprint(si, text)
 }
 
+// gauge draws percent as a bar of gaugeWidth cells.
+func gauge(percent int32) string {
+	filled := int(percent) * gaugeWidth / 100
+	return strings.Repeat("█", filled) + strings.Repeat("░", gaugeWidth-filled)
+}
+
 func formatDuration(d time.Duration) string {
 	h := int(d.Hours())
 	m := int(d.Minutes()) % 60
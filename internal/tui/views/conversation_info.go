@@ -2,6 +2,8 @@ package views
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	wppv1 "github.com/matheus3301/wpp/gen/wpp/v1"
 	"github.com/matheus3301/wpp/internal/tui/ui"
@@ -52,8 +54,9 @@ func (ci *ConversationInfo) Hints() []ui.MenuHint {
 	}
 }
 
-// Update renders conversation details.
-func (ci *ConversationInfo) Update(chat *wppv1.Chat) {
+// Update renders conversation details. group is the group metadata of a
+// group chat, or nil when it is not a group or not known.
+func (ci *ConversationInfo) Update(chat *wppv1.Chat, group *wppv1.GroupInfo) {
 	ci.Clear()
 	if chat == nil {
 		return
@@ -90,10 +93,39 @@ func (ci *ConversationInfo) Update(chat *wppv1.Chat) {
 		fg, ct, chat.LastMessagePreview,
 	)
 
+	if group != nil {
+		text += groupDetails(group, fg, ct)
+	}
+
 	_, _ = fmt.Fprint(ci, text)
 	ci.SetTitle(fmt.Sprintf(" %s Details ", chat.Name))
 }
 
+// groupDetails renders the topic and participant list of a group.
+func groupDetails(g *wppv1.GroupInfo, fg, ct string) string {
+	var b strings.Builder
+	if g.Topic != "" {
+		fmt.Fprintf(&b, "\n [%s::b]Topic:[-:-:-]       [%s]%s[-]",
+			fg, ct, tview.Escape(sanitizeForTerminal(g.Topic)))
+	}
+	if g.CreatedAtUnixMs > 0 {
+		fmt.Fprintf(&b, "\n [%s::b]Created:[-:-:-]     [%s]%s[-]",
+			fg, ct, time.UnixMilli(g.CreatedAtUnixMs).Format("2006-01-02"))
+	}
+	fmt.Fprintf(&b, "\n\n [%s::b]Participants (%d):[-:-:-]", fg, len(g.Participants))
+	for _, p := range g.Participants {
+		role := ""
+		switch {
+		case p.IsSuperAdmin:
+			role = " [::d](owner)[-:-:-]"
+		case p.IsAdmin:
+			role = " [::d](admin)[-:-:-]"
+		}
+		fmt.Fprintf(&b, "\n   [%s]%s[-]%s", ct, tview.Escape(sanitizeForTerminal(p.Name)), role)
+	}
+	return b.String()
+}
+
 func colorNameFromTheme(c interface{ Hex() int32 }) string {
 	return fmt.Sprintf("#%06x", c.Hex())
 }
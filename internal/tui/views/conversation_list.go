@@ -2,6 +2,7 @@ package views
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
@@ -16,6 +17,7 @@ type ConversationList struct {
 	theme  *ui.Theme
 	chats  []*wppv1.Chat
 	filter string
+	onEnd  func()
 }
 
 // NewConversationList creates a new conversation list table.
@@ -37,9 +39,20 @@ func NewConversationList(theme *ui.Theme) *ConversationList {
 		Table: table,
 		theme: theme,
 	}
+	table.SetSelectionChangedFunc(func(row, _ int) {
+		if cl.onEnd != nil && row > 0 && row == cl.GetRowCount()-1 {
+			cl.onEnd()
+		}
+	})
 	return cl
 }
 
+// SetOnScrollEnd sets the callback run when the selection reaches the last
+// row, to load more chats.
+func (cl *ConversationList) SetOnScrollEnd(fn func()) {
+	cl.onEnd = fn
+}
+
 // Name implements Component.
 func (cl *ConversationList) Name() string { return "Conversations" }
 
@@ -59,6 +72,9 @@ func (cl *ConversationList) Hints() []ui.MenuHint {
 		{Key: "/", Description: "Filter"},
 		{Key: ":", Description: "Command"},
 		{Key: "s", Description: "Sort"},
+		{Key: "a", Description: "Archive"},
+		{Key: "p", Description: "Pin"},
+		{Key: "m", Description: "Mute"},
 		{Key: "?", Description: "Help"},
 		{Key: "q", Description: "Quit"},
 		{Key: "0-9", Description: "Jump", Numeric: true},
@@ -71,7 +87,8 @@ func (cl *ConversationList) Update(chats []*wppv1.Chat) {
 	cl.render()
 }
 
-// SetFilter sets the active filter text and re-renders.
+// SetFilter records the filter the daemon applied to the chats and shows
+// it in the title. The list itself is filtered server-side.
 func (cl *ConversationList) SetFilter(filter string) {
 	cl.filter = filter
 	cl.render()
@@ -107,17 +124,13 @@ func (cl *ConversationList) render() {
 	}
 
 	row := 1
+	now := time.Now().UnixMilli()
 	for _, chat := range cl.chats {
 		name := chat.Name
 		if name == "" {
 			name = chat.Jid
 		}
 
-		// Apply filter.
-		if cl.filter != "" && !containsFold(name, cl.filter) && !containsFold(chat.LastMessagePreview, cl.filter) {
-			continue
-		}
-
 		// Show unread badge in name.
 		if chat.UnreadCount > 0 {
 			name = fmt.Sprintf("(%d) %s", chat.UnreadCount, name)
@@ -128,7 +141,12 @@ func (cl *ConversationList) render() {
 			chatType = "GROUP"
 		}
 
-		cl.SetCell(row, 0, tview.NewTableCell(" "+tview.Escape(sanitizeForTerminal(name))).SetExpansion(1).SetTextColor(cl.theme.FgColor))
+		nameText := " " + tview.Escape(sanitizeForTerminal(name))
+		if flags := chatFlags(chat, now); flags != "" {
+			nameText += " [::d]" + flags + "[-:-:-]"
+		}
+
+		cl.SetCell(row, 0, tview.NewTableCell(nameText).SetExpansion(1).SetTextColor(cl.theme.FgColor))
 		cl.SetCell(row, 1, tview.NewTableCell(" "+tview.Escape(sanitizeForTerminal(chat.LastMessagePreview))).SetExpansion(2).SetTextColor(cl.theme.FgColor))
 		cl.SetCell(row, 2, tview.NewTableCell(formatTimestamp(chat.LastMessageAtUnixMs)).SetExpansion(0).SetTextColor(cl.theme.FgColor).SetAlign(tview.AlignRight))
 		cl.SetCell(row, 3, tview.NewTableCell(chatType).SetExpansion(0).SetTextColor(cl.theme.FgColor).SetAlign(tview.AlignRight))
@@ -137,7 +155,7 @@ func (cl *ConversationList) render() {
 
 	// Update title with count.
 	if cl.filter != "" {
-		cl.SetTitle(fmt.Sprintf(" Conversations (%d/%d) filter: %s ", row-1, len(cl.chats), cl.filter))
+		cl.SetTitle(fmt.Sprintf(" Conversations (%d) filter: %s ", len(cl.chats), tview.Escape(cl.filter)))
 	} else {
 		cl.SetTitle(fmt.Sprintf(" Conversations (%d) ", len(cl.chats)))
 	}
@@ -146,49 +164,31 @@ func (cl *ConversationList) render() {
 // SelectedChat returns the JID of the currently selected chat.
 func (cl *ConversationList) SelectedChat() string {
 	row, _ := cl.GetSelection()
-	idx := row - 1 // account for header
-	if idx < 0 {
-		return ""
-	}
-
-	// If filtered, we need to find the actual chat.
-	visible := 0
-	for _, chat := range cl.chats {
-		name := chat.Name
-		if name == "" {
-			name = chat.Jid
-		}
-		if cl.filter != "" && !containsFold(name, cl.filter) && !containsFold(chat.LastMessagePreview, cl.filter) {
-			continue
-		}
-		if visible == idx {
-			return chat.Jid
-		}
-		visible++
-	}
-	return ""
+	return cl.ChatByIndex(row) // row 0 is the header
 }
 
-// ChatByIndex returns the JID of the Nth visible conversation (1-based).
+// ChatByIndex returns the JID of the Nth conversation (1-based).
 func (cl *ConversationList) ChatByIndex(n int) string {
-	if n < 1 {
+	if n < 1 || n > len(cl.chats) {
 		return ""
 	}
-	visible := 0
-	for _, chat := range cl.chats {
-		name := chat.Name
-		if name == "" {
-			name = chat.Jid
-		}
-		if cl.filter != "" && !containsFold(name, cl.filter) && !containsFold(chat.LastMessagePreview, cl.filter) {
-			continue
-		}
-		visible++
-		if visible == n {
-			return chat.Jid
-		}
+	return cl.chats[n-1].Jid
+}
+
+// chatFlags describes how a chat is organized on WhatsApp: pinned, muted
+// or archived.
+func chatFlags(chat *wppv1.Chat, now int64) string {
+	var flags []string
+	if chat.PinnedAtUnixMs > 0 {
+		flags = append(flags, "pinned")
+	}
+	if chat.MutedUntilUnixMs < 0 || chat.MutedUntilUnixMs > now {
+		flags = append(flags, "muted")
 	}
-	return ""
+	if chat.Archived {
+		flags = append(flags, "archived")
+	}
+	return strings.Join(flags, " · ")
 }
 
 func formatTimestamp(ms int64) string {
@@ -202,34 +202,3 @@ func formatTimestamp(ms int64) string {
 	}
 	return t.Format("01/02")
 }
-
-func containsFold(s, substr string) bool {
-	return len(s) >= len(substr) &&
-		(substr == "" ||
-			len(s) == 0 ||
-			tview.Escape(s) != "" && // ensure non-empty
-				containsLower(s, substr))
-}
-
-func containsLower(s, substr string) bool {
-	s = toLower(s)
-	substr = toLower(substr)
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
-	}
-	return false
-}
-
-func toLower(s string) string {
-	b := make([]byte, len(s))
-	for i := 0; i < len(s); i++ {
-		c := s[i]
-		if c >= 'A' && c <= 'Z' {
-			c += 'a' - 'A'
-		}
-		b[i] = c
-	}
-	return string(b)
-}
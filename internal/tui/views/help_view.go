@@ -68,24 +68,43 @@ func (hv *HelpView) render() {
   [%s]Enter[-:-:-]  Open conversation  [%s]0[-:-:-]     Show all (clear filter)
   [%s]1-9[-:-:-]    Jump to Nth chat   [%s]s[-:-:-]     Cycle sort mode
   [%s]j/Down[-:-:-] Move down          [%s]k/Up[-:-:-]  Move up
+  [%s]a[-:-:-]      Archive / unarchive [%s]p[-:-:-]     Pin / unpin
+  [%s]m[-:-:-]      Mute / unmute
+
+  [::b]Filters (/ mode)[-:-:-]
+
+  [%s]is:unread is:group is:dm is:archived is:pinned is:muted[-:-:-]  (prefix - to negate)
+  [%s]name:<text>[-:-:-]  Name contains    [%s]since:YYYY-MM-DD[-:-:-]  Active since
 
   [::b]Message Thread[-:-:-]
 
   [%s]i[-:-:-]    Focus composer      [%s]d[-:-:-]     Show conversation details
   [%s]Esc[-:-:-]  Exit composer       [%s]Enter[-:-:-] Send message (in composer)
+  [%s]j/k[-:-:-]  Select message      [%s]r[-:-:-]     Reply to selected message
+  [%s]R[-:-:-]    Retry failed msg    [%s]x[-:-:-]     Cancel failed message
+  [%s]e[-:-:-]    Edit own message    [%s]D D[-:-:-]   Delete own message for everyone
 
   [::b]Commands (: mode)[-:-:-]
 
   [%s]:search <query>[-:-:-]    Search messages
   [%s]:chat <name>[-:-:-]       Open chat by name
+  [%s]:attach <path>[-:-:-]      Send a file to the open chat
+  [%s]:scheduled[-:-:-]         Scheduled messages (R send now, x cancel)
   [%s]:logout[-:-:-]            Logout current session
   [%s]:help[-:-:-] / [%s]:h[-:-:-]       Show this help
   [%s]:quit[-:-:-] / [%s]:q[-:-:-]       Quit application
+
+  [::b]Search Operators[-:-:-]
+
+  [%s]from:<name|jid|me>[-:-:-]  Sender       [%s]in:<chat>[-:-:-]  Chat name or JID
+  [%s]after:/before:YYYY-MM-DD[-:-:-]  Date range   [%s]has:link has:media type:image[-:-:-]
 `,
 		kc, kc, kc, kc, kc, kc,
-		kc, kc, kc, kc, kc, kc,
+		kc, kc, kc, kc, kc, kc, kc, kc, kc,
+		kc, kc, kc,
+		kc, kc, kc, kc, kc, kc, kc, kc, kc, kc,
+		kc, kc, kc, kc, kc, kc, kc, kc, kc,
 		kc, kc, kc, kc,
-		kc, kc, kc, kc, kc, kc, kc,
 	)
 
 	_, _ = fmt.Fprint(hv, help)
@@ -2,6 +2,7 @@ package views
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	wppv1 "github.com/matheus3301/wpp/gen/wpp/v1"
@@ -17,13 +18,23 @@ type MessageThread struct {
 	composer *tview.InputField
 	chatName string
 	chatJID  string
-	onSend   func(text string)
+	presence string // typing or online state shown after the chat name
+	onSend   func(text, replyToMsgID string)
+	onEdit   func(msgID, text string)
+	onTyping func(typing bool)
+	onTop    func()
+
+	msgs     []*wppv1.Message // newest first, as last passed to Update
+	selected string           // msg ID of the selected message, "" for none
+	replyTo  *wppv1.Message
+	editing  *wppv1.Message // message the composer text replaces, if any
 }
 
 // NewMessageThread creates a new message thread view.
 func NewMessageThread(theme *ui.Theme) *MessageThread {
 	messages := tview.NewTextView().
 		SetDynamicColors(true).
+		SetRegions(true).
 		SetScrollable(true).
 		SetWordWrap(true)
 	messages.SetBorder(true)
@@ -57,12 +68,46 @@ func NewMessageThread(theme *ui.Theme) *MessageThread {
 		composer: composer,
 	}
 
+	// Scrolling up past the first line asks for older messages.
+	messages.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyUp, tcell.KeyPgUp, tcell.KeyHome:
+			if row, _ := messages.GetScrollOffset(); row == 0 {
+				mt.reachedTop()
+			}
+		}
+		return event
+	})
+
+	// We are typing while the composer has focus and text, except when
+	// editing a sent message.
+	typing := func(t bool) {
+		if mt.onTyping != nil && mt.editing == nil {
+			mt.onTyping(t)
+		}
+	}
+	composer.SetChangedFunc(func(text string) { typing(composer.HasFocus() && text != "") })
+	composer.SetFocusFunc(func() { typing(composer.GetText() != "") })
+	composer.SetBlurFunc(func() { typing(false) })
+
 	composer.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter && mt.editing != nil {
+			if text := composer.GetText(); text != "" && text != mt.editing.Body && mt.onEdit != nil {
+				mt.onEdit(mt.editing.Id, text)
+			}
+			mt.CancelReply()
+			return
+		}
 		if key == tcell.KeyEnter && mt.onSend != nil {
 			text := composer.GetText()
 			if text != "" {
-				mt.onSend(text)
+				replyTo := ""
+				if mt.replyTo != nil {
+					replyTo = mt.replyTo.Id
+				}
+				mt.onSend(text, replyTo)
 				composer.SetText("")
+				mt.CancelReply()
 			}
 		}
 	})
@@ -91,6 +136,10 @@ func (mt *MessageThread) Stop() {}
 func (mt *MessageThread) Hints() []ui.MenuHint {
 	return []ui.MenuHint{
 		{Key: "i", Description: "Compose"},
+		{Key: "j/k", Description: "Select"},
+		{Key: "r", Description: "Reply"},
+		{Key: "e/D", Description: "Edit/Delete"},
+		{Key: "R/x", Description: "Retry/Cancel"},
 		{Key: "d", Description: "Details"},
 		{Key: "Esc", Description: "Back"},
 		{Key: ":", Description: "Command"},
@@ -101,11 +150,34 @@ func (mt *MessageThread) Hints() []ui.MenuHint {
 // SetChatName updates the chat name and title.
 func (mt *MessageThread) SetChatName(name string) {
 	mt.chatName = name
-	mt.messages.SetTitle(fmt.Sprintf(" %s ", name))
+	mt.updateTitle()
+}
+
+// SetPresence shows who is typing, or whether the contact is online,
+// next to the chat name. Empty hides it.
+func (mt *MessageThread) SetPresence(presence string) {
+	if presence == mt.presence {
+		return
+	}
+	mt.presence = presence
+	mt.updateTitle()
+}
+
+func (mt *MessageThread) updateTitle() {
+	if mt.presence == "" {
+		mt.messages.SetTitle(fmt.Sprintf(" %s ", mt.chatName))
+		return
+	}
+	mt.messages.SetTitle(fmt.Sprintf(" %s · %s ", mt.chatName, mt.presence))
 }
 
-// SetChatJID stores the current chat JID.
+// SetChatJID stores the current chat JID. Switching chats drops the
+// selection and any pending reply.
 func (mt *MessageThread) SetChatJID(jid string) {
+	if jid != mt.chatJID {
+		mt.selected = ""
+		mt.CancelReply()
+	}
 	mt.chatJID = jid
 }
 
@@ -114,33 +186,210 @@ func (mt *MessageThread) ChatJID() string {
 	return mt.chatJID
 }
 
-// SetOnSend sets the callback when a message is sent.
-func (mt *MessageThread) SetOnSend(fn func(text string)) {
+// SetOnSend sets the callback when a message is sent. replyToMsgID is the
+// ID of the quoted message, or "" for a plain message.
+func (mt *MessageThread) SetOnSend(fn func(text, replyToMsgID string)) {
 	mt.onSend = fn
 }
 
+// SetOnTyping sets the callback when we start or stop typing in the
+// composer. It is called on every change, so it must be cheap.
+func (mt *MessageThread) SetOnTyping(fn func(typing bool)) {
+	mt.onTyping = fn
+}
+
+// SetOnEdit sets the callback when an edit of one of our messages is
+// submitted.
+func (mt *MessageThread) SetOnEdit(fn func(msgID, text string)) {
+	mt.onEdit = fn
+}
+
+// SetOnScrollTop sets the callback run when the view reaches the oldest
+// loaded message, to load older ones.
+func (mt *MessageThread) SetOnScrollTop(fn func()) {
+	mt.onTop = fn
+}
+
+// SelectOlder moves the selection one message up, starting from the
+// newest message when nothing is selected. Moving past the oldest loaded
+// message asks for older ones.
+func (mt *MessageThread) SelectOlder() {
+	i := mt.selectedIndex()
+	if i+1 < len(mt.msgs) {
+		mt.selected = mt.msgs[i+1].Id
+		mt.Update(mt.msgs)
+		return
+	}
+	mt.reachedTop()
+}
+
+// reachedTop anchors the selection on the oldest loaded message, so the
+// view stays in place once older messages are prepended, and asks for them.
+func (mt *MessageThread) reachedTop() {
+	if len(mt.msgs) == 0 {
+		return
+	}
+	mt.selected = mt.msgs[len(mt.msgs)-1].Id
+	mt.Update(mt.msgs)
+	if mt.onTop != nil {
+		mt.onTop()
+	}
+}
+
+// SelectNewer moves the selection one message down, clearing it past the
+// newest message.
+func (mt *MessageThread) SelectNewer() {
+	switch i := mt.selectedIndex(); {
+	case i > 0:
+		mt.selected = mt.msgs[i-1].Id
+	case i == 0:
+		mt.selected = ""
+	}
+	mt.Update(mt.msgs)
+}
+
+// ReplyToSelected makes the selected message the one the next sent message
+// quotes. Returns false when no message is selected or it is a system row.
+func (mt *MessageThread) ReplyToSelected() bool {
+	i := mt.selectedIndex()
+	if i < 0 || mt.msgs[i].MessageType == "system" {
+		return false
+	}
+	mt.replyTo = mt.msgs[i]
+	mt.composer.SetTitle(fmt.Sprintf(" Replying to %s (Esc to cancel) ", tview.Escape(sanitizeForTerminal(mt.senderName(mt.replyTo)))))
+	return true
+}
+
+// EditSelected loads the selected message into the composer for editing.
+// Returns false unless it is a text message we sent that has not been
+// deleted.
+func (mt *MessageThread) EditSelected() bool {
+	i := mt.selectedIndex()
+	if i < 0 || !mt.msgs[i].FromMe || mt.msgs[i].MessageType != "text" || mt.msgs[i].Deleted {
+		return false
+	}
+	mt.replyTo = nil
+	mt.editing = mt.msgs[i]
+	mt.composer.SetText(mt.editing.Body)
+	mt.composer.SetTitle(" Editing message (Esc to cancel) ")
+	return true
+}
+
+// SelectedMessage returns the selected message, or nil.
+func (mt *MessageThread) SelectedMessage() *wppv1.Message {
+	if i := mt.selectedIndex(); i >= 0 {
+		return mt.msgs[i]
+	}
+	return nil
+}
+
+// CancelReply clears a pending reply or edit.
+func (mt *MessageThread) CancelReply() {
+	if mt.editing != nil {
+		mt.editing = nil
+		mt.composer.SetText("")
+	}
+	mt.replyTo = nil
+	mt.composer.SetTitle(" Compose (i to focus) ")
+}
+
+func (mt *MessageThread) selectedIndex() int {
+	if mt.selected == "" {
+		return -1
+	}
+	for i, m := range mt.msgs {
+		if m.Id == mt.selected {
+			return i
+		}
+	}
+	return -1
+}
+
+func (mt *MessageThread) senderName(m *wppv1.Message) string {
+	if m.FromMe {
+		return "You"
+	}
+	if m.SenderName != "" {
+		return m.SenderName
+	}
+	return m.SenderJid
+}
+
+// quoteLine renders the excerpt of the message m replies to.
+func (mt *MessageThread) quoteLine(m *wppv1.Message) string {
+	sender := m.QuotedSender
+	for _, q := range mt.msgs {
+		if q.Id == m.QuotedMsgId {
+			sender = mt.senderName(q)
+			break
+		}
+	}
+	excerpt := []rune(sanitizeForTerminal(m.QuotedBody))
+	if len(excerpt) > 60 {
+		excerpt = append(excerpt[:60], '…')
+	}
+	if len(excerpt) == 0 {
+		excerpt = []rune("…")
+	}
+	return fmt.Sprintf("[::d]│ %s: %s[-:-:-]",
+		tview.Escape(sanitizeForTerminal(sender)), tview.Escape(string(excerpt)))
+}
+
 // Update refreshes the message view with new messages.
 func (mt *MessageThread) Update(msgs []*wppv1.Message) {
+	mt.msgs = msgs
+	if mt.selectedIndex() < 0 {
+		mt.selected = ""
+	}
 	mt.messages.Clear()
 
 	// Messages come in reverse chronological order; display oldest first.
+	// Each message is its own region so the selection can be highlighted.
 	for i := len(msgs) - 1; i >= 0; i-- {
 		m := msgs[i]
-		sender := m.SenderName
-		if sender == "" {
-			sender = m.SenderJid
-		}
-		if m.FromMe {
-			sender = "You"
+		// System rows (participants joining or leaving) have no header.
+		if m.MessageType == "system" {
+			_, _ = fmt.Fprintf(mt.messages, "[\"%d\"][::d]— %s · %s —[-:-:-][\"\"]\n\n",
+				i, tview.Escape(sanitizeForTerminal(m.Body)), formatTimestamp(m.TimestampUnixMs))
+			continue
 		}
+		sender := mt.senderName(m)
 
 		ts := formatTimestamp(m.TimestampUnixMs)
-		line := fmt.Sprintf("[::b]%s[-:-:-] [::d]%s[-:-:-]\n%s\n\n",
-			tview.Escape(sanitizeForTerminal(sender)), ts,
-			tview.Escape(sanitizeForTerminal(m.Body)))
+		if m.EditedAtUnixMs > 0 && !m.Deleted {
+			ts += " (edited)"
+		}
+		if m.FromMe {
+			ts += " " + statusMarker(m.Status)
+		}
+		body := tview.Escape(sanitizeForTerminal(m.Body))
+		if m.Deleted {
+			body = "[::di]This message was deleted[-:-:-]"
+		}
+		if m.Media != nil {
+			label := mediaLabel(m.Media)
+			if m.Body != "" && !m.Deleted {
+				label += "\n" + body
+			}
+			body = label
+		}
+		if m.QuotedMsgId != "" {
+			body = mt.quoteLine(m) + "\n" + body
+		}
+		if badges := reactionBadges(m.Reactions); badges != "" {
+			body += "\n" + badges
+		}
+		line := fmt.Sprintf("[\"%d\"][::b]%s[-:-:-] [::d]%s[-:-:-]\n%s[\"\"]\n\n",
+			i, tview.Escape(sanitizeForTerminal(sender)), ts, body)
 		_, _ = fmt.Fprint(mt.messages, line)
 	}
 
+	if i := mt.selectedIndex(); i >= 0 {
+		mt.messages.Highlight(fmt.Sprint(i))
+		mt.messages.ScrollToHighlight()
+		return
+	}
+	mt.messages.Highlight()
 	mt.messages.ScrollToEnd()
 }
 
@@ -153,3 +402,62 @@ func (mt *MessageThread) Messages() *tview.TextView {
 func (mt *MessageThread) Composer() *tview.InputField {
 	return mt.composer
 }
+
+// statusMarker renders the delivery state of an outgoing message
+// as WhatsApp-style ticks.
+func statusMarker(status string) string {
+	switch status {
+	case "queued", "sending":
+		return "…"
+	case "sent":
+		return "✓"
+	case "delivered":
+		return "✓✓"
+	case "read", "played":
+		return "[blue]✓✓[-]"
+	case "failed":
+		return "[red]✗[-]"
+	default:
+		return ""
+	}
+}
+
+// reactionBadges renders reaction counts as badges under a message,
+// highlighting the ones that include our own reaction.
+func reactionBadges(reactions []*wppv1.ReactionCount) string {
+	badges := make([]string, 0, len(reactions))
+	for _, r := range reactions {
+		badge := fmt.Sprintf("%s %d", tview.Escape(sanitizeForTerminal(r.Emoji)), r.Count)
+		if r.FromMe {
+			badge = "[::r]" + badge + "[-:-:-]"
+		}
+		badges = append(badges, badge)
+	}
+	return strings.Join(badges, "  ")
+}
+
+// mediaLabel renders a one-line description of an attachment.
+func mediaLabel(m *wppv1.MediaInfo) string {
+	label := "📎 " + m.MediaType
+	if m.FileName != "" {
+		label += " " + tview.Escape(sanitizeForTerminal(m.FileName))
+	}
+	if m.FileLength > 0 {
+		label += " (" + humanSize(m.FileLength) + ")"
+	}
+	return "[::d]" + label + "[-:-:-]"
+}
+
+// humanSize formats a byte count with a binary unit.
+func humanSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for q := n / unit; q >= unit; q /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,94 @@
+package views
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	wppv1 "github.com/matheus3301/wpp/gen/wpp/v1"
+	"github.com/matheus3301/wpp/internal/tui/ui"
+	"github.com/rivo/tview"
+)
+
+// ScheduledView lists the messages scheduled to be sent later.
+type ScheduledView struct {
+	*tview.Table
+	theme *ui.Theme
+	data  []*wppv1.ScheduledMessage
+}
+
+// NewScheduledView creates a new scheduled messages view.
+func NewScheduledView(theme *ui.Theme) *ScheduledView {
+	table := tview.NewTable().
+		SetSelectable(true, false).
+		SetBorders(false).
+		SetFixed(1, 0)
+	table.SetBorder(true)
+	table.SetBorderColor(theme.BorderColor)
+	table.SetBackgroundColor(theme.BgColor)
+	table.SetTitle(" Scheduled ")
+	table.SetTitleColor(theme.TitleColor)
+	table.SetSelectedStyle(tcell.StyleDefault.
+		Foreground(theme.TableCursorFg).
+		Background(theme.TableCursorBg))
+
+	return &ScheduledView{
+		Table: table,
+		theme: theme,
+	}
+}
+
+// Name implements Component.
+func (sv *ScheduledView) Name() string { return "Scheduled" }
+
+// Init implements Component.
+func (sv *ScheduledView) Init() {}
+
+// Start implements Component.
+func (sv *ScheduledView) Start() {}
+
+// Stop implements Component.
+func (sv *ScheduledView) Stop() {}
+
+// Hints implements Component.
+func (sv *ScheduledView) Hints() []ui.MenuHint {
+	return []ui.MenuHint{
+		{Key: "R", Description: "Send now"},
+		{Key: "x", Description: "Cancel"},
+		{Key: "Esc", Description: "Back"},
+		{Key: ":", Description: "Command"},
+	}
+}
+
+// Update renders the scheduled messages, soonest first. chatName resolves
+// a chat JID to its display name.
+func (sv *ScheduledView) Update(msgs []*wppv1.ScheduledMessage, chatName func(jid string) string) {
+	sv.data = msgs
+	sv.Clear()
+
+	headers := []string{" SEND AT", " CHAT", " MESSAGE"}
+	for col, h := range headers {
+		sv.SetCell(0, col, tview.NewTableCell(h).
+			SetSelectable(false).
+			SetTextColor(sv.theme.TableHeaderFg).
+			SetBackgroundColor(sv.theme.TableHeaderBg).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	for i, m := range msgs {
+		row := i + 1
+		sendAt := time.UnixMilli(m.SendAtUnixMs).Format("2006-01-02 15:04")
+		sv.SetCell(row, 0, tview.NewTableCell(" "+sendAt).SetTextColor(sv.theme.CounterColor))
+		sv.SetCell(row, 1, tview.NewTableCell(" "+tview.Escape(sanitizeForTerminal(chatName(m.ChatJid)))).SetMaxWidth(25).SetTextColor(sv.theme.FgColor))
+		sv.SetCell(row, 2, tview.NewTableCell(" "+tview.Escape(sanitizeForTerminal(m.Text))).SetExpansion(1).SetTextColor(sv.theme.FgColor))
+	}
+}
+
+// SelectedMessage returns the selected scheduled message, or nil.
+func (sv *ScheduledView) SelectedMessage() *wppv1.ScheduledMessage {
+	row, _ := sv.GetSelection()
+	idx := row - 1
+	if idx >= 0 && idx < len(sv.data) {
+		return sv.data[idx]
+	}
+	return nil
+}
@@ -21,12 +21,14 @@ type SearchView struct {
 func NewSearchView(theme *ui.Theme) *SearchView {
 	input := tview.NewInputField().
 		SetLabel(" Search: ").
+		SetPlaceholder("words, \"phrases\", from: in: after: before: has:link has:media type:").
 		SetFieldWidth(0)
 	input.SetBorderColor(theme.BorderColor)
 	input.SetBackgroundColor(theme.BgColor)
 	input.SetFieldBackgroundColor(theme.BgColor)
 	input.SetFieldTextColor(theme.FgColor)
 	input.SetLabelColor(theme.MenuKeyColor)
+	input.SetPlaceholderStyle(tcell.StyleDefault.Background(theme.BgColor).Foreground(tcell.ColorGray))
 
 	results := tview.NewTable().
 		SetSelectable(true, false).
@@ -3,11 +3,14 @@ package wa
 import (
 	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/matheus3301/wpp/internal/bus"
 	"github.com/matheus3301/wpp/internal/session"
 	"github.com/matheus3301/wpp/internal/store"
 	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/appstate"
 	"go.mau.fi/whatsmeow/proto/waE2E"
 	wastore "go.mau.fi/whatsmeow/store"
 	"go.mau.fi/whatsmeow/store/sqlstore"
@@ -21,10 +24,12 @@ import (
 // Adapter wraps the whatsmeow client and manages the WhatsApp connection.
 type Adapter struct {
 	client    *whatsmeow.Client
+	uploader  Uploader
 	container *sqlstore.Container
 	bus       *bus.Bus
 	logger    *zap.Logger
 	session   string
+	presence  presenceWatch
 }
 
 // NewAdapter creates a new WhatsApp adapter for the given session.
@@ -51,6 +56,7 @@ func NewAdapter(ctx context.Context, sessionName string, b *bus.Bus, logger *zap
 
 	return &Adapter{
 		client:    client,
+		uploader:  client,
 		container: container,
 		bus:       b,
 		logger:    logger,
@@ -90,21 +96,264 @@ func (a *Adapter) RegisterEventHandler(handler whatsmeow.EventHandler) {
 	a.client.AddEventHandler(handler)
 }
 
-// SendText sends a text message to the given JID. Returns the server message ID.
-func (a *Adapter) SendText(ctx context.Context, jid string, text string) (string, error) {
+// GenerateMessageID returns a new WhatsApp message ID, for assigning to an
+// outgoing message before it is sent.
+func (a *Adapter) GenerateMessageID() string {
+	return string(a.client.GenerateMessageID())
+}
+
+// SendText sends a text message to the given JID with the message ID msgID,
+// or a generated one if it is empty, quoting replyTo when it is not nil.
+// Sending again with the same msgID does not duplicate the message.
+// Returns the server message ID.
+func (a *Adapter) SendText(ctx context.Context, jid, msgID, text string, replyTo *store.QuotedMessage) (string, error) {
 	to, err := types.ParseJID(jid)
 	if err != nil {
 		return "", fmt.Errorf("parse JID: %w", err)
 	}
-	resp, err := a.client.SendMessage(ctx, to, &waE2E.Message{
-		Conversation: proto.String(text),
-	})
+	msg := &waE2E.Message{Conversation: proto.String(text)}
+	if replyTo != nil {
+		// Replies must be extended text messages to carry a ContextInfo.
+		msg = &waE2E.Message{ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+			Text:        proto.String(text),
+			ContextInfo: a.quoteContext(to, replyTo),
+		}}
+	}
+	resp, err := a.client.SendMessage(ctx, to, msg, whatsmeow.SendRequestExtra{ID: types.MessageID(msgID)})
 	if err != nil {
 		return "", fmt.Errorf("send message: %w", err)
 	}
 	return resp.ID, nil
 }
 
+// SendMedia uploads the cached file of m and sends it to the given JID
+// with the message ID msgID, as for SendText. m's key material is filled
+// in from the upload. Returns the server message ID.
+func (a *Adapter) SendMedia(ctx context.Context, jid, msgID string, m *store.Media, replyTo *store.QuotedMessage) (string, error) {
+	to, err := types.ParseJID(jid)
+	if err != nil {
+		return "", fmt.Errorf("parse JID: %w", err)
+	}
+	f, err := os.Open(m.LocalPath)
+	if err != nil {
+		return "", fmt.Errorf("open attachment: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	var ctxInfo *waE2E.ContextInfo
+	if replyTo != nil {
+		ctxInfo = a.quoteContext(to, replyTo)
+	}
+	msg, err := uploadMedia(ctx, a.uploader, m, f, ctxInfo)
+	if err != nil {
+		return "", err
+	}
+	resp, err := a.client.SendMessage(ctx, to, msg, whatsmeow.SendRequestExtra{ID: types.MessageID(msgID)})
+	if err != nil {
+		return "", fmt.Errorf("send message: %w", err)
+	}
+	return resp.ID, nil
+}
+
+// quoteContext returns the ContextInfo of a message to chat that quotes
+// replyTo.
+func (a *Adapter) quoteContext(chat types.JID, replyTo *store.QuotedMessage) *waE2E.ContextInfo {
+	participant := replyTo.Key.SenderJID
+	if replyTo.Key.FromMe && a.client.Store.ID != nil {
+		participant = a.client.Store.ID.ToNonAD().String()
+	} else if participant == "" {
+		participant = chat.ToNonAD().String()
+	}
+	return &waE2E.ContextInfo{
+		StanzaID:      proto.String(replyTo.Key.ID),
+		Participant:   proto.String(participant),
+		QuotedMessage: &waE2E.Message{Conversation: proto.String(replyTo.Body)},
+	}
+}
+
+// SendReaction reacts to the target message with emoji; an empty emoji
+// removes our reaction. msgID is as for SendText. Returns the server
+// message ID of the reaction.
+func (a *Adapter) SendReaction(ctx context.Context, target store.MessageKey, msgID, emoji string) (string, error) {
+	chat, err := types.ParseJID(target.ChatJID)
+	if err != nil {
+		return "", fmt.Errorf("parse JID: %w", err)
+	}
+	// An empty sender marks the target as our own message.
+	sender := types.EmptyJID
+	if !target.FromMe {
+		sender = chat
+		if target.SenderJID != "" {
+			if sender, err = types.ParseJID(target.SenderJID); err != nil {
+				return "", fmt.Errorf("parse sender JID: %w", err)
+			}
+		}
+	}
+	resp, err := a.client.SendMessage(ctx, chat, a.client.BuildReaction(chat, sender, target.ID, emoji), whatsmeow.SendRequestExtra{ID: types.MessageID(msgID)})
+	if err != nil {
+		return "", fmt.Errorf("send reaction: %w", err)
+	}
+	return resp.ID, nil
+}
+
+// EditWindow is how long after sending a message it can still be edited.
+const EditWindow = whatsmeow.EditWindow
+
+// SendEdit replaces the text of our message target with text, sending the
+// edit with the message ID msgID as for SendText. Returns the server
+// message ID of the edit.
+func (a *Adapter) SendEdit(ctx context.Context, target store.MessageKey, msgID, text string) (string, error) {
+	chat, err := types.ParseJID(target.ChatJID)
+	if err != nil {
+		return "", fmt.Errorf("parse JID: %w", err)
+	}
+	msg := a.client.BuildEdit(chat, target.ID, &waE2E.Message{Conversation: proto.String(text)})
+	resp, err := a.client.SendMessage(ctx, chat, msg, whatsmeow.SendRequestExtra{ID: types.MessageID(msgID)})
+	if err != nil {
+		return "", fmt.Errorf("send edit: %w", err)
+	}
+	return resp.ID, nil
+}
+
+// SendRevoke deletes our message target for everyone, sending the revoke
+// with the message ID msgID as for SendText. Returns the server message ID
+// of the revoke.
+func (a *Adapter) SendRevoke(ctx context.Context, target store.MessageKey, msgID string) (string, error) {
+	chat, err := types.ParseJID(target.ChatJID)
+	if err != nil {
+		return "", fmt.Errorf("parse JID: %w", err)
+	}
+	// An empty sender marks the target as our own message.
+	msg := a.client.BuildRevoke(chat, types.EmptyJID, target.ID)
+	resp, err := a.client.SendMessage(ctx, chat, msg, whatsmeow.SendRequestExtra{ID: types.MessageID(msgID)})
+	if err != nil {
+		return "", fmt.Errorf("send revoke: %w", err)
+	}
+	return resp.ID, nil
+}
+
+// RequestHistory asks our phone, in a message with the ID msgID, for up
+// to count messages of the chat of oldest that are older than it, oldest
+// being sent at timestamp (Unix ms). The phone answers later with an
+// on-demand history sync chunk for the chat.
+func (a *Adapter) RequestHistory(ctx context.Context, msgID string, oldest store.MessageKey, timestamp int64, count int) error {
+	if a.client.Store.ID == nil {
+		return fmt.Errorf("not logged in")
+	}
+	chat, err := types.ParseJID(oldest.ChatJID)
+	if err != nil {
+		return fmt.Errorf("parse JID: %w", err)
+	}
+	msg := a.client.BuildHistorySyncRequest(&types.MessageInfo{
+		MessageSource: types.MessageSource{Chat: chat, IsFromMe: oldest.FromMe},
+		ID:            oldest.ID,
+		Timestamp:     time.UnixMilli(timestamp),
+	}, count)
+	if _, err := a.client.SendMessage(ctx, a.client.Store.ID.ToNonAD(), msg, whatsmeow.SendRequestExtra{ID: types.MessageID(msgID), Peer: true}); err != nil {
+		return fmt.Errorf("send history request: %w", err)
+	}
+	return nil
+}
+
+// MarkRead sends read receipts for incoming messages of one chat. Group
+// receipts name the sender, so messages are sent in one receipt per sender.
+func (a *Adapter) MarkRead(ctx context.Context, chatJID string, msgs []store.MessageKey) error {
+	chat, err := types.ParseJID(chatJID)
+	if err != nil {
+		return fmt.Errorf("parse JID: %w", err)
+	}
+	var senders []string
+	bySender := make(map[string][]types.MessageID)
+	for _, m := range msgs {
+		if _, ok := bySender[m.SenderJID]; !ok {
+			senders = append(senders, m.SenderJID)
+		}
+		bySender[m.SenderJID] = append(bySender[m.SenderJID], m.ID)
+	}
+	now := time.Now()
+	for _, s := range senders {
+		sender := types.EmptyJID
+		if chat.Server == types.GroupServer && s != "" {
+			if sender, err = types.ParseJID(s); err != nil {
+				return fmt.Errorf("parse sender JID: %w", err)
+			}
+		}
+		if err := a.client.MarkRead(ctx, bySender[s], now, chat, sender); err != nil {
+			return fmt.Errorf("mark read: %w", err)
+		}
+	}
+	return nil
+}
+
+// ArchiveChat archives or unarchives a chat through app state. WhatsApp
+// unpins a chat when it is archived.
+func (a *Adapter) ArchiveChat(ctx context.Context, chatJID string, archive bool) error {
+	return a.sendChatPatch(ctx, chatJID, func(chat types.JID) appstate.PatchInfo {
+		return appstate.BuildArchive(chat, archive, time.Time{}, nil)
+	})
+}
+
+// PinChat pins or unpins a chat through app state.
+func (a *Adapter) PinChat(ctx context.Context, chatJID string, pin bool) error {
+	return a.sendChatPatch(ctx, chatJID, func(chat types.JID) appstate.PatchInfo {
+		return appstate.BuildPin(chat, pin)
+	})
+}
+
+// MuteChat mutes a chat until mutedUntil (Unix ms) through app state, or
+// indefinitely when it is negative. Zero unmutes the chat.
+func (a *Adapter) MuteChat(ctx context.Context, chatJID string, mutedUntil int64) error {
+	return a.sendChatPatch(ctx, chatJID, func(chat types.JID) appstate.PatchInfo {
+		if mutedUntil == 0 {
+			return appstate.BuildMuteAbs(chat, false, nil)
+		}
+		return appstate.BuildMuteAbs(chat, true, proto.Int64(mutedUntil))
+	})
+}
+
+func (a *Adapter) sendChatPatch(ctx context.Context, chatJID string, build func(types.JID) appstate.PatchInfo) error {
+	chat, err := types.ParseJID(chatJID)
+	if err != nil {
+		return fmt.Errorf("parse JID: %w", err)
+	}
+	if err := a.client.SendAppState(ctx, build(chat)); err != nil {
+		return fmt.Errorf("send app state: %w", err)
+	}
+	return nil
+}
+
+// GetJoinedGroups fetches the metadata of every group we are a member of.
+func (a *Adapter) GetJoinedGroups(ctx context.Context) ([]*types.GroupInfo, error) {
+	groups, err := a.client.GetJoinedGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get joined groups: %w", err)
+	}
+	return groups, nil
+}
+
+// Download fetches and decrypts the file of a media attachment.
+func (a *Adapter) Download(ctx context.Context, m *store.Media) ([]byte, error) {
+	var msg whatsmeow.DownloadableMessage
+	switch m.MediaType {
+	case "image":
+		msg = &waE2E.ImageMessage{DirectPath: &m.DirectPath, MediaKey: m.MediaKey, FileSHA256: m.FileSHA256, FileEncSHA256: m.FileEncSHA256}
+	case "video":
+		msg = &waE2E.VideoMessage{DirectPath: &m.DirectPath, MediaKey: m.MediaKey, FileSHA256: m.FileSHA256, FileEncSHA256: m.FileEncSHA256}
+	case "audio":
+		msg = &waE2E.AudioMessage{DirectPath: &m.DirectPath, MediaKey: m.MediaKey, FileSHA256: m.FileSHA256, FileEncSHA256: m.FileEncSHA256}
+	case "document":
+		msg = &waE2E.DocumentMessage{DirectPath: &m.DirectPath, MediaKey: m.MediaKey, FileSHA256: m.FileSHA256, FileEncSHA256: m.FileEncSHA256}
+	case "sticker":
+		msg = &waE2E.StickerMessage{DirectPath: &m.DirectPath, MediaKey: m.MediaKey, FileSHA256: m.FileSHA256, FileEncSHA256: m.FileEncSHA256}
+	default:
+		return nil, fmt.Errorf("unknown media type %q", m.MediaType)
+	}
+	data, err := a.client.Download(ctx, msg)
+	if err != nil {
+		return nil, fmt.Errorf("download media: %w", err)
+	}
+	return data, nil
+}
+
 // GetQRChannel returns the QR channel for pairing. Must be called before Connect.
 func (a *Adapter) GetQRChannel(ctx context.Context) (<-chan whatsmeow.QRChannelItem, error) {
 	if a.IsLoggedIn() {
@@ -2,11 +2,15 @@ package wa
 
 import (
 	"context"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/matheus3301/wpp/internal/bus"
 	"github.com/matheus3301/wpp/internal/status"
 	"github.com/matheus3301/wpp/internal/store"
+	"go.mau.fi/whatsmeow/proto/waHistorySync"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 	"go.uber.org/zap"
@@ -20,6 +24,7 @@ type EventHandler struct {
 	machine *status.Machine
 	adapter *Adapter
 	logger  *zap.Logger
+	ready   readiness
 }
 
 // NewEventHandler creates a new event handler.
@@ -29,6 +34,7 @@ func NewEventHandler(b *bus.Bus, machine *status.Machine, adapter *Adapter, logg
 		machine: machine,
 		adapter: adapter,
 		logger:  logger,
+		ready:   readiness{timeout: syncTimeout},
 	}
 }
 
@@ -39,18 +45,74 @@ func (h *EventHandler) Handle(rawEvt any) {
 		h.handleMessage(evt)
 	case *events.PushName:
 		h.handlePushName(evt)
+	case *events.Receipt:
+		h.handleReceipt(evt)
+	case *events.GroupInfo:
+		h.handleGroupInfo(evt)
+	case *events.JoinedGroup:
+		h.publishGroup(h.groupFromInfo(&evt.GroupInfo))
+	case *events.MarkChatAsRead:
+		h.handleMarkChatAsRead(evt)
+	case *events.Archive:
+		archived := evt.Action.GetArchived()
+		h.publishChatSettings(&store.ChatSettings{ChatJID: h.resolveJID(evt.JID.ToNonAD().String()), Archived: &archived})
+	case *events.Pin:
+		h.handlePin(evt)
+	case *events.Mute:
+		h.handleMute(evt)
+	case *events.ClearChat:
+		h.publishChatClear(&store.ChatClear{
+			ChatJID: h.resolveJID(evt.JID.ToNonAD().String()),
+			Before:  evt.Action.GetMessageRange().GetLastMessageTimestamp() * 1000,
+		})
+	case *events.DeleteChat:
+		h.publishChatClear(&store.ChatClear{
+			ChatJID: h.resolveJID(evt.JID.ToNonAD().String()),
+			Before:  evt.Action.GetMessageRange().GetLastMessageTimestamp() * 1000,
+			Delete:  true,
+		})
+	case *events.ChatPresence:
+		h.handleChatPresence(evt)
+	case *events.Presence:
+		h.handlePresence(evt)
 	case *events.Connected:
 		h.logger.Info("WhatsApp connected")
 		current := h.machine.Current()
-		if current == status.AuthRequired || current == status.Reconnecting {
+		if current == status.AuthRequired || current == status.Reconnecting || current == status.Degraded {
 			_ = h.machine.Transition(status.Connecting)
 		}
 		_ = h.machine.Transition(status.Syncing)
+		h.startSync()
 		h.bus.Publish(bus.Event{Kind: "sync.connected", Timestamp: time.Now()})
+		if h.adapter != nil {
+			go h.syncGroups()
+			go h.adapter.restorePresence(context.Background())
+		}
 	case *events.Disconnected:
 		h.logger.Warn("WhatsApp disconnected")
+		h.stopSync()
 		_ = h.machine.Transition(status.Reconnecting)
 		h.bus.Publish(bus.Event{Kind: "sync.disconnected", Timestamp: time.Now()})
+	case *events.OfflineSyncCompleted:
+		h.logger.Info("offline sync completed", zap.Int("count", evt.Count))
+		h.syncCompleted()
+	case *events.KeepAliveTimeout:
+		h.degrade("keepalive timeout")
+	case *events.KeepAliveRestored:
+		h.restore()
+	case *events.StreamError:
+		h.degrade("stream error " + evt.Code)
+	case *events.StreamReplaced:
+		// Another client took over the session. The WhatsApp client does not
+		// reconnect after this, so it is not a degraded connection.
+		h.logger.Error("WhatsApp session taken over by another client")
+		h.stopSync()
+		_ = h.machine.Transition(status.Error)
+		h.bus.Publish(bus.Event{
+			Kind:      "sync.disconnected",
+			Timestamp: time.Now(),
+			Payload:   map[string]string{"reason": "replaced by another client"},
+		})
 	case *events.HistorySync:
 		h.handleHistorySync(evt)
 	case *events.LoggedOut:
@@ -75,14 +137,42 @@ func (h *EventHandler) resolveJID(jid string) string {
 }
 
 func (h *EventHandler) handleMessage(evt *events.Message) {
-	if h.machine.Current() == status.Syncing {
-		_ = h.machine.Transition(status.Ready)
+	// Protocol messages are never shown; edits and revokes change
+	// the message they refer to.
+	if evt.Message.GetProtocolMessage() != nil {
+		if change := parseMessageChange(evt.Message); change != nil {
+			change.ChatJID = h.resolveJID(evt.Info.Chat.ToNonAD().String())
+			change.SenderJID = h.resolveJID(evt.Info.Sender.ToNonAD().String())
+			change.FromMe = evt.Info.IsFromMe
+			if change.Timestamp == 0 {
+				change.Timestamp = evt.Info.Timestamp.UnixMilli()
+			}
+			h.publishMessageChange(change)
+		}
+		return
+	}
+
+	// Reactions annotate the message they target instead of becoming rows.
+	if evt.Message.GetReactionMessage() != nil {
+		if reaction := parseReaction(evt.Message); reaction != nil {
+			reaction.ChatJID = h.resolveJID(evt.Info.Chat.ToNonAD().String())
+			reaction.SenderJID = h.resolveJID(evt.Info.Sender.ToNonAD().String())
+			reaction.FromMe = evt.Info.IsFromMe
+			if reaction.Timestamp == 0 {
+				reaction.Timestamp = evt.Info.Timestamp.UnixMilli()
+			}
+			h.publishReaction(reaction)
+		}
+		return
 	}
 
 	parsed := ParseLiveMessage(evt)
 	// Resolve LID JIDs to phone number JIDs.
 	parsed.ChatJID = h.resolveJID(parsed.ChatJID)
 	parsed.SenderJID = h.resolveJID(parsed.SenderJID)
+	if parsed.QuotedSender != "" {
+		parsed.QuotedSender = h.resolveJID(parsed.QuotedSender)
+	}
 
 	h.bus.Publish(bus.Event{
 		Kind:      "wa.message",
@@ -103,6 +193,22 @@ func (h *EventHandler) handleMessage(evt *events.Message) {
 	}
 }
 
+func (h *EventHandler) publishMessageChange(change *store.MessageChange) {
+	h.bus.Publish(bus.Event{
+		Kind:      "wa.message_change",
+		Timestamp: time.Now(),
+		Payload:   change,
+	})
+}
+
+func (h *EventHandler) publishReaction(reaction *store.Reaction) {
+	h.bus.Publish(bus.Event{
+		Kind:      "wa.reaction",
+		Timestamp: time.Now(),
+		Payload:   reaction,
+	})
+}
+
 func (h *EventHandler) handlePushName(evt *events.PushName) {
 	h.bus.Publish(bus.Event{
 		Kind:      "wa.contact",
@@ -114,16 +220,262 @@ func (h *EventHandler) handlePushName(evt *events.PushName) {
 	})
 }
 
+func (h *EventHandler) handleReceipt(evt *events.Receipt) {
+	// Receipts from our own devices (read-self, played-self) describe
+	// incoming messages we read elsewhere, not delivery of ours. A
+	// read-self receipt means the chat was read on another device.
+	if evt.IsFromMe {
+		if evt.Type == types.ReceiptTypeReadSelf {
+			h.publishChatRead(&store.ChatReadState{ChatJID: h.resolveJID(evt.Chat.ToNonAD().String())})
+		}
+		return
+	}
+	st := receiptStatus(evt.Type)
+	if st == "" || len(evt.MessageIDs) == 0 {
+		return
+	}
+	h.bus.Publish(bus.Event{
+		Kind:      "wa.receipt",
+		Timestamp: time.Now(),
+		Payload: &store.Receipt{
+			ChatJID:   h.resolveJID(evt.Chat.ToNonAD().String()),
+			MsgIDs:    evt.MessageIDs,
+			Status:    st,
+			Timestamp: evt.Timestamp.UnixMilli(),
+		},
+	})
+}
+
+func (h *EventHandler) handleMarkChatAsRead(evt *events.MarkChatAsRead) {
+	state := &store.ChatReadState{ChatJID: h.resolveJID(evt.JID.ToNonAD().String())}
+	if !evt.Action.GetRead() {
+		state.UnreadCount = -1
+	}
+	h.publishChatRead(state)
+}
+
+// handlePin records when a chat was pinned, falling back to now when the
+// action carries no time.
+func (h *EventHandler) handlePin(evt *events.Pin) {
+	pinnedAt := int64(0)
+	if evt.Action.GetPinned() {
+		pinnedAt = evt.Timestamp.UnixMilli()
+		if evt.Timestamp.IsZero() {
+			pinnedAt = time.Now().UnixMilli()
+		}
+	}
+	h.publishChatSettings(&store.ChatSettings{ChatJID: h.resolveJID(evt.JID.ToNonAD().String()), PinnedAt: &pinnedAt})
+}
+
+// handleMute records when a chat's mute ends. WhatsApp reports mutes
+// without an end as a negative timestamp.
+func (h *EventHandler) handleMute(evt *events.Mute) {
+	mutedUntil := int64(0)
+	if evt.Action.GetMuted() {
+		mutedUntil = evt.Action.GetMuteEndTimestamp()
+		if mutedUntil <= 0 {
+			mutedUntil = -1
+		}
+	}
+	h.publishChatSettings(&store.ChatSettings{ChatJID: h.resolveJID(evt.JID.ToNonAD().String()), MutedUntil: &mutedUntil})
+}
+
+// handleChatPresence publishes whether someone is typing in a chat.
+// Composing a voice note is reported as recording.
+func (h *EventHandler) handleChatPresence(evt *events.ChatPresence) {
+	if evt.IsFromMe {
+		return
+	}
+	state := string(evt.State)
+	if evt.State == types.ChatPresenceComposing && evt.Media == types.ChatPresenceMediaAudio {
+		state = "recording"
+	}
+	h.bus.Publish(bus.Event{
+		Kind:      "presence.chat_state",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"chat_jid":   h.resolveJID(evt.Chat.ToNonAD().String()),
+			"sender_jid": h.resolveJID(evt.Sender.ToNonAD().String()),
+			"state":      state,
+		},
+	})
+}
+
+// handlePresence publishes whether a contact is online, keyed by their
+// direct chat.
+func (h *EventHandler) handlePresence(evt *events.Presence) {
+	lastSeen := int64(0)
+	if !evt.LastSeen.IsZero() {
+		lastSeen = evt.LastSeen.UnixMilli()
+	}
+	h.bus.Publish(bus.Event{
+		Kind:      "presence.updated",
+		Timestamp: time.Now(),
+		Payload: map[string]string{
+			"chat_jid":     h.resolveJID(evt.From.ToNonAD().String()),
+			"online":       strconv.FormatBool(!evt.Unavailable),
+			"last_seen_at": strconv.FormatInt(lastSeen, 10),
+		},
+	})
+}
+
+func (h *EventHandler) publishChatRead(state *store.ChatReadState) {
+	h.bus.Publish(bus.Event{
+		Kind:      "wa.chat_read",
+		Timestamp: time.Now(),
+		Payload:   state,
+	})
+}
+
+func (h *EventHandler) publishChatSettings(s *store.ChatSettings) {
+	h.bus.Publish(bus.Event{
+		Kind:      "wa.chat_settings",
+		Timestamp: time.Now(),
+		Payload:   s,
+	})
+}
+
+func (h *EventHandler) publishChatClear(c *store.ChatClear) {
+	h.bus.Publish(bus.Event{
+		Kind:      "wa.chat_clear",
+		Timestamp: time.Now(),
+		Payload:   c,
+	})
+}
+
+// syncGroups refreshes the metadata of all joined groups, which group
+// notifications only update incrementally.
+func (h *EventHandler) syncGroups() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	groups, err := h.adapter.GetJoinedGroups(ctx)
+	if err != nil {
+		h.logger.Warn("failed to fetch joined groups", zap.Error(err))
+		return
+	}
+	for _, info := range groups {
+		h.publishGroup(h.groupFromInfo(info))
+	}
+	h.logger.Info("group metadata refreshed", zap.Int("groups", len(groups)))
+}
+
+// groupFromInfo converts whatsmeow group metadata, preferring phone number
+// JIDs for participants of LID-addressed groups.
+func (h *EventHandler) groupFromInfo(info *types.GroupInfo) *store.Group {
+	owner := ""
+	if !info.OwnerPN.IsEmpty() {
+		owner = info.OwnerPN.ToNonAD().String()
+	} else if !info.OwnerJID.IsEmpty() {
+		owner = h.resolveJID(info.OwnerJID.ToNonAD().String())
+	}
+	g := &store.Group{
+		JID:          info.JID.ToNonAD().String(),
+		Name:         info.Name,
+		Topic:        info.Topic,
+		OwnerJID:     owner,
+		Participants: make([]store.GroupParticipant, 0, len(info.Participants)),
+	}
+	if !info.GroupCreated.IsZero() {
+		g.CreatedAt = info.GroupCreated.UnixMilli()
+	}
+	for _, p := range info.Participants {
+		jid := p.JID
+		if jid.Server == types.HiddenUserServer && !p.PhoneNumber.IsEmpty() {
+			jid = p.PhoneNumber
+		}
+		g.Participants = append(g.Participants, store.GroupParticipant{
+			JID:          h.resolveJID(jid.ToNonAD().String()),
+			IsAdmin:      p.IsAdmin,
+			IsSuperAdmin: p.IsSuperAdmin,
+		})
+	}
+	return g
+}
+
+func (h *EventHandler) publishGroup(g *store.Group) {
+	h.bus.Publish(bus.Event{
+		Kind:      "wa.group",
+		Timestamp: time.Now(),
+		Payload:   g,
+	})
+}
+
+func (h *EventHandler) handleGroupInfo(evt *events.GroupInfo) {
+	change := &store.GroupChange{
+		GroupJID:  evt.JID.ToNonAD().String(),
+		Join:      h.resolveJIDs(evt.Join),
+		Leave:     h.resolveJIDs(evt.Leave),
+		Promote:   h.resolveJIDs(evt.Promote),
+		Demote:    h.resolveJIDs(evt.Demote),
+		Timestamp: evt.Timestamp.UnixMilli(),
+	}
+	if evt.Name != nil {
+		change.Name = &evt.Name.Name
+	}
+	if evt.Topic != nil {
+		topic := evt.Topic.Topic
+		if evt.Topic.TopicDeleted {
+			topic = ""
+		}
+		change.Topic = &topic
+	}
+	if change.Name == nil && change.Topic == nil && len(change.Join)+len(change.Leave)+len(change.Promote)+len(change.Demote) == 0 {
+		return
+	}
+	h.bus.Publish(bus.Event{
+		Kind:      "wa.group_change",
+		Timestamp: time.Now(),
+		Payload:   change,
+	})
+}
+
+func (h *EventHandler) resolveJIDs(jids []types.JID) []string {
+	if len(jids) == 0 {
+		return nil
+	}
+	out := make([]string, len(jids))
+	for i, jid := range jids {
+		out[i] = h.resolveJID(jid.ToNonAD().String())
+	}
+	return out
+}
+
 func (h *EventHandler) handleHistorySync(evt *events.HistorySync) {
 	data := evt.Data
 	if data == nil {
 		return
 	}
+	// The last chunk of the initial history means we have caught up even
+	// if the offline sync completion never arrives. Full and on-demand
+	// history arrive later and say nothing about this connection.
+	switch data.GetSyncType() {
+	case waHistorySync.HistorySync_INITIAL_BOOTSTRAP, waHistorySync.HistorySync_RECENT:
+		if data.GetProgress() >= 100 {
+			defer h.syncCompleted()
+		}
+	}
 
 	var msgs []*store.Message
+	var changes []*store.MessageChange
+	var reactions []*store.Reaction
 	var contacts []*store.Contact
+	var readStates []*store.ChatReadState
+	var settings []*store.ChatSettings
+	var chats []string
 	for _, conv := range data.GetConversations() {
 		chatJID := h.resolveJID(conv.GetID())
+		chats = append(chats, chatJID)
+
+		// The conversation's unread count is authoritative; history
+		// messages never count as unread on their own.
+		readState := &store.ChatReadState{ChatJID: chatJID, UnreadCount: int(conv.GetUnreadCount())}
+		if readState.UnreadCount == 0 && conv.GetMarkedAsUnread() {
+			readState.UnreadCount = -1
+		}
+		readStates = append(readStates, readState)
+		if s := conversationSettings(chatJID, conv); s != nil {
+			settings = append(settings, s)
+		}
 
 		// Extract chat/contact name from conversation metadata.
 		convName := conv.GetName()
@@ -140,7 +492,26 @@ func (h *EventHandler) handleHistorySync(evt *events.HistorySync) {
 				continue
 			}
 			info := wmsg.GetMessage()
+			if info.GetProtocolMessage() != nil {
+				if change := parseMessageChange(info); change != nil {
+					change.ChatJID = chatJID
+					change.SenderJID = chatJID
+					if p := wmsg.GetKey().GetParticipant(); p != "" {
+						change.SenderJID = h.resolveJID(p)
+					}
+					change.FromMe = wmsg.GetKey().GetFromMe()
+					if change.Timestamp == 0 {
+						change.Timestamp = int64(wmsg.GetMessageTimestamp()) * 1000
+					}
+					changes = append(changes, change)
+				}
+				continue
+			}
+			if info.GetReactionMessage() != nil {
+				continue
+			}
 			senderJID := h.resolveJID(wmsg.GetKey().GetParticipant())
+			ci := contextInfo(info)
 			parsed := &ParsedMessage{
 				ChatJID:     chatJID,
 				MsgID:       wmsg.GetKey().GetID(),
@@ -149,9 +520,32 @@ func (h *EventHandler) handleHistorySync(evt *events.HistorySync) {
 				MessageType: detectMessageType(info),
 				FromMe:      wmsg.GetKey().GetFromMe(),
 				Timestamp:   int64(wmsg.GetMessageTimestamp()) * 1000,
+
+				QuotedMsgID: ci.GetStanzaID(),
+				QuotedBody:  quotedBody(ci),
+				Media:       parseMedia(info),
+			}
+			if qs := quotedSender(ci); qs != "" {
+				parsed.QuotedSender = h.resolveJID(qs)
 			}
 			msgs = append(msgs, parsed.ToStoreMessage())
 
+			// History sync carries reactions on the message they target.
+			for _, r := range wmsg.GetReactions() {
+				reactionSender := chatJID
+				if p := r.GetKey().GetParticipant(); p != "" {
+					reactionSender = h.resolveJID(p)
+				}
+				reactions = append(reactions, &store.Reaction{
+					ChatJID:   chatJID,
+					MsgID:     parsed.MsgID,
+					SenderJID: reactionSender,
+					Emoji:     r.GetText(),
+					FromMe:    r.GetKey().GetFromMe(),
+					Timestamp: r.GetSenderTimestampMS(),
+				})
+			}
+
 			// Extract push name from history message if available.
 			if pn := wmsg.GetPushName(); pn != "" && senderJID != "" {
 				contacts = append(contacts, &store.Contact{
@@ -162,11 +556,36 @@ func (h *EventHandler) handleHistorySync(evt *events.HistorySync) {
 		}
 	}
 
-	if len(msgs) > 0 {
+	// Chunks without conversations (push names, status) carry no progress
+	// of the message history.
+	if len(data.GetConversations()) > 0 {
 		h.bus.Publish(bus.Event{
 			Kind:      "wa.history_batch",
 			Timestamp: time.Now(),
-			Payload:   msgs,
+			Payload: &store.HistoryBatch{
+				SyncType:   strings.ToLower(data.GetSyncType().String()),
+				ChunkOrder: int(data.GetChunkOrder()),
+				Progress:   int(data.GetProgress()),
+				Chats:      chats,
+				Messages:   msgs,
+			},
+		})
+	}
+
+	// Changes and reactions go out after the batch so their targets are
+	// stored first.
+	if len(changes) > 0 {
+		h.bus.Publish(bus.Event{
+			Kind:      "wa.message_change_batch",
+			Timestamp: time.Now(),
+			Payload:   changes,
+		})
+	}
+	if len(reactions) > 0 {
+		h.bus.Publish(bus.Event{
+			Kind:      "wa.reaction_batch",
+			Timestamp: time.Now(),
+			Payload:   reactions,
 		})
 	}
 
@@ -177,4 +596,40 @@ func (h *EventHandler) handleHistorySync(evt *events.HistorySync) {
 			Payload:   contacts,
 		})
 	}
+
+	// Read states and settings apply to the chats the batch created.
+	for _, state := range readStates {
+		h.publishChatRead(state)
+	}
+	for _, s := range settings {
+		h.publishChatSettings(s)
+	}
+}
+
+// conversationSettings returns the app state settings a history sync
+// conversation carries, or nil when it carries none. Unlike app state,
+// history sync reports times in seconds.
+func conversationSettings(chatJID string, conv *waHistorySync.Conversation) *store.ChatSettings {
+	s := &store.ChatSettings{ChatJID: chatJID}
+	if conv.Archived != nil {
+		archived := conv.GetArchived()
+		s.Archived = &archived
+	}
+	if conv.Pinned != nil {
+		pinnedAt := int64(conv.GetPinned()) * 1000
+		s.PinnedAt = &pinnedAt
+	}
+	if conv.MuteEndTime != nil {
+		mutedUntil := int64(0)
+		if end := conv.GetMuteEndTime(); end > math.MaxInt64/1000 {
+			mutedUntil = -1
+		} else if end > 0 {
+			mutedUntil = int64(end) * 1000
+		}
+		s.MutedUntil = &mutedUntil
+	}
+	if s.Archived == nil && s.PinnedAt == nil && s.MutedUntil == nil {
+		return nil
+	}
+	return s
 }
@@ -2,6 +2,7 @@ package wa
 
 import (
 	"context"
+	"maps"
 	"testing"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"go.mau.fi/whatsmeow/proto/waCommon"
 	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/proto/waHistorySync"
+	"go.mau.fi/whatsmeow/proto/waSyncAction"
 	"go.mau.fi/whatsmeow/proto/waWeb"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
@@ -112,7 +114,7 @@ func TestHandleDisconnected(t *testing.T) {
 	}
 }
 
-func TestHandleMessageTransitionsToReady(t *testing.T) {
+func TestHandleMessageWhileSyncing(t *testing.T) {
 	b := bus.New()
 	m := status.NewMachine(b)
 	logger := zap.NewNop()
@@ -135,8 +137,10 @@ func TestHandleMessageTransitionsToReady(t *testing.T) {
 		Message: &waE2E.Message{Conversation: proto.String("hello")},
 	})
 
-	if m.Current() != status.Ready {
-		t.Errorf("state = %s, want READY (first message after sync)", m.Current())
+	// Offline messages arrive before the offline sync completes, so a
+	// message alone does not mean we have caught up.
+	if m.Current() != status.Syncing {
+		t.Errorf("state = %s, want SYNCING (messages do not end the sync)", m.Current())
 	}
 
 	select {
@@ -149,6 +153,199 @@ func TestHandleMessageTransitionsToReady(t *testing.T) {
 	}
 }
 
+func TestHandleOfflineSyncCompleted(t *testing.T) {
+	b := bus.New()
+	m := status.NewMachine(b)
+	h := NewEventHandler(b, m, nil, zap.NewNop())
+
+	walkTo(t, m, status.Connecting)
+	h.Handle(&events.Connected{})
+	h.Handle(&events.OfflineSyncPreview{Total: 3, Messages: 3})
+	if m.Current() != status.Syncing {
+		t.Fatalf("state = %s, want SYNCING before completion", m.Current())
+	}
+
+	h.Handle(&events.OfflineSyncCompleted{Count: 3})
+	if m.Current() != status.Ready {
+		t.Errorf("state = %s, want READY", m.Current())
+	}
+}
+
+func TestHandleHistorySyncCompleteTransitionsToReady(t *testing.T) {
+	b := bus.New()
+	m := status.NewMachine(b)
+	h := NewEventHandler(b, m, nil, zap.NewNop())
+
+	walkTo(t, m, status.Connecting)
+	h.Handle(&events.Connected{})
+
+	h.Handle(&events.HistorySync{Data: &waHistorySync.HistorySync{
+		SyncType: waHistorySync.HistorySync_INITIAL_BOOTSTRAP.Enum(),
+		Progress: proto.Uint32(40),
+	}})
+	if m.Current() != status.Syncing {
+		t.Fatalf("state = %s, want SYNCING mid-history", m.Current())
+	}
+
+	// The end of an on-demand or full history is not the end of the initial one.
+	for _, syncType := range []waHistorySync.HistorySync_HistorySyncType{waHistorySync.HistorySync_ON_DEMAND, waHistorySync.HistorySync_FULL} {
+		h.Handle(&events.HistorySync{Data: &waHistorySync.HistorySync{
+			SyncType: syncType.Enum(),
+			Progress: proto.Uint32(100),
+		}})
+		if m.Current() != status.Syncing {
+			t.Fatalf("state = %s after the last %s chunk, want SYNCING", m.Current(), syncType)
+		}
+	}
+
+	h.Handle(&events.HistorySync{Data: &waHistorySync.HistorySync{
+		SyncType: waHistorySync.HistorySync_INITIAL_BOOTSTRAP.Enum(),
+		Progress: proto.Uint32(100),
+	}})
+	if m.Current() != status.Ready {
+		t.Errorf("state = %s, want READY after the last history chunk", m.Current())
+	}
+}
+
+func TestSyncTimeoutFallback(t *testing.T) {
+	b := bus.New()
+	m := status.NewMachine(b)
+	h := NewEventHandler(b, m, nil, zap.NewNop())
+	h.ready.timeout = 20 * time.Millisecond
+
+	walkTo(t, m, status.Connecting)
+	h.Handle(&events.Connected{})
+
+	deadline := time.Now().Add(time.Second)
+	for m.Current() != status.Ready {
+		if time.Now().After(deadline) {
+			t.Fatalf("state = %s, want READY after the sync timeout", m.Current())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSyncTimeoutCancelledByDisconnect(t *testing.T) {
+	b := bus.New()
+	m := status.NewMachine(b)
+	h := NewEventHandler(b, m, nil, zap.NewNop())
+	h.ready.timeout = 20 * time.Millisecond
+
+	walkTo(t, m, status.Connecting)
+	h.Handle(&events.Connected{})
+	h.Handle(&events.Disconnected{})
+	walkTo(t, m, status.Connecting, status.Syncing)
+
+	// The timer of the dropped connection must not end this one's sync.
+	time.Sleep(60 * time.Millisecond)
+	if m.Current() != status.Syncing {
+		t.Errorf("state = %s, want SYNCING", m.Current())
+	}
+}
+
+func TestKeepAliveDegradesAndRestores(t *testing.T) {
+	b := bus.New()
+	m := status.NewMachine(b)
+	h := NewEventHandler(b, m, nil, zap.NewNop())
+
+	walkTo(t, m, status.Connecting)
+	h.Handle(&events.Connected{})
+	h.Handle(&events.OfflineSyncCompleted{})
+
+	ch, unsub := b.Subscribe("sync.degraded", 10)
+	defer unsub()
+
+	h.Handle(&events.KeepAliveTimeout{ErrorCount: 1})
+	if m.Current() != status.Degraded {
+		t.Fatalf("state = %s, want DEGRADED", m.Current())
+	}
+	select {
+	case evt := <-ch:
+		if p, _ := evt.Payload.(map[string]string); p["reason"] != "keepalive timeout" {
+			t.Errorf("payload = %v, want keepalive timeout reason", evt.Payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for sync.degraded event")
+	}
+
+	// Repeated timeouts stay degraded without another event.
+	h.Handle(&events.KeepAliveTimeout{ErrorCount: 2})
+	h.Handle(&events.KeepAliveRestored{})
+	if m.Current() != status.Ready {
+		t.Errorf("state = %s, want READY after restore", m.Current())
+	}
+	select {
+	case evt := <-ch:
+		t.Errorf("unexpected second %s event", evt.Kind)
+	default:
+	}
+}
+
+func TestKeepAliveRestoredBeforeSyncCompleted(t *testing.T) {
+	b := bus.New()
+	m := status.NewMachine(b)
+	h := NewEventHandler(b, m, nil, zap.NewNop())
+
+	walkTo(t, m, status.Connecting)
+	h.Handle(&events.Connected{})
+	h.Handle(&events.KeepAliveTimeout{ErrorCount: 1})
+	h.Handle(&events.KeepAliveRestored{})
+	if m.Current() != status.Syncing {
+		t.Fatalf("state = %s, want SYNCING (sync not completed yet)", m.Current())
+	}
+
+	// Completion while degraded is remembered for the restore.
+	h.Handle(&events.KeepAliveTimeout{ErrorCount: 1})
+	h.Handle(&events.OfflineSyncCompleted{})
+	if m.Current() != status.Degraded {
+		t.Fatalf("state = %s, want DEGRADED", m.Current())
+	}
+	h.Handle(&events.KeepAliveRestored{})
+	if m.Current() != status.Ready {
+		t.Errorf("state = %s, want READY", m.Current())
+	}
+}
+
+func TestStreamErrorDegradesUntilReconnect(t *testing.T) {
+	b := bus.New()
+	m := status.NewMachine(b)
+	h := NewEventHandler(b, m, nil, zap.NewNop())
+
+	walkTo(t, m, status.Connecting, status.Syncing, status.Ready)
+	h.Handle(&events.StreamError{Code: "500"})
+	if m.Current() != status.Degraded {
+		t.Fatalf("state = %s, want DEGRADED", m.Current())
+	}
+
+	h.Handle(&events.Connected{})
+	if m.Current() != status.Syncing {
+		t.Errorf("state = %s, want SYNCING after reconnect", m.Current())
+	}
+}
+
+func TestStreamReplacedIsError(t *testing.T) {
+	b := bus.New()
+	m := status.NewMachine(b)
+	h := NewEventHandler(b, m, nil, zap.NewNop())
+
+	walkTo(t, m, status.Connecting, status.Syncing, status.Ready)
+	ch, unsub := b.Subscribe("sync.disconnected", 10)
+	defer unsub()
+
+	h.Handle(&events.StreamReplaced{})
+	if m.Current() != status.Error {
+		t.Errorf("state = %s, want ERROR", m.Current())
+	}
+	select {
+	case evt := <-ch:
+		if p, _ := evt.Payload.(map[string]string); p["reason"] != "replaced by another client" {
+			t.Errorf("payload = %v, want replaced reason", evt.Payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for sync.disconnected event")
+	}
+}
+
 func TestHandleMessageWhileReady(t *testing.T) {
 	b := bus.New()
 	m := status.NewMachine(b)
@@ -225,6 +422,9 @@ func TestHandleHistorySync(t *testing.T) {
 	msgTS := uint64(time.Now().Unix())
 	h.Handle(&events.HistorySync{
 		Data: &waHistorySync.HistorySync{
+			SyncType:   waHistorySync.HistorySync_INITIAL_BOOTSTRAP.Enum(),
+			ChunkOrder: proto.Uint32(2),
+			Progress:   proto.Uint32(35),
 			Conversations: []*waHistorySync.Conversation{
 				{
 					ID: proto.String("chat@g.us"),
@@ -249,7 +449,17 @@ func TestHandleHistorySync(t *testing.T) {
 	select {
 	case evt := <-ch:
 		if evt.Kind != "wa.history_batch" {
-			t.Errorf("event kind = %q, want wa.history_batch", evt.Kind)
+			t.Fatalf("event kind = %q, want wa.history_batch", evt.Kind)
+		}
+		batch, ok := evt.Payload.(*store.HistoryBatch)
+		if !ok {
+			t.Fatalf("payload type = %T, want *store.HistoryBatch", evt.Payload)
+		}
+		if batch.SyncType != "initial_bootstrap" || batch.ChunkOrder != 2 || batch.Progress != 35 || len(batch.Messages) != 1 {
+			t.Errorf("batch = %+v, want initial_bootstrap chunk 2 at 35%% with 1 message", batch)
+		}
+		if len(batch.Chats) != 1 || batch.Chats[0] != "chat@g.us" {
+			t.Errorf("batch chats = %v, want [chat@g.us]", batch.Chats)
 		}
 	case <-time.After(time.Second):
 		t.Fatal("timeout waiting for wa.history_batch event")
@@ -448,10 +658,11 @@ loop:
 	if batchEvt.Kind != "wa.history_batch" {
 		t.Fatal("did not receive wa.history_batch event")
 	}
-	msgs, ok := batchEvt.Payload.([]*store.Message)
-	if !ok || len(msgs) == 0 {
+	batch, ok := batchEvt.Payload.(*store.HistoryBatch)
+	if !ok || len(batch.Messages) == 0 {
 		t.Fatal("history batch has no messages")
 	}
+	msgs := batch.Messages
 	// Without adapter, LID stays as-is (NormalizeJID can't resolve it).
 	if msgs[0].ChatJID != "3917077286968@lid" {
 		t.Errorf("ChatJID = %q, want 3917077286968@lid (unresolved without adapter)", msgs[0].ChatJID)
@@ -477,6 +688,87 @@ loop:
 	}
 }
 
+// TestHistorySyncBatchesReactionsAndChanges verifies that a history chunk's
+// reactions and edits are published as one batch each, not one event apiece.
+func TestHistorySyncBatchesReactionsAndChanges(t *testing.T) {
+	b := bus.New()
+	m := status.NewMachine(b)
+	h := NewEventHandler(b, m, nil, zap.NewNop())
+
+	walkTo(t, m, status.Connecting, status.Syncing)
+
+	ch, unsub := b.Subscribe("wa.", 10)
+	defer unsub()
+
+	msgTS := uint64(time.Now().Unix())
+	reaction := func(sender, emoji string) *waWeb.Reaction {
+		return &waWeb.Reaction{
+			Key:  &waCommon.MessageKey{ID: proto.String("r-" + sender), Participant: proto.String(sender)},
+			Text: proto.String(emoji),
+		}
+	}
+	h.Handle(&events.HistorySync{
+		Data: &waHistorySync.HistorySync{
+			Conversations: []*waHistorySync.Conversation{
+				{
+					ID: proto.String("g@g.us"),
+					Messages: []*waHistorySync.HistorySyncMsg{
+						{
+							Message: &waWeb.WebMessageInfo{
+								Key:              &waCommon.MessageKey{ID: proto.String("hm1"), Participant: proto.String("alice@s.whatsapp.net")},
+								MessageTimestamp: &msgTS,
+								Message:          &waE2E.Message{Conversation: proto.String("helo")},
+								Reactions:        []*waWeb.Reaction{reaction("bob@s.whatsapp.net", "👍"), reaction("carol@s.whatsapp.net", "🎉")},
+							},
+						},
+						{
+							Message: &waWeb.WebMessageInfo{
+								Key:              &waCommon.MessageKey{ID: proto.String("hm2"), Participant: proto.String("alice@s.whatsapp.net")},
+								MessageTimestamp: &msgTS,
+								Message: &waE2E.Message{ProtocolMessage: &waE2E.ProtocolMessage{
+									Type:          waE2E.ProtocolMessage_MESSAGE_EDIT.Enum(),
+									Key:           &waCommon.MessageKey{ID: proto.String("hm1")},
+									EditedMessage: &waE2E.Message{Conversation: proto.String("hello")},
+								}},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	counts := make(map[string]int)
+	var reactions []*store.Reaction
+	var changes []*store.MessageChange
+	timeout := time.After(200 * time.Millisecond)
+loop:
+	for {
+		select {
+		case evt := <-ch:
+			counts[evt.Kind]++
+			switch p := evt.Payload.(type) {
+			case []*store.Reaction:
+				reactions = p
+			case []*store.MessageChange:
+				changes = p
+			}
+		case <-timeout:
+			break loop
+		}
+	}
+
+	if counts["wa.reaction_batch"] != 1 || counts["wa.reaction"] != 0 || len(reactions) != 2 {
+		t.Errorf("events = %v, reactions = %d, want one wa.reaction_batch of 2", counts, len(reactions))
+	}
+	if counts["wa.message_change_batch"] != 1 || counts["wa.message_change"] != 0 || len(changes) != 1 {
+		t.Fatalf("events = %v, changes = %d, want one wa.message_change_batch of 1", counts, len(changes))
+	}
+	if c := changes[0]; c.MsgID != "hm1" || c.Body != "hello" || c.SenderJID != "alice@s.whatsapp.net" {
+		t.Errorf("change = %+v, want edit of hm1 by alice", c)
+	}
+}
+
 // TestHistorySyncDeviceSuffixStripped verifies that history sync conversations
 // with device-suffix JIDs are normalized to plain JIDs.
 func TestHistorySyncDeviceSuffixStripped(t *testing.T) {
@@ -517,10 +809,11 @@ func TestHistorySyncDeviceSuffixStripped(t *testing.T) {
 
 	select {
 	case evt := <-ch:
-		msgs, ok := evt.Payload.([]*store.Message)
-		if !ok || len(msgs) == 0 {
+		batch, ok := evt.Payload.(*store.HistoryBatch)
+		if !ok || len(batch.Messages) == 0 {
 			t.Fatal("history batch has no messages")
 		}
+		msgs := batch.Messages
 		if msgs[0].ChatJID != "558592403672@s.whatsapp.net" {
 			t.Errorf("ChatJID = %q, want 558592403672@s.whatsapp.net (device suffix not stripped)", msgs[0].ChatJID)
 		}
@@ -563,3 +856,385 @@ func TestPushNameContactJIDNormalized(t *testing.T) {
 		t.Fatal("timeout waiting for wa.contact event")
 	}
 }
+
+func TestHandleReceipt(t *testing.T) {
+	b := bus.New()
+	m := status.NewMachine(b)
+	h := NewEventHandler(b, m, nil, zap.NewNop())
+
+	ch, unsub := b.Subscribe("wa.receipt", 10)
+	defer unsub()
+
+	chat := types.JID{User: "558592403672", Server: "s.whatsapp.net", Device: 2}
+	h.Handle(&events.Receipt{
+		MessageSource: types.MessageSource{Chat: chat, Sender: chat},
+		MessageIDs:    []types.MessageID{"A1", "A2"},
+		Timestamp:     time.UnixMilli(5000),
+		Type:          types.ReceiptTypeRead,
+	})
+
+	select {
+	case evt := <-ch:
+		r, ok := evt.Payload.(*store.Receipt)
+		if !ok {
+			t.Fatal("payload is not *store.Receipt")
+		}
+		if r.ChatJID != "558592403672@s.whatsapp.net" {
+			t.Errorf("ChatJID = %q, want device suffix stripped", r.ChatJID)
+		}
+		if r.Status != "read" || len(r.MsgIDs) != 2 || r.Timestamp != 5000 {
+			t.Errorf("receipt = %+v, want read for 2 ids at 5000", r)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for wa.receipt event")
+	}
+
+	// Receipts from our own devices and non-delivery types are not
+	// delivery receipts.
+	h.Handle(&events.Receipt{
+		MessageSource: types.MessageSource{Chat: chat, IsFromMe: true},
+		MessageIDs:    []types.MessageID{"A3"},
+		Type:          types.ReceiptTypeReadSelf,
+	})
+	h.Handle(&events.Receipt{
+		MessageSource: types.MessageSource{Chat: chat},
+		MessageIDs:    []types.MessageID{"A4"},
+		Type:          types.ReceiptTypeRetry,
+	})
+	select {
+	case evt := <-ch:
+		t.Errorf("unexpected event: %+v", evt.Payload)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestHandleMessageEditPublishesChange verifies that an edit arrives as a
+// change to the target message instead of a new "unknown" message row.
+func TestHandleMessageEditPublishesChange(t *testing.T) {
+	b := bus.New()
+	m := status.NewMachine(b)
+	h := NewEventHandler(b, m, nil, zap.NewNop())
+
+	ch, unsub := b.Subscribe("wa.", 10)
+	defer unsub()
+
+	editType := waE2E.ProtocolMessage_MESSAGE_EDIT
+	h.Handle(&events.Message{
+		Info: types.MessageInfo{
+			ID:        "edit1",
+			Timestamp: time.UnixMilli(9000),
+			MessageSource: types.MessageSource{
+				Chat:   types.JID{User: "c", Server: "s.whatsapp.net"},
+				Sender: types.JID{User: "c", Server: "s.whatsapp.net"},
+			},
+		},
+		IsEdit: true,
+		Message: &waE2E.Message{ProtocolMessage: &waE2E.ProtocolMessage{
+			Key:           &waCommon.MessageKey{ID: proto.String("orig")},
+			Type:          &editType,
+			EditedMessage: &waE2E.Message{Conversation: proto.String("corrected")},
+		}},
+	})
+
+	select {
+	case evt := <-ch:
+		if evt.Kind != "wa.message_change" {
+			t.Fatalf("event kind = %q, want wa.message_change", evt.Kind)
+		}
+		c, ok := evt.Payload.(*store.MessageChange)
+		if !ok {
+			t.Fatal("payload is not *store.MessageChange")
+		}
+		if c.ChatJID != "c@s.whatsapp.net" || c.MsgID != "orig" || c.Body != "corrected" || c.Timestamp != 9000 {
+			t.Errorf("change = %+v", c)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for wa.message_change event")
+	}
+
+	select {
+	case evt := <-ch:
+		t.Errorf("unexpected event %q: edits must not be stored as messages", evt.Kind)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestHandleMessageReactionPublishesReaction(t *testing.T) {
+	b := bus.New()
+	m := status.NewMachine(b)
+	h := NewEventHandler(b, m, nil, zap.NewNop())
+
+	ch, unsub := b.Subscribe("wa.", 10)
+	defer unsub()
+
+	h.Handle(&events.Message{
+		Info: types.MessageInfo{
+			ID:        "react1",
+			Timestamp: time.UnixMilli(9000),
+			MessageSource: types.MessageSource{
+				Chat:   types.JID{User: "g", Server: "g.us"},
+				Sender: types.JID{User: "bob", Server: "s.whatsapp.net", Device: 2},
+			},
+		},
+		Message: &waE2E.Message{ReactionMessage: &waE2E.ReactionMessage{
+			Key:               &waCommon.MessageKey{ID: proto.String("orig")},
+			Text:              proto.String("👍"),
+			SenderTimestampMS: proto.Int64(8500),
+		}},
+	})
+
+	select {
+	case evt := <-ch:
+		if evt.Kind != "wa.reaction" {
+			t.Fatalf("event kind = %q, want wa.reaction", evt.Kind)
+		}
+		r, ok := evt.Payload.(*store.Reaction)
+		if !ok {
+			t.Fatal("payload is not *store.Reaction")
+		}
+		if r.ChatJID != "g@g.us" || r.MsgID != "orig" || r.SenderJID != "bob@s.whatsapp.net" || r.Emoji != "👍" || r.Timestamp != 8500 {
+			t.Errorf("reaction = %+v", r)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for wa.reaction event")
+	}
+
+	select {
+	case evt := <-ch:
+		t.Errorf("unexpected event %q: reactions must not be stored as messages", evt.Kind)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestHandleChatReadState(t *testing.T) {
+	b := bus.New()
+	m := status.NewMachine(b)
+	h := NewEventHandler(b, m, nil, zap.NewNop())
+
+	ch, unsub := b.Subscribe("wa.chat_read", 10)
+	defer unsub()
+
+	chat := types.JID{User: "558592403672", Server: "s.whatsapp.net"}
+	h.Handle(&events.MarkChatAsRead{JID: chat, Action: &waSyncAction.MarkChatAsReadAction{Read: proto.Bool(false)}})
+	h.Handle(&events.Receipt{
+		MessageSource: types.MessageSource{Chat: chat, IsFromMe: true},
+		MessageIDs:    []types.MessageID{"A1"},
+		Type:          types.ReceiptTypeReadSelf,
+	})
+
+	want := []int{-1, 0}
+	for _, count := range want {
+		select {
+		case evt := <-ch:
+			state, ok := evt.Payload.(*store.ChatReadState)
+			if !ok {
+				t.Fatal("payload is not *store.ChatReadState")
+			}
+			if state.ChatJID != "558592403672@s.whatsapp.net" || state.UnreadCount != count {
+				t.Errorf("state = %+v, want unread %d", state, count)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for wa.chat_read event")
+		}
+	}
+}
+
+func TestHandleChatSettings(t *testing.T) {
+	b := bus.New()
+	m := status.NewMachine(b)
+	h := NewEventHandler(b, m, nil, zap.NewNop())
+
+	ch, unsub := b.Subscribe("wa.chat_settings", 10)
+	defer unsub()
+
+	chat := types.JID{User: "558592403672", Server: "s.whatsapp.net"}
+	pinnedAt := time.UnixMilli(1700000000000)
+	h.Handle(&events.Archive{JID: chat, Action: &waSyncAction.ArchiveChatAction{Archived: proto.Bool(true)}})
+	h.Handle(&events.Pin{JID: chat, Timestamp: pinnedAt, Action: &waSyncAction.PinAction{Pinned: proto.Bool(true)}})
+	h.Handle(&events.Pin{JID: chat, Timestamp: pinnedAt, Action: &waSyncAction.PinAction{Pinned: proto.Bool(false)}})
+	h.Handle(&events.Mute{JID: chat, Action: &waSyncAction.MuteAction{Muted: proto.Bool(true), MuteEndTimestamp: proto.Int64(1700000900000)}})
+	h.Handle(&events.Mute{JID: chat, Action: &waSyncAction.MuteAction{Muted: proto.Bool(true), MuteEndTimestamp: proto.Int64(-1)}})
+	h.Handle(&events.Mute{JID: chat, Action: &waSyncAction.MuteAction{Muted: proto.Bool(false)}})
+
+	is := func(p *int64, want int64) bool { return p != nil && *p == want }
+	tests := []struct {
+		want  string
+		check func(*store.ChatSettings) bool
+	}{
+		{"archived", func(s *store.ChatSettings) bool { return s.Archived != nil && *s.Archived }},
+		{"pinned at the action time", func(s *store.ChatSettings) bool { return is(s.PinnedAt, 1700000000000) }},
+		{"unpinned", func(s *store.ChatSettings) bool { return is(s.PinnedAt, 0) }},
+		{"muted until the end", func(s *store.ChatSettings) bool { return is(s.MutedUntil, 1700000900000) }},
+		{"muted indefinitely", func(s *store.ChatSettings) bool { return is(s.MutedUntil, -1) }},
+		{"unmuted", func(s *store.ChatSettings) bool { return is(s.MutedUntil, 0) }},
+	}
+	for _, tt := range tests {
+		select {
+		case evt := <-ch:
+			s, ok := evt.Payload.(*store.ChatSettings)
+			if !ok {
+				t.Fatal("payload is not *store.ChatSettings")
+			}
+			if s.ChatJID != "558592403672@s.whatsapp.net" || !tt.check(s) {
+				t.Errorf("settings = %+v, want %s", s, tt.want)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for wa.chat_settings event")
+		}
+	}
+}
+
+func TestHandleClearAndDeleteChat(t *testing.T) {
+	b := bus.New()
+	m := status.NewMachine(b)
+	h := NewEventHandler(b, m, nil, zap.NewNop())
+
+	ch, unsub := b.Subscribe("wa.chat_clear", 10)
+	defer unsub()
+
+	chat := types.JID{User: "558592403672", Server: "s.whatsapp.net"}
+	h.Handle(&events.ClearChat{JID: chat, Action: &waSyncAction.ClearChatAction{
+		MessageRange: &waSyncAction.SyncActionMessageRange{LastMessageTimestamp: proto.Int64(1700000000)},
+	}})
+	h.Handle(&events.DeleteChat{JID: chat, Action: &waSyncAction.DeleteChatAction{}})
+
+	want := []store.ChatClear{
+		{ChatJID: "558592403672@s.whatsapp.net", Before: 1700000000000},
+		{ChatJID: "558592403672@s.whatsapp.net", Delete: true},
+	}
+	for _, w := range want {
+		select {
+		case evt := <-ch:
+			c, ok := evt.Payload.(*store.ChatClear)
+			if !ok {
+				t.Fatal("payload is not *store.ChatClear")
+			}
+			if *c != w {
+				t.Errorf("clear = %+v, want %+v", *c, w)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for wa.chat_clear event")
+		}
+	}
+}
+
+func TestHistorySyncChatSettings(t *testing.T) {
+	b := bus.New()
+	m := status.NewMachine(b)
+	h := NewEventHandler(b, m, nil, zap.NewNop())
+
+	ch, unsub := b.Subscribe("wa.chat_settings", 10)
+	defer unsub()
+
+	h.Handle(&events.HistorySync{
+		Data: &waHistorySync.HistorySync{
+			SyncType: waHistorySync.HistorySync_INITIAL_BOOTSTRAP.Enum(),
+			Conversations: []*waHistorySync.Conversation{
+				{ID: proto.String("plain@s.whatsapp.net")},
+				{
+					ID:          proto.String("org@s.whatsapp.net"),
+					Archived:    proto.Bool(true),
+					Pinned:      proto.Uint32(1700000000),
+					MuteEndTime: proto.Uint64(1700000900),
+				},
+			},
+		},
+	})
+
+	select {
+	case evt := <-ch:
+		s := evt.Payload.(*store.ChatSettings)
+		if s.ChatJID != "org@s.whatsapp.net" || s.Archived == nil || !*s.Archived ||
+			s.PinnedAt == nil || *s.PinnedAt != 1700000000000 || s.MutedUntil == nil || *s.MutedUntil != 1700000900000 {
+			t.Errorf("settings = %+v, want org@s.whatsapp.net archived, pinned and muted in ms", s)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for wa.chat_settings event")
+	}
+	select {
+	case evt := <-ch:
+		t.Errorf("unexpected settings %+v for a conversation without any", evt.Payload)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestHandleGroupInfoPublishesChange(t *testing.T) {
+	b := bus.New()
+	m := status.NewMachine(b)
+	h := NewEventHandler(b, m, nil, zap.NewNop())
+
+	ch, unsub := b.Subscribe("wa.group_change", 10)
+	defer unsub()
+
+	group := types.JID{User: "120363", Server: types.GroupServer}
+	h.Handle(&events.GroupInfo{
+		JID:       group,
+		Timestamp: time.UnixMilli(5000),
+		Name:      &types.GroupName{Name: "Team"},
+		Join:      []types.JID{{User: "558592403672", Server: "s.whatsapp.net", Device: 3}},
+	})
+	// Notifications that change nothing we track are dropped.
+	h.Handle(&events.GroupInfo{JID: group, Locked: &types.GroupLocked{IsLocked: true}})
+
+	select {
+	case evt := <-ch:
+		c, ok := evt.Payload.(*store.GroupChange)
+		if !ok {
+			t.Fatal("payload is not *store.GroupChange")
+		}
+		if c.GroupJID != "120363@g.us" || c.Name == nil || *c.Name != "Team" || c.Timestamp != 5000 {
+			t.Errorf("change = %+v, want Team at 5000", c)
+		}
+		if len(c.Join) != 1 || c.Join[0] != "558592403672@s.whatsapp.net" {
+			t.Errorf("join = %v, want device suffix stripped", c.Join)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for wa.group_change event")
+	}
+	select {
+	case evt := <-ch:
+		t.Errorf("unexpected event: %+v", evt.Payload)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestHandlePresence(t *testing.T) {
+	b := bus.New()
+	m := status.NewMachine(b)
+	h := NewEventHandler(b, m, nil, zap.NewNop())
+
+	ch, unsub := b.Subscribe("presence.", 10)
+	defer unsub()
+
+	chat := types.JID{User: "123456789", Server: types.GroupServer}
+	alice := types.JID{User: "558592403672", Server: types.DefaultUserServer, Device: 3}
+	h.Handle(&events.ChatPresence{
+		MessageSource: types.MessageSource{Chat: chat, Sender: alice, IsFromMe: true},
+		State:         types.ChatPresenceComposing,
+	}) // ours: ignored
+	h.Handle(&events.ChatPresence{
+		MessageSource: types.MessageSource{Chat: chat, Sender: alice},
+		State:         types.ChatPresenceComposing,
+		Media:         types.ChatPresenceMediaAudio,
+	})
+	h.Handle(&events.Presence{From: alice, Unavailable: true, LastSeen: time.UnixMilli(1700000000000)})
+
+	want := []struct {
+		kind    string
+		payload map[string]string
+	}{
+		{"presence.chat_state", map[string]string{"chat_jid": "123456789@g.us", "sender_jid": "558592403672@s.whatsapp.net", "state": "recording"}},
+		{"presence.updated", map[string]string{"chat_jid": "558592403672@s.whatsapp.net", "online": "false", "last_seen_at": "1700000000000"}},
+	}
+	for _, w := range want {
+		select {
+		case evt := <-ch:
+			p, _ := evt.Payload.(map[string]string)
+			if evt.Kind != w.kind || !maps.Equal(p, w.payload) {
+				t.Errorf("event = %s %v, want %s %v", evt.Kind, p, w.kind, w.payload)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for %s event", w.kind)
+		}
+	}
+}
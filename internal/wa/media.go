@@ -0,0 +1,87 @@
+package wa
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/matheus3301/wpp/internal/store"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"google.golang.org/protobuf/proto"
+)
+
+// Uploader encrypts an attachment and uploads it to WhatsApp's media
+// servers, streaming the file through tempFile, or a temporary file when
+// nil. It is satisfied by *whatsmeow.Client.
+type Uploader interface {
+	UploadReader(ctx context.Context, plaintext io.Reader, tempFile io.ReadWriteSeeker, appInfo whatsmeow.MediaType) (whatsmeow.UploadResponse, error)
+}
+
+// uploadMedia uploads r, the file of m, and returns the message that
+// carries it, quoting with ctxInfo when it is not nil. m's key material is
+// filled in from the upload so the attachment can be downloaded again like
+// a received one.
+func uploadMedia(ctx context.Context, up Uploader, m *store.Media, r io.Reader, ctxInfo *waE2E.ContextInfo) (*waE2E.Message, error) {
+	var appInfo whatsmeow.MediaType
+	switch m.MediaType {
+	case "image", "sticker":
+		appInfo = whatsmeow.MediaImage
+	case "video":
+		appInfo = whatsmeow.MediaVideo
+	case "audio":
+		appInfo = whatsmeow.MediaAudio
+	case "document":
+		appInfo = whatsmeow.MediaDocument
+	default:
+		return nil, fmt.Errorf("unknown media type %q", m.MediaType)
+	}
+
+	resp, err := up.UploadReader(ctx, r, nil, appInfo)
+	if err != nil {
+		return nil, fmt.Errorf("upload media: %w", err)
+	}
+	m.DirectPath = resp.DirectPath
+	m.MediaKey = resp.MediaKey
+	m.FileSHA256 = resp.FileSHA256
+	m.FileEncSHA256 = resp.FileEncSHA256
+	m.FileLength = int64(resp.FileLength)
+
+	var caption *string
+	if m.Caption != "" {
+		caption = proto.String(m.Caption)
+	}
+	switch m.MediaType {
+	case "image":
+		return &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
+			URL: proto.String(resp.URL), DirectPath: proto.String(resp.DirectPath), MediaKey: resp.MediaKey,
+			FileEncSHA256: resp.FileEncSHA256, FileSHA256: resp.FileSHA256, FileLength: proto.Uint64(resp.FileLength),
+			Mimetype: proto.String(m.Mimetype), Caption: caption, JPEGThumbnail: m.Thumbnail, ContextInfo: ctxInfo,
+		}}, nil
+	case "sticker":
+		return &waE2E.Message{StickerMessage: &waE2E.StickerMessage{
+			URL: proto.String(resp.URL), DirectPath: proto.String(resp.DirectPath), MediaKey: resp.MediaKey,
+			FileEncSHA256: resp.FileEncSHA256, FileSHA256: resp.FileSHA256, FileLength: proto.Uint64(resp.FileLength),
+			Mimetype: proto.String(m.Mimetype), ContextInfo: ctxInfo,
+		}}, nil
+	case "video":
+		return &waE2E.Message{VideoMessage: &waE2E.VideoMessage{
+			URL: proto.String(resp.URL), DirectPath: proto.String(resp.DirectPath), MediaKey: resp.MediaKey,
+			FileEncSHA256: resp.FileEncSHA256, FileSHA256: resp.FileSHA256, FileLength: proto.Uint64(resp.FileLength),
+			Mimetype: proto.String(m.Mimetype), Caption: caption, JPEGThumbnail: m.Thumbnail, ContextInfo: ctxInfo,
+		}}, nil
+	case "audio":
+		return &waE2E.Message{AudioMessage: &waE2E.AudioMessage{
+			URL: proto.String(resp.URL), DirectPath: proto.String(resp.DirectPath), MediaKey: resp.MediaKey,
+			FileEncSHA256: resp.FileEncSHA256, FileSHA256: resp.FileSHA256, FileLength: proto.Uint64(resp.FileLength),
+			Mimetype: proto.String(m.Mimetype), PTT: proto.Bool(m.Voice), ContextInfo: ctxInfo,
+		}}, nil
+	default: // document
+		return &waE2E.Message{DocumentMessage: &waE2E.DocumentMessage{
+			URL: proto.String(resp.URL), DirectPath: proto.String(resp.DirectPath), MediaKey: resp.MediaKey,
+			FileEncSHA256: resp.FileEncSHA256, FileSHA256: resp.FileSHA256, FileLength: proto.Uint64(resp.FileLength),
+			Mimetype: proto.String(m.Mimetype), FileName: proto.String(m.FileName), Title: proto.String(m.FileName),
+			Caption: caption, JPEGThumbnail: m.Thumbnail, ContextInfo: ctxInfo,
+		}}, nil
+	}
+}
@@ -0,0 +1,92 @@
+package wa
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/matheus3301/wpp/internal/store"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeUploader returns a fixed upload and records what it was given.
+type fakeUploader struct {
+	err     error
+	data    []byte
+	appInfo whatsmeow.MediaType
+}
+
+func (f *fakeUploader) UploadReader(_ context.Context, plaintext io.Reader, _ io.ReadWriteSeeker, appInfo whatsmeow.MediaType) (whatsmeow.UploadResponse, error) {
+	if f.err != nil {
+		return whatsmeow.UploadResponse{}, f.err
+	}
+	data, err := io.ReadAll(plaintext)
+	if err != nil {
+		return whatsmeow.UploadResponse{}, err
+	}
+	f.data, f.appInfo = data, appInfo
+	return whatsmeow.UploadResponse{
+		URL: "https://mmg.whatsapp.net/v/x", DirectPath: "/v/x",
+		MediaKey: []byte("key"), FileSHA256: []byte("sha"), FileEncSHA256: []byte("enc"),
+		FileLength: uint64(len(f.data)),
+	}, nil
+}
+
+func TestUploadMedia(t *testing.T) {
+	data := []byte("file contents")
+	quote := &waE2E.ContextInfo{StanzaID: proto.String("m1")}
+
+	up := &fakeUploader{}
+	m := &store.Media{MediaType: "image", Mimetype: "image/jpeg", Caption: "sunset", Thumbnail: []byte("thumb")}
+	msg, err := uploadMedia(context.Background(), up, m, bytes.NewReader(data), quote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if up.appInfo != whatsmeow.MediaImage || !bytes.Equal(up.data, data) {
+		t.Errorf("uploaded %q as %q, want the file as an image", up.data, up.appInfo)
+	}
+	im := msg.GetImageMessage()
+	if im.GetDirectPath() != "/v/x" || im.GetURL() == "" || string(im.GetMediaKey()) != "key" ||
+		im.GetCaption() != "sunset" || string(im.GetJPEGThumbnail()) != "thumb" ||
+		im.GetFileLength() != uint64(len(data)) || im.GetContextInfo().GetStanzaID() != "m1" {
+		t.Errorf("image message = %v", im)
+	}
+	if m.DirectPath != "/v/x" || string(m.FileEncSHA256) != "enc" || m.FileLength != int64(len(data)) {
+		t.Errorf("media after upload = %+v, want the upload's key material", m)
+	}
+
+	m = &store.Media{MediaType: "audio", Mimetype: "audio/ogg; codecs=opus", Voice: true}
+	msg, err = uploadMedia(context.Background(), up, m, bytes.NewReader(data), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if up.appInfo != whatsmeow.MediaAudio || !msg.GetAudioMessage().GetPTT() {
+		t.Errorf("voice note = %v uploaded as %q, want a PTT audio message", msg, up.appInfo)
+	}
+
+	m = &store.Media{MediaType: "document", Mimetype: "application/pdf", FileName: "report.pdf"}
+	msg, err = uploadMedia(context.Background(), up, m, bytes.NewReader(data), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dm := msg.GetDocumentMessage(); dm.GetFileName() != "report.pdf" || dm.Caption != nil || up.appInfo != whatsmeow.MediaDocument {
+		t.Errorf("document message = %v", dm)
+	}
+
+	m = &store.Media{MediaType: "sticker", Mimetype: "image/webp"}
+	if msg, err = uploadMedia(context.Background(), up, m, bytes.NewReader(data), nil); err != nil || msg.GetStickerMessage() == nil {
+		t.Errorf("sticker = %v, %v", msg, err)
+	}
+
+	if _, err := uploadMedia(context.Background(), up, &store.Media{MediaType: "gif"}, bytes.NewReader(data), nil); err == nil {
+		t.Error("unknown media type uploaded, want error")
+	}
+	up.err = errors.New("network down")
+	if _, err := uploadMedia(context.Background(), up, &store.Media{MediaType: "video"}, bytes.NewReader(data), nil); !errors.Is(err, up.err) {
+		t.Errorf("failed upload = %v, want %v", err, up.err)
+	}
+}
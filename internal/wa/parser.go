@@ -17,12 +17,20 @@ type ParsedMessage struct {
 	MessageType string
 	FromMe      bool
 	Timestamp   int64
+
+	QuotedMsgID  string
+	QuotedSender string
+	QuotedBody   string
+
+	Media *store.Media // nil unless the message has an attachment
 }
 
 // ParseLiveMessage normalizes a live whatsmeow message event.
 func ParseLiveMessage(evt *events.Message) *ParsedMessage {
 	body := extractTextBody(evt.Message)
 	msgType := detectMessageType(evt.Message)
+	ci := contextInfo(evt.Message)
+	media := parseMedia(evt.Message)
 
 	return &ParsedMessage{
 		ChatJID:     evt.Info.Chat.ToNonAD().String(),
@@ -33,6 +41,11 @@ func ParseLiveMessage(evt *events.Message) *ParsedMessage {
 		MessageType: msgType,
 		FromMe:      evt.Info.IsFromMe,
 		Timestamp:   evt.Info.Timestamp.UnixMilli(),
+
+		QuotedMsgID:  ci.GetStanzaID(),
+		QuotedSender: quotedSender(ci),
+		QuotedBody:   quotedBody(ci),
+		Media:        media,
 	}
 }
 
@@ -40,6 +53,8 @@ func ParseLiveMessage(evt *events.Message) *ParsedMessage {
 func ParseHistoryMessage(msg *waE2E.Message, info types.MessageInfo) *ParsedMessage {
 	body := extractTextBody(msg)
 	msgType := detectMessageType(msg)
+	ci := contextInfo(msg)
+	media := parseMedia(msg)
 
 	return &ParsedMessage{
 		ChatJID:     info.Chat.ToNonAD().String(),
@@ -50,12 +65,18 @@ func ParseHistoryMessage(msg *waE2E.Message, info types.MessageInfo) *ParsedMess
 		MessageType: msgType,
 		FromMe:      info.IsFromMe,
 		Timestamp:   info.Timestamp.UnixMilli(),
+
+		QuotedMsgID:  ci.GetStanzaID(),
+		QuotedSender: quotedSender(ci),
+		QuotedBody:   quotedBody(ci),
+		Media:        media,
 	}
 }
 
-// ToStoreMessage converts a ParsedMessage to a store.Message.
+// ToStoreMessage converts a ParsedMessage to a store.Message. Our own
+// messages seen on the wire were sent; receipts move them further.
 func (p *ParsedMessage) ToStoreMessage() *store.Message {
-	return &store.Message{
+	m := &store.Message{
 		ChatJID:     p.ChatJID,
 		MsgID:       p.MsgID,
 		SenderJID:   p.SenderJID,
@@ -65,7 +86,21 @@ func (p *ParsedMessage) ToStoreMessage() *store.Message {
 		FromMe:      p.FromMe,
 		Status:      "received",
 		Timestamp:   p.Timestamp,
+
+		QuotedMsgID:  p.QuotedMsgID,
+		QuotedSender: p.QuotedSender,
+		QuotedBody:   p.QuotedBody,
+	}
+	if p.Media != nil {
+		media := *p.Media
+		media.ChatJID = p.ChatJID
+		media.MsgID = p.MsgID
+		m.Media = &media
+	}
+	if p.FromMe {
+		m.Status = "sent"
 	}
+	return m
 }
 
 // NormalizeJID strips the device/agent suffix from a JID string.
@@ -88,9 +123,104 @@ func extractTextBody(msg *waE2E.Message) string {
 	if ext := msg.GetExtendedTextMessage(); ext != nil {
 		return ext.GetText()
 	}
+	// Media captions are the text of media messages.
+	switch {
+	case msg.GetImageMessage() != nil:
+		return msg.GetImageMessage().GetCaption()
+	case msg.GetVideoMessage() != nil:
+		return msg.GetVideoMessage().GetCaption()
+	case msg.GetDocumentMessage() != nil:
+		return msg.GetDocumentMessage().GetCaption()
+	}
 	return ""
 }
 
+// parseMedia extracts the attachment metadata of a media message. The
+// returned media has no ChatJID or MsgID; ToStoreMessage fills them.
+// Returns nil for messages without an attachment.
+func parseMedia(msg *waE2E.Message) *store.Media {
+	switch {
+	case msg.GetImageMessage() != nil:
+		im := msg.GetImageMessage()
+		return &store.Media{
+			MediaType: "image", Mimetype: im.GetMimetype(), FileLength: int64(im.GetFileLength()),
+			Caption: im.GetCaption(), DirectPath: im.GetDirectPath(), MediaKey: im.GetMediaKey(),
+			FileSHA256: im.GetFileSHA256(), FileEncSHA256: im.GetFileEncSHA256(), Thumbnail: im.GetJPEGThumbnail(),
+		}
+	case msg.GetVideoMessage() != nil:
+		vm := msg.GetVideoMessage()
+		return &store.Media{
+			MediaType: "video", Mimetype: vm.GetMimetype(), FileLength: int64(vm.GetFileLength()),
+			Caption: vm.GetCaption(), DirectPath: vm.GetDirectPath(), MediaKey: vm.GetMediaKey(),
+			FileSHA256: vm.GetFileSHA256(), FileEncSHA256: vm.GetFileEncSHA256(), Thumbnail: vm.GetJPEGThumbnail(),
+		}
+	case msg.GetAudioMessage() != nil:
+		am := msg.GetAudioMessage()
+		return &store.Media{
+			MediaType: "audio", Mimetype: am.GetMimetype(), FileLength: int64(am.GetFileLength()),
+			DirectPath: am.GetDirectPath(), MediaKey: am.GetMediaKey(),
+			FileSHA256: am.GetFileSHA256(), FileEncSHA256: am.GetFileEncSHA256(), Voice: am.GetPTT(),
+		}
+	case msg.GetDocumentMessage() != nil:
+		dm := msg.GetDocumentMessage()
+		return &store.Media{
+			MediaType: "document", Mimetype: dm.GetMimetype(), FileLength: int64(dm.GetFileLength()),
+			FileName: dm.GetFileName(), Caption: dm.GetCaption(), DirectPath: dm.GetDirectPath(), MediaKey: dm.GetMediaKey(),
+			FileSHA256: dm.GetFileSHA256(), FileEncSHA256: dm.GetFileEncSHA256(), Thumbnail: dm.GetJPEGThumbnail(),
+		}
+	case msg.GetStickerMessage() != nil:
+		sm := msg.GetStickerMessage()
+		return &store.Media{
+			MediaType: "sticker", Mimetype: sm.GetMimetype(), FileLength: int64(sm.GetFileLength()),
+			DirectPath: sm.GetDirectPath(), MediaKey: sm.GetMediaKey(),
+			FileSHA256: sm.GetFileSHA256(), FileEncSHA256: sm.GetFileEncSHA256(), Thumbnail: sm.GetPngThumbnail(),
+		}
+	default:
+		return nil
+	}
+}
+
+// contextInfo returns the ContextInfo of the message content that can
+// quote another message, or nil.
+func contextInfo(msg *waE2E.Message) *waE2E.ContextInfo {
+	switch {
+	case msg == nil:
+		return nil
+	case msg.GetExtendedTextMessage() != nil:
+		return msg.GetExtendedTextMessage().GetContextInfo()
+	case msg.GetImageMessage() != nil:
+		return msg.GetImageMessage().GetContextInfo()
+	case msg.GetVideoMessage() != nil:
+		return msg.GetVideoMessage().GetContextInfo()
+	case msg.GetAudioMessage() != nil:
+		return msg.GetAudioMessage().GetContextInfo()
+	case msg.GetDocumentMessage() != nil:
+		return msg.GetDocumentMessage().GetContextInfo()
+	case msg.GetStickerMessage() != nil:
+		return msg.GetStickerMessage().GetContextInfo()
+	default:
+		return nil
+	}
+}
+
+// quotedSender returns the normalized JID of the quoted message's sender,
+// or "" when ci does not quote a message.
+func quotedSender(ci *waE2E.ContextInfo) string {
+	if ci.GetStanzaID() == "" || ci.GetParticipant() == "" {
+		return ""
+	}
+	return NormalizeJID(ci.GetParticipant())
+}
+
+// quotedBody returns the text a reply carries of the message it quotes, or
+// "" when ci does not quote a message.
+func quotedBody(ci *waE2E.ContextInfo) string {
+	if ci.GetStanzaID() == "" {
+		return ""
+	}
+	return extractTextBody(ci.GetQuotedMessage())
+}
+
 func detectMessageType(msg *waE2E.Message) string {
 	if msg == nil {
 		return "unknown"
@@ -116,3 +246,62 @@ func detectMessageType(msg *waE2E.Message) string {
 		return "unknown"
 	}
 }
+
+// parseMessageChange extracts an edit or revoke from a protocol message.
+// The returned change has no ChatJID or sender; callers fill them from the
+// message envelope. Timestamp is zero when the protocol message carries none.
+// Returns nil for anything that is not an edit or revoke.
+func parseMessageChange(msg *waE2E.Message) *store.MessageChange {
+	pm := msg.GetProtocolMessage()
+	if pm == nil || pm.GetKey().GetID() == "" {
+		return nil
+	}
+	switch pm.GetType() {
+	case waE2E.ProtocolMessage_MESSAGE_EDIT:
+		return &store.MessageChange{
+			MsgID:     pm.GetKey().GetID(),
+			Kind:      "edit",
+			Body:      extractTextBody(pm.GetEditedMessage()),
+			Timestamp: pm.GetTimestampMS(),
+		}
+	case waE2E.ProtocolMessage_REVOKE:
+		return &store.MessageChange{
+			MsgID:     pm.GetKey().GetID(),
+			Kind:      "revoke",
+			Timestamp: pm.GetTimestampMS(),
+		}
+	default:
+		return nil
+	}
+}
+
+// parseReaction extracts a reaction from a message. The returned reaction
+// has no ChatJID or sender; callers fill them from the message envelope.
+// Returns nil when the message is not a reaction.
+func parseReaction(msg *waE2E.Message) *store.Reaction {
+	rm := msg.GetReactionMessage()
+	if rm == nil || rm.GetKey().GetID() == "" {
+		return nil
+	}
+	return &store.Reaction{
+		MsgID:     rm.GetKey().GetID(),
+		Emoji:     rm.GetText(),
+		Timestamp: rm.GetSenderTimestampMS(),
+	}
+}
+
+// receiptStatus maps a WhatsApp receipt type to a message status.
+// Returns "" for receipt types that do not describe delivery state
+// (retries, server errors, receipts sent by our own devices, etc.).
+func receiptStatus(t types.ReceiptType) string {
+	switch t {
+	case types.ReceiptTypeDelivered:
+		return "delivered"
+	case types.ReceiptTypeRead:
+		return "read"
+	case types.ReceiptTypePlayed:
+		return "played"
+	default:
+		return ""
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"go.mau.fi/whatsmeow/proto/waCommon"
 	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
@@ -20,6 +21,8 @@ func TestExtractTextBody(t *testing.T) {
 		{"conversation", &waE2E.Message{Conversation: proto.String("hello")}, "hello"},
 		{"extended text", &waE2E.Message{ExtendedTextMessage: &waE2E.ExtendedTextMessage{Text: proto.String("extended")}}, "extended"},
 		{"image (no text)", &waE2E.Message{ImageMessage: &waE2E.ImageMessage{}}, ""},
+		{"image caption", &waE2E.Message{ImageMessage: &waE2E.ImageMessage{Caption: proto.String("sunset")}}, "sunset"},
+		{"document caption", &waE2E.Message{DocumentMessage: &waE2E.DocumentMessage{Caption: proto.String("notes"), FileName: proto.String("a.pdf")}}, "notes"},
 		{"empty conversation", &waE2E.Message{Conversation: proto.String("")}, ""},
 	}
 
@@ -129,6 +132,11 @@ func TestToStoreMessage(t *testing.T) {
 	if sm.FromMe {
 		t.Error("FromMe should be false")
 	}
+
+	p.FromMe = true
+	if sm := p.ToStoreMessage(); sm.Status != "sent" {
+		t.Errorf("own message Status = %q, want sent", sm.Status)
+	}
 }
 
 // TestNormalizeJID verifies that device/agent suffixes are stripped.
@@ -206,3 +214,119 @@ func TestParseLiveMessageImageType(t *testing.T) {
 		t.Errorf("Body = %q, want empty for image", parsed.Body)
 	}
 }
+
+func TestParseMessageChange(t *testing.T) {
+	key := &waCommon.MessageKey{ID: proto.String("target")}
+	edit := waE2E.ProtocolMessage_MESSAGE_EDIT
+	revoke := waE2E.ProtocolMessage_REVOKE
+	other := waE2E.ProtocolMessage_EPHEMERAL_SETTING
+
+	tests := []struct {
+		name     string
+		msg      *waE2E.Message
+		wantKind string
+		wantBody string
+	}{
+		{"plain text", &waE2E.Message{Conversation: proto.String("hi")}, "", ""},
+		{"edit", &waE2E.Message{ProtocolMessage: &waE2E.ProtocolMessage{
+			Key: key, Type: &edit, TimestampMS: proto.Int64(42),
+			EditedMessage: &waE2E.Message{Conversation: proto.String("fixed")},
+		}}, "edit", "fixed"},
+		{"revoke", &waE2E.Message{ProtocolMessage: &waE2E.ProtocolMessage{Key: key, Type: &revoke}}, "revoke", ""},
+		{"other protocol", &waE2E.Message{ProtocolMessage: &waE2E.ProtocolMessage{Key: key, Type: &other}}, "", ""},
+		{"missing key", &waE2E.Message{ProtocolMessage: &waE2E.ProtocolMessage{Type: &revoke}}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseMessageChange(tt.msg)
+			if tt.wantKind == "" {
+				if got != nil {
+					t.Errorf("parseMessageChange() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("parseMessageChange() = nil")
+			}
+			if got.Kind != tt.wantKind || got.Body != tt.wantBody || got.MsgID != "target" {
+				t.Errorf("parseMessageChange() = %+v, want %s of target with body %q", got, tt.wantKind, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestParseLiveMessageQuotedReply(t *testing.T) {
+	evt := &events.Message{
+		Info: types.MessageInfo{
+			ID:        "R1",
+			Timestamp: time.Now(),
+			MessageSource: types.MessageSource{
+				Chat:   types.JID{User: "g", Server: "g.us"},
+				Sender: types.JID{User: "alice", Server: "s.whatsapp.net"},
+			},
+		},
+		Message: &waE2E.Message{ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+			Text: proto.String("agreed"),
+			ContextInfo: &waE2E.ContextInfo{
+				StanzaID:      proto.String("ORIG"),
+				Participant:   proto.String("bob:3@s.whatsapp.net"),
+				QuotedMessage: &waE2E.Message{Conversation: proto.String("lunch?")},
+			},
+		}},
+	}
+
+	sm := ParseLiveMessage(evt).ToStoreMessage()
+	if sm.Body != "agreed" || sm.QuotedMsgID != "ORIG" || sm.QuotedSender != "bob@s.whatsapp.net" || sm.QuotedBody != "lunch?" {
+		t.Errorf("got body=%q quoted=%q/%q/%q, want agreed quoting lunch? (ORIG) by bob@s.whatsapp.net", sm.Body, sm.QuotedMsgID, sm.QuotedSender, sm.QuotedBody)
+	}
+
+	// Context info without a quoted message (e.g. a forward) is not a reply.
+	evt.Message.ExtendedTextMessage.ContextInfo = &waE2E.ContextInfo{IsForwarded: proto.Bool(true)}
+	if p := ParseLiveMessage(evt); p.QuotedMsgID != "" || p.QuotedSender != "" {
+		t.Errorf("forward parsed as reply: %+v", p)
+	}
+}
+
+func TestParseMedia(t *testing.T) {
+	msg := &waE2E.Message{DocumentMessage: &waE2E.DocumentMessage{
+		Mimetype:      proto.String("application/pdf"),
+		FileLength:    proto.Uint64(4096),
+		FileName:      proto.String("report.pdf"),
+		DirectPath:    proto.String("/v/t62/xyz"),
+		MediaKey:      []byte{9, 9},
+		FileSHA256:    []byte{1},
+		FileEncSHA256: []byte{2},
+	}}
+	evt := &events.Message{
+		Info: types.MessageInfo{
+			ID:        "DOC1",
+			Timestamp: time.Now(),
+			MessageSource: types.MessageSource{
+				Chat:   types.JID{User: "c", Server: "s.whatsapp.net"},
+				Sender: types.JID{User: "c", Server: "s.whatsapp.net"},
+			},
+		},
+		Message: msg,
+	}
+
+	sm := ParseLiveMessage(evt).ToStoreMessage()
+	m := sm.Media
+	if m == nil {
+		t.Fatal("Media = nil for a document message")
+	}
+	if m.ChatJID != "c@s.whatsapp.net" || m.MsgID != "DOC1" || m.MediaType != "document" ||
+		m.Mimetype != "application/pdf" || m.FileLength != 4096 || m.FileName != "report.pdf" ||
+		m.DirectPath != "/v/t62/xyz" || len(m.MediaKey) != 2 {
+		t.Errorf("Media = %+v", m)
+	}
+
+	voice := parseMedia(&waE2E.Message{AudioMessage: &waE2E.AudioMessage{PTT: proto.Bool(true)}})
+	if voice == nil || voice.MediaType != "audio" || !voice.Voice {
+		t.Errorf("parseMedia(voice note) = %+v, want voice audio", voice)
+	}
+
+	if parseMedia(&waE2E.Message{Conversation: proto.String("hi")}) != nil {
+		t.Error("parseMedia(text) != nil")
+	}
+}
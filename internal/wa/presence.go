@@ -0,0 +1,130 @@
+package wa
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+	"go.uber.org/zap"
+)
+
+// presenceWatch counts the clients watching presence. We show as online,
+// which WhatsApp requires before it sends any presence, only while at
+// least one client watches: being online also silences notifications on
+// the phone.
+type presenceWatch struct {
+	mu       sync.Mutex
+	watchers int
+	subs     map[string]int // contact JID -> watchers of its presence
+}
+
+// WatchPresence marks us online and, unless jid is empty or a group,
+// subscribes to the presence of that contact, until release is called.
+// Both are best-effort: failures are logged, and they are redone by
+// restorePresence after a reconnect.
+func (a *Adapter) WatchPresence(ctx context.Context, jid string) (release func()) {
+	contact := jid
+	if parsed, err := types.ParseJID(jid); err != nil || parsed.Server != types.DefaultUserServer {
+		contact = ""
+	}
+
+	a.presence.mu.Lock()
+	a.presence.watchers++
+	first := a.presence.watchers == 1
+	if contact != "" {
+		if a.presence.subs == nil {
+			a.presence.subs = make(map[string]int)
+		}
+		a.presence.subs[contact]++
+	}
+	a.presence.mu.Unlock()
+
+	if first {
+		a.sendPresence(ctx, types.PresenceAvailable)
+	}
+	if contact != "" {
+		a.subscribePresence(ctx, contact)
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			a.presence.mu.Lock()
+			a.presence.watchers--
+			last := a.presence.watchers == 0
+			if contact != "" {
+				if a.presence.subs[contact]--; a.presence.subs[contact] == 0 {
+					delete(a.presence.subs, contact)
+				}
+			}
+			a.presence.mu.Unlock()
+
+			if last {
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				a.sendPresence(ctx, types.PresenceUnavailable)
+			}
+		})
+	}
+}
+
+// restorePresence goes online again and renews the presence
+// subscriptions, which do not survive a reconnect, if anyone watches.
+func (a *Adapter) restorePresence(ctx context.Context) {
+	a.presence.mu.Lock()
+	watched := a.presence.watchers > 0
+	var contacts []string
+	for jid := range a.presence.subs {
+		contacts = append(contacts, jid)
+	}
+	a.presence.mu.Unlock()
+
+	if !watched {
+		return
+	}
+	a.sendPresence(ctx, types.PresenceAvailable)
+	for _, jid := range contacts {
+		a.subscribePresence(ctx, jid)
+	}
+}
+
+func (a *Adapter) sendPresence(ctx context.Context, state types.Presence) {
+	if err := a.client.SendPresence(ctx, state); err != nil {
+		a.logger.Warn("failed to send presence", zap.Error(err), zap.String("state", string(state)))
+	}
+}
+
+func (a *Adapter) subscribePresence(ctx context.Context, jid string) {
+	parsed, err := types.ParseJID(jid)
+	if err == nil {
+		err = a.client.SubscribePresence(ctx, parsed)
+	}
+	if err != nil {
+		a.logger.Warn("failed to subscribe to presence", zap.Error(err), zap.String("jid", jid))
+	}
+}
+
+// SendChatPresence tells a chat whether we are typing: state is composing,
+// recording (a voice note) or paused.
+func (a *Adapter) SendChatPresence(ctx context.Context, chatJID, state string) error {
+	chat, err := types.ParseJID(chatJID)
+	if err != nil {
+		return fmt.Errorf("parse JID: %w", err)
+	}
+	presence, media := types.ChatPresenceComposing, types.ChatPresenceMediaText
+	switch state {
+	case "composing":
+	case "recording":
+		media = types.ChatPresenceMediaAudio
+	case "paused":
+		presence = types.ChatPresencePaused
+	default:
+		return fmt.Errorf("unknown chat presence %q", state)
+	}
+	if err := a.client.SendChatPresence(ctx, chat, presence, media); err != nil {
+		return fmt.Errorf("send chat presence: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package wa
+
+import (
+	"sync"
+	"time"
+
+	"github.com/matheus3301/wpp/internal/bus"
+	"github.com/matheus3301/wpp/internal/status"
+	"go.uber.org/zap"
+)
+
+// syncTimeout bounds how long a connection stays SYNCING when WhatsApp
+// never reports the offline sync as completed.
+const syncTimeout = time.Minute
+
+// readiness tracks whether the current connection has caught up with
+// what was sent to us while offline, which moves SYNCING to READY.
+type readiness struct {
+	mu      sync.Mutex
+	synced  bool
+	conn    int // bumped on every connect and disconnect
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+// startSync begins catching up on a new connection. If the offline sync
+// does not complete within the timeout we assume READY anyway, so a quiet
+// account or a lost completion event cannot hold the outbox back.
+func (h *EventHandler) startSync() {
+	h.ready.mu.Lock()
+	defer h.ready.mu.Unlock()
+	h.ready.synced = false
+	h.ready.conn++
+	conn := h.ready.conn
+	if h.ready.timer != nil {
+		h.ready.timer.Stop()
+	}
+	h.ready.timer = time.AfterFunc(h.ready.timeout, func() {
+		if h.completeSync(conn) {
+			h.logger.Warn("offline sync did not complete in time, assuming ready",
+				zap.Duration("timeout", h.ready.timeout))
+		}
+	})
+}
+
+// stopSync forgets the sync of a connection that went away.
+func (h *EventHandler) stopSync() {
+	h.ready.mu.Lock()
+	defer h.ready.mu.Unlock()
+	h.ready.synced = false
+	h.ready.conn++
+	if h.ready.timer != nil {
+		h.ready.timer.Stop()
+		h.ready.timer = nil
+	}
+}
+
+// syncCompleted records that the current connection has caught up.
+func (h *EventHandler) syncCompleted() {
+	h.ready.mu.Lock()
+	conn := h.ready.conn
+	h.ready.mu.Unlock()
+	h.completeSync(conn)
+}
+
+// completeSync marks connection conn as synced and moves SYNCING to
+// READY. It reports false if conn is gone or was already synced.
+func (h *EventHandler) completeSync(conn int) bool {
+	h.ready.mu.Lock()
+	if conn != h.ready.conn || h.ready.synced {
+		h.ready.mu.Unlock()
+		return false
+	}
+	h.ready.synced = true
+	if h.ready.timer != nil {
+		h.ready.timer.Stop()
+		h.ready.timer = nil
+	}
+	h.ready.mu.Unlock()
+
+	if h.machine.Current() == status.Syncing {
+		_ = h.machine.Transition(status.Ready)
+	}
+	return true
+}
+
+// degrade reports a connection that is up but not working.
+func (h *EventHandler) degrade(reason string) {
+	current := h.machine.Current()
+	if current != status.Syncing && current != status.Ready {
+		return
+	}
+	h.logger.Warn("WhatsApp connection degraded", zap.String("reason", reason))
+	if h.machine.Transition(status.Degraded) == nil {
+		h.bus.Publish(bus.Event{
+			Kind:      "sync.degraded",
+			Timestamp: time.Now(),
+			Payload:   map[string]string{"reason": reason},
+		})
+	}
+}
+
+// restore leaves DEGRADED once the connection works again: READY if it
+// had caught up, else back to SYNCING.
+func (h *EventHandler) restore() {
+	if h.machine.Current() != status.Degraded {
+		return
+	}
+	h.ready.mu.Lock()
+	synced := h.ready.synced
+	h.ready.mu.Unlock()
+	h.logger.Info("WhatsApp connection restored")
+	if synced {
+		_ = h.machine.Transition(status.Ready)
+	} else {
+		_ = h.machine.Transition(status.Syncing)
+	}
+}